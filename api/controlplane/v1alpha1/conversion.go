@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+// ConvertTo converts this GardenerShootControlPlane to the hub version (v1alpha2).
+func (src *GardenerShootControlPlane) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*controlplanev1alpha2.GardenerShootControlPlane)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	convertSpecToHub(src.Spec.DeepCopy(), &dst.Spec)
+	convertStatusToHub(src.Status.DeepCopy(), &dst.Status)
+
+	// Version and CloudProfileName were dropped in v1alpha2, preserve them so that they can be restored when converting
+	// back to v1alpha1.
+	if src.Spec.Version != "" || src.Spec.CloudProfileName != nil {
+		return utilconversion.MarshalData(src, dst)
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha2) to this GardenerShootControlPlane.
+func (dst *GardenerShootControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*controlplanev1alpha2.GardenerShootControlPlane)
+
+	restored := &GardenerShootControlPlane{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	convertSpecFromHub(src.Spec.DeepCopy(), &dst.Spec)
+	convertStatusFromHub(src.Status.DeepCopy(), &dst.Status)
+
+	if ok {
+		dst.Spec.Version = restored.Spec.Version
+		// CloudProfileName was folded into CloudProfile, it is only restored if CloudProfile was not changed since.
+		if restored.Spec.CloudProfileName != nil &&
+			apiequality.Semantic.DeepEqual(dst.Spec.CloudProfile, cloudProfileReference(&restored.Spec)) {
+			dst.Spec.CloudProfileName = restored.Spec.CloudProfileName
+			dst.Spec.CloudProfile = restored.Spec.CloudProfile
+		}
+	}
+	return nil
+}
+
+// cloudProfileReference returns the CloudProfile reference of the given spec. The deprecated CloudProfileName is only
+// used if CloudProfile is not set, like Gardener does.
+func cloudProfileReference(in *GardenerShootControlPlaneSpec) *gardenercorev1beta1.CloudProfileReference {
+	if in.CloudProfile != nil || in.CloudProfileName == nil {
+		return in.CloudProfile
+	}
+	return &gardenercorev1beta1.CloudProfileReference{
+		Kind: constants.CloudProfileReferenceKindCloudProfile,
+		Name: *in.CloudProfileName,
+	}
+}
+
+func convertSpecToHub(in *GardenerShootControlPlaneSpec, out *controlplanev1alpha2.GardenerShootControlPlaneSpec) {
+	*out = controlplanev1alpha2.GardenerShootControlPlaneSpec{
+		ControlPlaneEndpoint:   in.ControlPlaneEndpoint,
+		ProjectNamespace:       in.ProjectNamespace,
+		Workerless:             in.Workerless,
+		Addons:                 in.Addons,
+		DNS:                    in.DNS,
+		Extensions:             in.Extensions,
+		Kubernetes:             in.Kubernetes,
+		Networking:             in.Networking,
+		Monitoring:             in.Monitoring,
+		Provider:               controlplanev1alpha2.ProviderGSCP(in.Provider),
+		Purpose:                in.Purpose,
+		SecretBindingName:      in.SecretBindingName,
+		Resources:              in.Resources,
+		Tolerations:            in.Tolerations,
+		ExposureClassName:      in.ExposureClassName,
+		SystemComponents:       in.SystemComponents,
+		ControlPlane:           in.ControlPlane,
+		SchedulerName:          in.SchedulerName,
+		CloudProfile:           cloudProfileReference(in),
+		CredentialsBindingName: in.CredentialsBindingName,
+		AccessRestrictions:     in.AccessRestrictions,
+	}
+}
+
+func convertSpecFromHub(in *controlplanev1alpha2.GardenerShootControlPlaneSpec, out *GardenerShootControlPlaneSpec) {
+	*out = GardenerShootControlPlaneSpec{
+		ControlPlaneEndpoint:   in.ControlPlaneEndpoint,
+		ProjectNamespace:       in.ProjectNamespace,
+		Workerless:             in.Workerless,
+		Addons:                 in.Addons,
+		DNS:                    in.DNS,
+		Extensions:             in.Extensions,
+		Kubernetes:             in.Kubernetes,
+		Networking:             in.Networking,
+		Monitoring:             in.Monitoring,
+		Provider:               ProviderGSCP(in.Provider),
+		Purpose:                in.Purpose,
+		SecretBindingName:      in.SecretBindingName,
+		Resources:              in.Resources,
+		Tolerations:            in.Tolerations,
+		ExposureClassName:      in.ExposureClassName,
+		SystemComponents:       in.SystemComponents,
+		ControlPlane:           in.ControlPlane,
+		SchedulerName:          in.SchedulerName,
+		CloudProfile:           in.CloudProfile,
+		CredentialsBindingName: in.CredentialsBindingName,
+		AccessRestrictions:     in.AccessRestrictions,
+	}
+}
+
+func convertStatusToHub(in *GardenerShootControlPlaneStatus, out *controlplanev1alpha2.GardenerShootControlPlaneStatus) {
+	*out = controlplanev1alpha2.GardenerShootControlPlaneStatus{
+		ShootStatus:                 in.ShootStatus,
+		Initialized:                 in.Initialized,
+		Ready:                       in.Ready,
+		Initialization:              controlplanev1alpha2.GardenerShootControlPlaneInitializationStatus(in.Initialization),
+		ExternalManagedControlPlane: in.ExternalManagedControlPlane,
+		Conditions:                  in.Conditions,
+	}
+}
+
+func convertStatusFromHub(in *controlplanev1alpha2.GardenerShootControlPlaneStatus, out *GardenerShootControlPlaneStatus) {
+	*out = GardenerShootControlPlaneStatus{
+		ShootStatus:                 in.ShootStatus,
+		Initialized:                 in.Initialized,
+		Ready:                       in.Ready,
+		Initialization:              GardenerShootControlPlaneInitializationStatus(in.Initialization),
+		ExternalManagedControlPlane: in.ExternalManagedControlPlane,
+		Conditions:                  in.Conditions,
+	}
+}
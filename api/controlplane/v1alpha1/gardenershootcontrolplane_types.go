@@ -11,15 +11,6 @@ import (
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
-const (
-	// GSCPReferenceNamespaceKey is the key used to store the namespace of the GardenerShootControlPlane in a reference.
-	GSCPReferenceNamespaceKey = "controlplane.cluster.x-k8s.io/gscp_namespace"
-	// GSCPReferenceNameKey is the key used to store the name of the GardenerShootControlPlane in a reference.
-	GSCPReferenceNameKey = "controlplane.cluster.x-k8s.io/gscp_name"
-	// GSCPReferenceClusterNameKey is the key used to store the name of the cluster in a reference.
-	GSCPReferenceClusterNameKey = "controlplane.cluster.x-k8s.io/gscp_cluster"
-)
-
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=gscp
@@ -47,6 +38,7 @@ type GardenerShootControlPlane struct {
 // The workers are managed through the GardenerWorkerPool CRD.
 type ProviderGSCP struct {
 	// Type is the type of the provider. This field is immutable.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
 	Type string `json:"type" protobuf:"bytes,1,opt,name=type"`
 	// ControlPlaneConfig contains the provider-specific control plane config blob. Please look up the concrete
 	// definition in the documentation of your provider extension.
@@ -63,6 +55,11 @@ type ProviderGSCP struct {
 
 // GardenerShootControlPlaneSpec represents the Spec of the Shoot Cluster,
 // as well as the fields defined by the Cluster API contract.
+// +kubebuilder:validation:XValidation:rule="!(has(self.secretBindingName) && has(self.credentialsBindingName))",message="secretBindingName and credentialsBindingName are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.secretBindingName) || !has(self.secretBindingName)",message="secretBindingName cannot be added"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.secretBindingName) || has(self.secretBindingName) || has(self.credentialsBindingName)",message="secretBindingName can only be removed when migrating to credentialsBindingName"
+// +kubebuilder:validation:XValidation:rule="has(self.exposureClassName) == has(oldSelf.exposureClassName)",message="exposureClassName is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.schedulerName) || has(self.schedulerName)",message="schedulerName cannot be removed"
 type GardenerShootControlPlaneSpec struct {
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
@@ -116,9 +113,12 @@ type GardenerShootControlPlaneSpec struct {
 	// The field is mutually exclusive with CredentialsBindingName.
 	// This field is immutable.
 	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="secretBindingName is immutable"
 	// Deprecated: Use CredentialsBindingName instead. See https://github.com/gardener/gardener/blob/master/docs/usage/shoot-operations/secretbinding-to-credentialsbinding-migration.md for migration instructions.
 	SecretBindingName *string `json:"secretBindingName,omitempty" protobuf:"bytes,13,opt,name=secretBindingName"`
 	// Resources holds a list of named resource references that can be referred to in extension configs by their names.
+	// Secrets and ConfigMaps in the namespace of the GardenerShootControlPlane which carry the label
+	// `controlplane.cluster.x-k8s.io/replicate: "true"` are replicated into the project namespace.
 	// +optional
 	Resources []gardenercorev1beta1.NamedResourceReference `json:"resources,omitempty" protobuf:"bytes,16,rep,name=resources"`
 	// Tolerations contains the tolerations for taints on seed clusters.
@@ -129,6 +129,7 @@ type GardenerShootControlPlaneSpec struct {
 	// ExposureClassName is the optional name of an exposure class to apply a control plane endpoint exposure strategy.
 	// This field is immutable.
 	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="exposureClassName is immutable"
 	ExposureClassName *string `json:"exposureClassName,omitempty" protobuf:"bytes,18,opt,name=exposureClassName"`
 	// SystemComponents contains the settings of system components in the control or data plane of the Shoot cluster.
 	// +optional
@@ -140,6 +141,7 @@ type GardenerShootControlPlaneSpec struct {
 	// If not specified, the default scheduler takes over.
 	// This field is immutable.
 	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="schedulerName is immutable"
 	SchedulerName *string `json:"schedulerName,omitempty" protobuf:"bytes,21,opt,name=schedulerName"`
 	// CloudProfile contains a reference to a CloudProfile or a NamespacedCloudProfile.
 	// +optional
@@ -174,6 +176,44 @@ type GardenerShootControlPlaneStatus struct {
 	// to check the operational state of the control plane.
 	// +optional
 	Ready bool `json:"ready"`
+
+	// Initialization provides observations of the GardenerShootControlPlane initialization process.
+	// NOTE: fields in this struct are part of the Cluster API v1beta2 contract and are used to orchestrate provisioning.
+	// +optional
+	Initialization GardenerShootControlPlaneInitializationStatus `json:"initialization,omitempty,omitzero"`
+
+	// ExternalManagedControlPlane is always true, as the control plane of a Shoot is managed by Gardener and does not
+	// run on nodes of the Shoot.
+	// NOTE: this field is part of the Cluster API v1beta2 contract.
+	// +optional
+	ExternalManagedControlPlane *bool `json:"externalManagedControlPlane,omitempty"`
+
+	// Conditions represent the observations of the current state of the GardenerShootControlPlane.
+	// Known condition types are Available, Paused and CredentialsBindingMigrated.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GardenerShootControlPlaneInitializationStatus provides observations of the GardenerShootControlPlane initialization
+// process.
+type GardenerShootControlPlaneInitializationStatus struct {
+	// ControlPlaneInitialized is true when the API server of the Shoot is initialized and can accept requests.
+	// The value of this field is never updated after initialization is completed.
+	// +optional
+	ControlPlaneInitialized *bool `json:"controlPlaneInitialized,omitempty"`
+}
+
+// GetConditions returns the conditions of the GardenerShootControlPlane.
+func (in *GardenerShootControlPlane) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions of the GardenerShootControlPlane.
+func (in *GardenerShootControlPlane) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
 }
 
 // +kubebuilder:object:root=true
@@ -10,6 +10,7 @@ package v1alpha1
 
 import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -40,6 +41,26 @@ func (in *GardenerShootControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GardenerShootControlPlaneInitializationStatus) DeepCopyInto(out *GardenerShootControlPlaneInitializationStatus) {
+	*out = *in
+	if in.ControlPlaneInitialized != nil {
+		in, out := &in.ControlPlaneInitialized, &out.ControlPlaneInitialized
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootControlPlaneInitializationStatus.
+func (in *GardenerShootControlPlaneInitializationStatus) DeepCopy() *GardenerShootControlPlaneInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GardenerShootControlPlaneInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerShootControlPlaneList) DeepCopyInto(out *GardenerShootControlPlaneList) {
 	*out = *in
@@ -185,6 +206,19 @@ func (in *GardenerShootControlPlaneSpec) DeepCopy() *GardenerShootControlPlaneSp
 func (in *GardenerShootControlPlaneStatus) DeepCopyInto(out *GardenerShootControlPlaneStatus) {
 	*out = *in
 	in.ShootStatus.DeepCopyInto(&out.ShootStatus)
+	in.Initialization.DeepCopyInto(&out.Initialization)
+	if in.ExternalManagedControlPlane != nil {
+		in, out := &in.ExternalManagedControlPlane, &out.ExternalManagedControlPlane
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootControlPlaneStatus.
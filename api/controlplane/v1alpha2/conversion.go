@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+// Hub marks GardenerShootControlPlane as a conversion hub.
+func (*GardenerShootControlPlane) Hub() {}
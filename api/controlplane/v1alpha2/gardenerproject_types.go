@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GardenerProjectFinalizer is the finalizer which ensures that the Gardener Project is deleted before the
+	// GardenerProject.
+	GardenerProjectFinalizer = "controlplane.cluster.x-k8s.io/gardenerproject"
+	// ProjectOwnerAnnotation is the annotation on a Gardener Project which references the GardenerProject owning it,
+	// in the format `[<logical cluster>/]<name>`. The logical cluster is only set when running against kcp.
+	ProjectOwnerAnnotation = "controlplane.cluster.x-k8s.io/project-owner"
+	// WorkspaceProjectName is the name of the GardenerProject which is created in every bound kcp workspace if
+	// workspace projects are enabled. Its namespace is the default ProjectNamespace of the workspace.
+	WorkspaceProjectName = "workspace"
+)
+
+// GardenerProjectSpec defines the desired state of GardenerProject.
+type GardenerProjectSpec struct {
+	// ProjectName is the name of the Project in the Gardener cluster.
+	// If not set, the name of this object is used, which must then not be longer than 10 characters. When running
+	// against kcp, a name derived from the logical cluster and the name of this object is used instead, as project
+	// names are unique across the Gardener cluster.
+	// This field is immutable.
+	// +optional
+	// +kubebuilder:validation:MaxLength=10
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectName is immutable"
+	ProjectName string `json:"projectName,omitempty"`
+	// Namespace is the namespace of the Project in the Gardener cluster.
+	// If not set, Gardener uses `garden-<project name>`.
+	// This field is immutable.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="namespace is immutable"
+	Namespace *string `json:"namespace,omitempty"`
+	// Description is a human-readable description of what the project is used for.
+	// +optional
+	Description *string `json:"description,omitempty"`
+	// Purpose is a human-readable explanation of the project's purpose.
+	// +optional
+	Purpose *string `json:"purpose,omitempty"`
+	// Owner is a subject representing a user name, an email address, or any other identifier of a user owning
+	// the project. If not set, Gardener makes the creator of the project, i.e. the provider, the owner.
+	// +optional
+	Owner *rbacv1.Subject `json:"owner,omitempty"`
+	// Members is a list of subjects representing a user name, an email address, or any other identifier of a user,
+	// group, or service account that has a certain role.
+	// +optional
+	Members []gardenercorev1beta1.ProjectMember `json:"members,omitempty"`
+}
+
+// GardenerProjectStatus defines the observed state of GardenerProject.
+type GardenerProjectStatus struct {
+	// ProjectName is the name of the Project in the Gardener cluster.
+	// +optional
+	ProjectName string `json:"projectName,omitempty"`
+	// Namespace is the namespace of the Project in the Gardener cluster. It can be used as ProjectNamespace of
+	// GardenerShootControlPlanes.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Phase is the phase of the Project.
+	// +optional
+	Phase gardenercorev1beta1.ProjectPhase `json:"phase,omitempty"`
+	// Ready indicates whether the Project and its namespace are ready.
+	Ready bool `json:"ready,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=gp
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Project",type="string",JSONPath=".status.projectName"
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".status.namespace"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GardenerProject is the Schema for the gardenerprojects API. A GardenerProject provisions a Project with its members
+// and namespace in the Gardener cluster.
+type GardenerProject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GardenerProjectSpec   `json:"spec,omitempty"`
+	Status GardenerProjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GardenerProjectList contains a list of GardenerProject.
+type GardenerProjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GardenerProject `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &GardenerProject{}, &GardenerProjectList{})
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// ConvertTo converts this GardenerShootCluster to the hub version (v1alpha2).
+func (src *GardenerShootCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrastructurev1alpha2.GardenerShootCluster)
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = infrastructurev1alpha2.GardenerShootClusterSpec(in.Spec)
+	dst.Status = infrastructurev1alpha2.GardenerShootClusterStatus{
+		Ready:          in.Status.Ready,
+		Initialization: infrastructurev1alpha2.GardenerShootClusterInitializationStatus(in.Status.Initialization),
+		Conditions:     in.Status.Conditions,
+		Maintenance:    convertMaintenanceStatusToHub(in.Status.Maintenance),
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha2) to this GardenerShootCluster.
+func (dst *GardenerShootCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*infrastructurev1alpha2.GardenerShootCluster).DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = GardenerShootClusterSpec(in.Spec)
+	dst.Status = GardenerShootClusterStatus{
+		Ready:          in.Status.Ready,
+		Initialization: GardenerShootClusterInitializationStatus(in.Status.Initialization),
+		Conditions:     in.Status.Conditions,
+		Maintenance:    convertMaintenanceStatusFromHub(in.Status.Maintenance),
+	}
+	return nil
+}
+
+func convertMaintenanceStatusToHub(in *GardenerShootClusterMaintenanceStatus) *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+		NextWindowStart: in.NextWindowStart,
+		NextWindowEnd:   in.NextWindowEnd,
+	}
+	for _, update := range in.PendingUpdates {
+		out.PendingUpdates = append(out.PendingUpdates, infrastructurev1alpha2.PendingUpdate{
+			Type:           infrastructurev1alpha2.PendingUpdateType(update.Type),
+			WorkerPool:     update.WorkerPool,
+			MachineImage:   update.MachineImage,
+			CurrentVersion: update.CurrentVersion,
+			TargetVersion:  update.TargetVersion,
+			ExpirationDate: update.ExpirationDate,
+			Forced:         update.Forced,
+		})
+	}
+	return out
+}
+
+func convertMaintenanceStatusFromHub(in *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus) *GardenerShootClusterMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := &GardenerShootClusterMaintenanceStatus{
+		NextWindowStart: in.NextWindowStart,
+		NextWindowEnd:   in.NextWindowEnd,
+	}
+	for _, update := range in.PendingUpdates {
+		out.PendingUpdates = append(out.PendingUpdates, PendingUpdate{
+			Type:           PendingUpdateType(update.Type),
+			WorkerPool:     update.WorkerPool,
+			MachineImage:   update.MachineImage,
+			CurrentVersion: update.CurrentVersion,
+			TargetVersion:  update.TargetVersion,
+			ExpirationDate: update.ExpirationDate,
+			Forced:         update.Forced,
+		})
+	}
+	return out
+}
+
+// ConvertTo converts this GardenerWorkerPool to the hub version (v1alpha2).
+func (src *GardenerWorkerPool) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrastructurev1alpha2.GardenerWorkerPool)
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = infrastructurev1alpha2.GardenerWorkerPoolSpec(in.Spec)
+	dst.Status = infrastructurev1alpha2.GardenerWorkerPoolStatus{
+		Ready:                     in.Status.Ready,
+		InfrastructureMachineKind: in.Status.InfrastructureMachineKind,
+		KubernetesVersion:         in.Status.KubernetesVersion,
+		Initialization:            infrastructurev1alpha2.GardenerWorkerPoolInitializationStatus(in.Status.Initialization),
+		Conditions:                in.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha2) to this GardenerWorkerPool.
+func (dst *GardenerWorkerPool) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*infrastructurev1alpha2.GardenerWorkerPool).DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = GardenerWorkerPoolSpec(in.Spec)
+	dst.Status = GardenerWorkerPoolStatus{
+		Ready:                     in.Status.Ready,
+		InfrastructureMachineKind: in.Status.InfrastructureMachineKind,
+		KubernetesVersion:         in.Status.KubernetesVersion,
+		Initialization:            GardenerWorkerPoolInitializationStatus(in.Status.Initialization),
+		Conditions:                in.Status.Conditions,
+	}
+	return nil
+}
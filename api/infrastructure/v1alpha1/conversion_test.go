@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+func TestFuzzyConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(AddToScheme(scheme))
+	utilruntime.Must(infrastructurev1alpha2.AddToScheme(scheme))
+
+	t.Run("for GardenerShootCluster", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &infrastructurev1alpha2.GardenerShootCluster{},
+		Spoke:  &GardenerShootCluster{},
+	}))
+	t.Run("for GardenerWorkerPool", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &infrastructurev1alpha2.GardenerWorkerPool{},
+		Spoke:  &GardenerWorkerPool{},
+	}))
+}
@@ -12,17 +12,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-const (
-	// GSWReferenceNamespaceKey is the key for the namespace in which the Gardener worker pool is referenced.
-	GSWReferenceNamespaceKey = "infrastructure.cluster.x-k8s.io/gsw_namespace"
-	// GSWReferenceNamePrefix is the prefix for the name of the Gardener worker pool.
-	GSWReferenceNamePrefix = "infrastructure.cluster.x-k8s.io/gsw_name-"
-	// GSWReferenceClusterNameKey is the key for the name of the Gardener worker pool in the ControlPlane object.
-	GSWReferenceClusterNameKey = "infrastructure.cluster.x-k8s.io/gsw_cluster"
-	// GSWTrue is a string representation of the boolean value true, used for annotations.
-	GSWTrue = "true"
-)
-
 // GardenerWorkerPoolSpec defines the desired state of GardenerWorkerPool.
 type GardenerWorkerPoolSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -109,6 +98,33 @@ type GardenerWorkerPoolSpec struct {
 type GardenerWorkerPoolStatus struct {
 	// Ready indicates whether the worker pool is ready.
 	Ready bool `json:"ready,omitempty"`
+	// InfrastructureMachineKind is the kind of the infrastructure machines which represent the nodes of the worker
+	// pool, as required by the MachinePool Machines contract of Cluster API.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+	// KubernetesVersion is the Kubernetes version of the nodes of the worker pool. It is updated once all nodes run the
+	// same version, e.g. after a rolling update.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Initialization provides observations of the GardenerWorkerPool initialization process.
+	// NOTE: fields in this struct are part of the Cluster API v1beta2 contract and are used to orchestrate provisioning.
+	// +optional
+	Initialization GardenerWorkerPoolInitializationStatus `json:"initialization,omitempty,omitzero"`
+	// Conditions represent the observations of the current state of the GardenerWorkerPool.
+	// Known condition types are KubernetesVersionValid.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GardenerWorkerPoolInitializationStatus provides observations of the GardenerWorkerPool initialization process.
+type GardenerWorkerPoolInitializationStatus struct {
+	// Provisioned is true when the worker pool is ready for the first time.
+	// The value of this field is never updated after provisioning is completed.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
 }
 
 // +kubebuilder:object:root=true
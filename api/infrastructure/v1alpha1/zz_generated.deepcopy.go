@@ -11,6 +11,7 @@ package v1alpha1
 import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -21,7 +22,7 @@ func (in *GardenerShootCluster) DeepCopyInto(out *GardenerShootCluster) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootCluster.
@@ -42,6 +43,26 @@ func (in *GardenerShootCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GardenerShootClusterInitializationStatus) DeepCopyInto(out *GardenerShootClusterInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootClusterInitializationStatus.
+func (in *GardenerShootClusterInitializationStatus) DeepCopy() *GardenerShootClusterInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GardenerShootClusterInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerShootClusterList) DeepCopyInto(out *GardenerShootClusterList) {
 	*out = *in
@@ -74,6 +95,36 @@ func (in *GardenerShootClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GardenerShootClusterMaintenanceStatus) DeepCopyInto(out *GardenerShootClusterMaintenanceStatus) {
+	*out = *in
+	if in.NextWindowStart != nil {
+		in, out := &in.NextWindowStart, &out.NextWindowStart
+		*out = (*in).DeepCopy()
+	}
+	if in.NextWindowEnd != nil {
+		in, out := &in.NextWindowEnd, &out.NextWindowEnd
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingUpdates != nil {
+		in, out := &in.PendingUpdates, &out.PendingUpdates
+		*out = make([]PendingUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootClusterMaintenanceStatus.
+func (in *GardenerShootClusterMaintenanceStatus) DeepCopy() *GardenerShootClusterMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GardenerShootClusterMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerShootClusterSpec) DeepCopyInto(out *GardenerShootClusterSpec) {
 	*out = *in
@@ -112,6 +163,19 @@ func (in *GardenerShootClusterSpec) DeepCopy() *GardenerShootClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerShootClusterStatus) DeepCopyInto(out *GardenerShootClusterStatus) {
 	*out = *in
+	in.Initialization.DeepCopyInto(&out.Initialization)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(GardenerShootClusterMaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerShootClusterStatus.
@@ -130,7 +194,7 @@ func (in *GardenerWorkerPool) DeepCopyInto(out *GardenerWorkerPool) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerWorkerPool.
@@ -151,6 +215,26 @@ func (in *GardenerWorkerPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GardenerWorkerPoolInitializationStatus) DeepCopyInto(out *GardenerWorkerPoolInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerWorkerPoolInitializationStatus.
+func (in *GardenerWorkerPoolInitializationStatus) DeepCopy() *GardenerWorkerPoolInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GardenerWorkerPoolInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerWorkerPoolList) DeepCopyInto(out *GardenerWorkerPoolList) {
 	*out = *in
@@ -317,6 +401,14 @@ func (in *GardenerWorkerPoolSpec) DeepCopy() *GardenerWorkerPoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GardenerWorkerPoolStatus) DeepCopyInto(out *GardenerWorkerPoolStatus) {
 	*out = *in
+	in.Initialization.DeepCopyInto(&out.Initialization)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GardenerWorkerPoolStatus.
@@ -328,3 +420,22 @@ func (in *GardenerWorkerPoolStatus) DeepCopy() *GardenerWorkerPoolStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingUpdate) DeepCopyInto(out *PendingUpdate) {
+	*out = *in
+	if in.ExpirationDate != nil {
+		in, out := &in.ExpirationDate, &out.ExpirationDate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingUpdate.
+func (in *PendingUpdate) DeepCopy() *PendingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
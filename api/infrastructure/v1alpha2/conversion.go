@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+// Hub marks GardenerShootCluster as a conversion hub.
+func (*GardenerShootCluster) Hub() {}
+
+// Hub marks GardenerWorkerPool as a conversion hub.
+func (*GardenerWorkerPool) Hub() {}
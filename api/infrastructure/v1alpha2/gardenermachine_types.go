@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GardenerMachineFinalizer is the finalizer which ensures that the node of a GardenerMachine is removed before the
+	// GardenerMachine is deleted.
+	GardenerMachineFinalizer = "infrastructure.cluster.x-k8s.io/gardenermachine"
+)
+
+// GardenerMachineSpec defines the desired state of GardenerMachine.
+type GardenerMachineSpec struct {
+	// ProviderID is the provider ID of the node.
+	// +optional
+	ProviderID *string `json:"providerID,omitempty"`
+	// NodeName is the name of the node in the Shoot.
+	NodeName string `json:"nodeName"`
+}
+
+// GardenerMachineStatus defines the observed state of GardenerMachine.
+type GardenerMachineStatus struct {
+	// Ready indicates whether the machine is provisioned, i.e. its node has joined the Shoot.
+	Ready bool `json:"ready,omitempty"`
+	// NodeReady indicates whether the node is ready.
+	NodeReady bool `json:"nodeReady,omitempty"`
+	// Zone is the availability zone of the node.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// MachineType is the machine type of the node.
+	// +optional
+	MachineType string `json:"machineType,omitempty"`
+	// Initialization provides observations of the GardenerMachine initialization process.
+	// NOTE: fields in this struct are part of the Cluster API v1beta2 contract and are used to orchestrate provisioning.
+	// +optional
+	Initialization GardenerMachineInitializationStatus `json:"initialization,omitempty,omitzero"`
+}
+
+// GardenerMachineInitializationStatus provides observations of the GardenerMachine initialization process.
+type GardenerMachineInitializationStatus struct {
+	// Provisioned is true when the node of the machine has joined the Shoot.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".status.zone"
+// +kubebuilder:printcolumn:name="Machine Type",type="string",JSONPath=".status.machineType"
+// +kubebuilder:printcolumn:name="Node Ready",type="boolean",JSONPath=".status.nodeReady"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GardenerMachine is the Schema for the gardenermachines API. A GardenerMachine represents a single node of a
+// GardenerWorkerPool and is created and deleted by the GardenerWorkerPool controller.
+type GardenerMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GardenerMachineSpec   `json:"spec,omitempty"`
+	Status GardenerMachineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GardenerMachineList contains a list of GardenerMachine.
+type GardenerMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GardenerMachine `json:"items"`
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GardenerShootClusterSpec defines the desired state of GardenerShootCluster.
+type GardenerShootClusterSpec struct {
+	// Hibernation contains information whether the Shoot is suspended or not.
+	// +optional
+	Hibernation *gardenercorev1beta1.Hibernation `json:"hibernation,omitempty" protobuf:"bytes,5,opt,name=hibernation"`
+	// Maintenance contains information about the time window for maintenance operations and which
+	// operations should be performed.
+	// +optional
+	Maintenance *gardenercorev1beta1.Maintenance `json:"maintenance,omitempty" protobuf:"bytes,8,opt,name=maintenance"`
+	// Region is a name of a region. This field is immutable.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"
+	Region string `json:"region" protobuf:"bytes,12,opt,name=region"`
+	// SeedName is the name of the seed cluster that runs the control plane of the Shoot.
+	// +optional
+	SeedName *string `json:"seedName,omitempty" protobuf:"bytes,14,opt,name=seedName"`
+	// SeedSelector is an optional selector which must match a seed's labels for the shoot to be scheduled on that seed.
+	// +optional
+	SeedSelector *gardenercorev1beta1.SeedSelector `json:"seedSelector,omitempty" protobuf:"bytes,15,opt,name=seedSelector"`
+}
+
+// GardenerShootClusterStatus defines the observed state of GardenerShootCluster.
+type GardenerShootClusterStatus struct {
+	// Ready denotes that the Seed where the Shoot is hosted is ready.
+	// NOTE: this field is part of the Cluster API contract and it is used to orchestrate provisioning.
+	// The value of this field is never updated after provisioning is completed. Please use conditions
+	// to check the operational state of the infa cluster.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Initialization provides observations of the GardenerShootCluster initialization process.
+	// NOTE: fields in this struct are part of the Cluster API v1beta2 contract and are used to orchestrate provisioning.
+	// +optional
+	Initialization GardenerShootClusterInitializationStatus `json:"initialization,omitempty,omitzero"`
+
+	// Conditions represent the observations of the current state of the GardenerShootCluster.
+	// Known condition types are Ready and Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Maintenance provides insight into the next maintenance time window of the Shoot and the automatic updates
+	// which are going to be performed by Gardener.
+	// +optional
+	Maintenance *GardenerShootClusterMaintenanceStatus `json:"maintenance,omitempty"`
+}
+
+// GardenerShootClusterInitializationStatus provides observations of the GardenerShootCluster initialization process.
+type GardenerShootClusterInitializationStatus struct {
+	// Provisioned is true when the Seed where the Shoot is hosted is ready for the first time.
+	// The value of this field is never updated after provisioning is completed.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// GardenerShootClusterMaintenanceStatus provides insight into the maintenance of the Shoot.
+type GardenerShootClusterMaintenanceStatus struct {
+	// NextWindowStart is the start of the next maintenance time window of the Shoot. If the Shoot is currently in its
+	// maintenance time window, it is the start of the current one.
+	// +optional
+	NextWindowStart *metav1.Time `json:"nextWindowStart,omitempty"`
+	// NextWindowEnd is the end of the next maintenance time window of the Shoot.
+	// +optional
+	NextWindowEnd *metav1.Time `json:"nextWindowEnd,omitempty"`
+	// PendingUpdates are the automatic updates Gardener is going to perform in a maintenance time window of the Shoot.
+	// +optional
+	PendingUpdates []PendingUpdate `json:"pendingUpdates,omitempty"`
+}
+
+// PendingUpdateType is the type of an automatic update.
+type PendingUpdateType string
+
+const (
+	// PendingUpdateTypeKubernetesVersion is the update of the Kubernetes version of the Shoot.
+	PendingUpdateTypeKubernetesVersion PendingUpdateType = "KubernetesVersion"
+	// PendingUpdateTypeMachineImageVersion is the update of the machine image version of a worker pool.
+	PendingUpdateTypeMachineImageVersion PendingUpdateType = "MachineImageVersion"
+)
+
+// PendingUpdate is an automatic update Gardener is going to perform in a maintenance time window of the Shoot.
+type PendingUpdate struct {
+	// Type is the type of the update.
+	Type PendingUpdateType `json:"type"`
+	// WorkerPool is the name of the worker pool whose machine image is updated.
+	// +optional
+	WorkerPool string `json:"workerPool,omitempty"`
+	// MachineImage is the name of the machine image which is updated.
+	// +optional
+	MachineImage string `json:"machineImage,omitempty"`
+	// CurrentVersion is the version which is currently used.
+	CurrentVersion string `json:"currentVersion"`
+	// TargetVersion is the version which is going to be used after the update.
+	TargetVersion string `json:"targetVersion"`
+	// ExpirationDate is the expiration date of the current version in the CloudProfile.
+	// +optional
+	ExpirationDate *metav1.Time `json:"expirationDate,omitempty"`
+	// Forced is true if the update is enforced because the current version expires, regardless of whether automatic
+	// updates are enabled in the maintenance settings of the Shoot. A forced update is performed in the first
+	// maintenance time window after the expiration date.
+	// +optional
+	Forced bool `json:"forced,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GardenerShootCluster is the Schema for the gardenershootclusters API.
+type GardenerShootCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GardenerShootClusterSpec   `json:"spec,omitempty"`
+	Status GardenerShootClusterStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the conditions of the GardenerShootCluster.
+func (in *GardenerShootCluster) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions of the GardenerShootCluster.
+func (in *GardenerShootCluster) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// GardenerShootClusterList contains a list of GardenerShootCluster.
+type GardenerShootClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GardenerShootCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register()
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DefaultedMachineImageAnnotation records the machine image (in the format `<name>:<version>`) that was defaulted by the webhook.
+	DefaultedMachineImageAnnotation = "infrastructure.cluster.x-k8s.io/defaulted-machine-image"
+	// DefaultedVolumeTypeAnnotation records the volume type that was defaulted by the webhook.
+	DefaultedVolumeTypeAnnotation = "infrastructure.cluster.x-k8s.io/defaulted-volume-type"
+	// ReplicasManagedByAutoscalerAnnotation records on a MachinePool that the replicas-managed-by annotation of Cluster
+	// API was set by the GardenerWorkerPool controller, so that it is only removed again if the controller set it.
+	ReplicasManagedByAutoscalerAnnotation = "infrastructure.cluster.x-k8s.io/replicas-managed-by-autoscaler"
+)
+
+const (
+	// KubernetesVersionValidCondition reports whether the Kubernetes version of the MachinePool of a GardenerWorkerPool
+	// complies with the version skew policy of Gardener. MachinePools are not validated by the webhooks of the provider,
+	// so a version which violates the policy is only reported by this condition and not applied to the worker pool.
+	KubernetesVersionValidCondition = "KubernetesVersionValid"
+	// KubernetesVersionValidReason is the reason of the KubernetesVersionValid condition if the version is valid or
+	// the MachinePool does not set a version.
+	KubernetesVersionValidReason = "Valid"
+	// KubernetesVersionInvalidReason is the reason of the KubernetesVersionValid condition if the version of the
+	// MachinePool violates the version skew policy.
+	KubernetesVersionInvalidReason = "Invalid"
+)
+
+// GardenerWorkerPoolSpec defines the desired state of GardenerWorkerPool.
+type GardenerWorkerPoolSpec struct {
+	// ProviderIDList is a list of provider IDs for nodes that belong to this worker pool.
+	// It is maintained by the provider, as required by the InfraMachinePool contract of Cluster API.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+	// Annotations is a map of key/value pairs for annotations for all the `Node` objects in this worker pool.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,1,rep,name=annotations"`
+	// CABundle is a certificate bundle which will be installed onto every machine of this worker pool.
+	// +optional
+	CABundle *string `json:"caBundle,omitempty" protobuf:"bytes,2,opt,name=caBundle"`
+	// CRI contains configurations of CRI support of every machine in the worker pool.
+	// Defaults to a CRI with name `containerd`.
+	// +optional
+	CRI *gardenercorev1beta1.CRI `json:"cri,omitempty" protobuf:"bytes,3,opt,name=cri"`
+	// Kubernetes contains configuration for Kubernetes components related to this worker pool.
+	// +optional
+	Kubernetes *gardenercorev1beta1.WorkerKubernetes `json:"kubernetes,omitempty" protobuf:"bytes,4,opt,name=kubernetes"`
+	// Labels is a map of key/value pairs for labels for all the `Node` objects in this worker pool.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,5,rep,name=labels"`
+	// Machine contains information about the machine type and image.
+	Machine gardenercorev1beta1.Machine `json:"machine" protobuf:"bytes,7,opt,name=machine"`
+	// Maximum is the maximum number of machines to create.
+	// This value is divided by the number of configured zones for a fair distribution.
+	Maximum int32 `json:"maximum" protobuf:"varint,8,opt,name=maximum"`
+	// Minimum is the minimum number of machines to create.
+	// This value is divided by the number of configured zones for a fair distribution.
+	Minimum int32 `json:"minimum" protobuf:"varint,9,opt,name=minimum"`
+	// MaxSurge is maximum number of machines that are created during an update.
+	// This value is divided by the number of configured zones for a fair distribution.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty" protobuf:"bytes,10,opt,name=maxSurge"`
+	// MaxUnavailable is the maximum number of machines that can be unavailable during an update.
+	// This value is divided by the number of configured zones for a fair distribution.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" protobuf:"bytes,11,opt,name=maxUnavailable"`
+	// ProviderConfig is the provider-specific configuration for this worker pool.
+	// +optional
+	ProviderConfig *runtime.RawExtension `json:"providerConfig,omitempty" protobuf:"bytes,12,opt,name=providerConfig"`
+	// Taints is a list of taints for all the `Node` objects in this worker pool.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty" protobuf:"bytes,13,rep,name=taints"`
+	// Volume contains information about the volume type and size.
+	// +optional
+	Volume *gardenercorev1beta1.Volume `json:"volume,omitempty" protobuf:"bytes,14,opt,name=volume"`
+	// DataVolumes contains a list of additional worker volumes.
+	// +optional
+	DataVolumes []gardenercorev1beta1.DataVolume `json:"dataVolumes,omitempty" protobuf:"bytes,15,rep,name=dataVolumes"`
+	// KubeletDataVolumeName contains the name of a dataVolume that should be used for storing kubelet state.
+	// +optional
+	KubeletDataVolumeName *string `json:"kubeletDataVolumeName,omitempty" protobuf:"bytes,16,opt,name=kubeletDataVolumeName"`
+	// Zones is a list of availability zones that are used to evenly distribute this worker pool. Optional
+	// as not every provider may support availability zones.
+	// +optional
+	Zones []string `json:"zones,omitempty" protobuf:"bytes,17,rep,name=zones"`
+	// SystemComponents contains configuration for system components related to this worker pool
+	// +optional
+	SystemComponents *gardenercorev1beta1.WorkerSystemComponents `json:"systemComponents,omitempty" protobuf:"bytes,18,opt,name=systemComponents"`
+	// MachineControllerManagerSettings contains configurations for different worker-pools. Eg. MachineDrainTimeout, MachineHealthTimeout.
+	// +optional
+	MachineControllerManagerSettings *gardenercorev1beta1.MachineControllerManagerSettings `json:"machineControllerManager,omitempty" protobuf:"bytes,19,opt,name=machineControllerManager"`
+	// Sysctls is a map of kernel settings to apply on all machines in this worker pool.
+	// +optional
+	Sysctls map[string]string `json:"sysctls,omitempty" protobuf:"bytes,20,rep,name=sysctls"`
+	// ClusterAutoscaler contains the cluster autoscaler configurations for the worker pool.
+	// +optional
+	ClusterAutoscaler *gardenercorev1beta1.ClusterAutoscalerOptions `json:"clusterAutoscaler,omitempty" protobuf:"bytes,21,opt,name=clusterAutoscaler"`
+	// Priority (or weight) is the importance by which this worker group will be scaled by cluster autoscaling.
+	// +optional
+	Priority *int32 `json:"priority,omitempty" protobuf:"varint,22,opt,name=priority"`
+	// UpdateStrategy specifies the machine update strategy for the worker pool.
+	// +optional
+	UpdateStrategy *gardenercorev1beta1.MachineUpdateStrategy `json:"updateStrategy,omitempty" protobuf:"bytes,23,opt,name=updateStrategy,casttype=MachineUpdateStrategy"`
+	// ControlPlane specifies that the shoot cluster control plane components should be running in this worker pool.
+	// This is only relevant for autonomous shoot clusters.
+	// +optional
+	ControlPlane *gardenercorev1beta1.WorkerControlPlane `json:"controlPlane,omitempty" protobuf:"bytes,24,opt,name=controlPlane"`
+}
+
+// GardenerWorkerPoolStatus defines the observed state of GardenerWorkerPool.
+type GardenerWorkerPoolStatus struct {
+	// Ready indicates whether the worker pool is ready.
+	Ready bool `json:"ready,omitempty"`
+	// InfrastructureMachineKind is the kind of the infrastructure machines which represent the nodes of the worker
+	// pool, as required by the MachinePool Machines contract of Cluster API.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+	// KubernetesVersion is the Kubernetes version of the nodes of the worker pool. It is updated once all nodes run the
+	// same version, e.g. after a rolling update.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Initialization provides observations of the GardenerWorkerPool initialization process.
+	// NOTE: fields in this struct are part of the Cluster API v1beta2 contract and are used to orchestrate provisioning.
+	// +optional
+	Initialization GardenerWorkerPoolInitializationStatus `json:"initialization,omitempty,omitzero"`
+	// Conditions represent the observations of the current state of the GardenerWorkerPool.
+	// Known condition types are KubernetesVersionValid.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GardenerWorkerPoolInitializationStatus provides observations of the GardenerWorkerPool initialization process.
+type GardenerWorkerPoolInitializationStatus struct {
+	// Provisioned is true when the worker pool is ready for the first time.
+	// The value of this field is never updated after provisioning is completed.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GardenerWorkerPool is the Schema for the gardenerworkerpools API.
+type GardenerWorkerPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GardenerWorkerPoolSpec   `json:"spec,omitempty"`
+	Status GardenerWorkerPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GardenerWorkerPoolList contains a list of GardenerWorkerPool.
+type GardenerWorkerPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GardenerWorkerPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register()
+}
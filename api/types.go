@@ -17,7 +17,9 @@ import (
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
 	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 )
 
 var (
@@ -31,7 +33,9 @@ func init() {
 
 	utilruntime.Must(clusterv1beta2.AddToScheme(Scheme))
 	utilruntime.Must(controlplanev1alpha1.AddToScheme(Scheme))
+	utilruntime.Must(controlplanev1alpha2.AddToScheme(Scheme))
 	utilruntime.Must(infrastructurev1alpha1.AddToScheme(Scheme))
+	utilruntime.Must(infrastructurev1alpha2.AddToScheme(Scheme))
 
 	utilruntime.Must(gardenercorev1beta1.AddToScheme(Scheme))
 	utilruntime.Must(kubernetes.AddGardenSchemeToScheme(Scheme))
@@ -9,19 +9,26 @@ import (
 	"crypto/tls"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/kcp-dev/multicluster-provider/apiexport"
 	apisv1alpha2 "github.com/kcp-dev/sdk/apis/apis/v1alpha2"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -31,12 +38,16 @@ import (
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 
 	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/clustercache"
 	controllercluster "github.com/gardener/cluster-api-provider-gardener/internal/controller/cluster"
 	controlplanecontroller "github.com/gardener/cluster-api-provider-gardener/internal/controller/controlplane"
 	infrastructurecontroller "github.com/gardener/cluster-api-provider-gardener/internal/controller/infrastructure"
+	providermetrics "github.com/gardener/cluster-api-provider-gardener/internal/metrics"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
 	"github.com/gardener/cluster-api-provider-gardener/internal/util"
-	webhookcontrolplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/internal/webhook/controlplane/v1alpha1"
-	webhookinfrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/internal/webhook/infrastructure/v1alpha1"
+	webhookcontrolplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/internal/webhook/controlplane/v1alpha2"
+	webhookinfrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/internal/webhook/infrastructure/v1alpha2"
 )
 
 const (
@@ -60,6 +71,12 @@ func main() {
 		gardenerKubeConfigPath                           string
 		tlsOpts                                          []func(*tls.Config)
 		syncPeriod                                       time.Duration
+		viewerKubeconfig                                 bool
+		projectNamespaces                                string
+		shards                                           int
+		shardingNamespace                                string
+		workspaceProjects                                bool
+		embeddedCAPICore                                 bool
 	)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +98,21 @@ func main() {
 	flag.StringVar(&gardenerKubeConfigPath, "gardener-kubeconfig", "", "Path to the Gardener kube-config")
 	flag.DurationVar(&syncPeriod, "sync-period", time.Minute*10,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
+	flag.BoolVar(&viewerKubeconfig, "viewer-kubeconfig", false,
+		"If set, a read-only <cluster>-viewer-kubeconfig Secret is maintained for every Cluster")
+	flag.StringVar(&projectNamespaces, "gardener-project-namespaces", "",
+		"Comma-separated list of Gardener project namespaces whose Shoots are watched. All namespaces are watched if empty.")
+	flag.IntVar(&shards, "shards", 0,
+		"Number of shards the reconciliation is distributed to across the replicas. "+
+			"Sharding replaces leader election, it is disabled if 0.")
+	flag.StringVar(&shardingNamespace, "sharding-namespace", "",
+		"Namespace of the sharding Leases. Defaults to the namespace of the Pod.")
+	flag.BoolVar(&workspaceProjects, "workspace-projects", false,
+		"If set, every kcp workspace binding the provider gets its own Gardener project, "+
+			"whose namespace is the default project namespace of the workspace. Only supported when running against kcp.")
+	flag.BoolVar(&embeddedCAPICore, "embedded-capi-core", false,
+		"If set, the provider runs its own Cluster and MachinePool controllers, so that the Cluster API core provider "+
+			"does not need to be deployed. Always enabled when running against kcp.")
 	ctrl.RegisterFlags(flag.CommandLine)
 	opts := zap.Options{
 		Development: true,
@@ -209,8 +241,17 @@ func main() {
 		// LeaderElectionReleaseOnCancel: true,
 		Cache: cache.Options{
 			SyncPeriod: &syncPeriod,
+			ByObject: map[client.Object]cache.ByObject{
+				// ConfigMaps are only read to replicate them into the project namespaces. Secrets cannot be restricted
+				// likewise, as the kubeconfig Secrets of the Clusters are read from the cache as well.
+				&corev1.ConfigMap{}: {Label: util.ReplicatedSelector()},
+			},
 		},
 	}
+	if shards > 0 && enableLeaderElection {
+		setupLog.Info("Disabling leader election, because sharding is enabled")
+		ctrlOptions.LeaderElection = false
+	}
 	restConfig := ctrl.GetConfigOrDie()
 
 	if isKcp, err = util.HasKcpAPIGroups(restConfig); err != nil {
@@ -219,7 +260,10 @@ func main() {
 
 	var provider util.ProviderWithRun
 	if isKcp {
-		setupLog.Info("Found KCP APIs, looking up virtual workspace URL")
+		setupLog.Info("Found KCP APIs, watching the APIExport virtual workspaces")
+		// The provider watches all endpoints of the APIExportEndpointSlice, i.e. the virtual workspaces of all kcp
+		// shards, and serves every logical cluster from the virtual workspace of its shard. The manager itself keeps
+		// using the provider workspace.
 		provider, err = apiexport.New(restConfig, apiExportName, apiexport.Options{
 			Scheme:        controlplanev1alpha1.Scheme,
 			ObjectToWatch: &apisv1alpha2.APIBinding{},
@@ -228,12 +272,6 @@ func main() {
 			setupLog.Error(err, "unable to create kcp Provider")
 			os.Exit(1)
 		}
-
-		restConfig, err = util.RestConfigForLogicalClusterHostingAPIExport(mgrContext, restConfig, apiExportName)
-		if err != nil {
-			setupLog.Error(err, "looking up virtual workspace URL")
-			os.Exit(1)
-		}
 	}
 
 	mgr, err := mcmanager.New(restConfig, provider, ctrlOptions)
@@ -251,6 +289,40 @@ func main() {
 		provider = util.NewSingleClusterProviderWithRun(cl)
 	}
 
+	if err = util.AddClusterNameIndexes(mgrContext, mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to add field indexes")
+		os.Exit(1)
+	}
+
+	var projectNamespaceList []string
+	if projectNamespaces != "" {
+		projectNamespaceList = strings.Split(projectNamespaces, ",")
+	}
+
+	if embeddedCAPICore && !isKcp {
+		hasCAPICore, err := util.HasCAPICoreControllers(mgrContext, restConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to determine if the Cluster API core controllers are present")
+			os.Exit(1)
+		}
+		if hasCAPICore {
+			setupLog.Error(errors.New("the Cluster API core controllers are deployed"),
+				"embedded Cluster API core cannot be combined with the Cluster API core provider")
+			os.Exit(1)
+		}
+	}
+
+	if workspaceProjects {
+		if !isKcp {
+			setupLog.Info("Ignoring workspace projects, because KCP API Group is not present")
+			workspaceProjects = false
+		} else if len(projectNamespaceList) > 0 {
+			setupLog.Error(errors.New("the namespaces of workspace projects are not known in advance"),
+				"workspace projects cannot be combined with a restricted list of project namespaces")
+			os.Exit(1)
+		}
+	}
+
 	// Create client from kubeconfig
 	gardenRestConfig, err := clientcmd.BuildConfigFromFlags("", gardenerKubeConfigPath)
 	if err != nil {
@@ -258,17 +330,44 @@ func main() {
 		os.Exit(1)
 	}
 
-	gardenMgr, err := mcmanager.New(gardenRestConfig, nil, manager.Options{
+	var sharder *sharding.Sharder
+	if shards > 0 {
+		if sharder, err = setupSharder(restConfig, shards, shardingNamespace); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		if err = mgr.GetLocalManager().Add(sharder); err != nil {
+			setupLog.Error(err, "unable to add sharder to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Sharding enabled", "shards", shards)
+	}
+
+	gardenOptions := manager.Options{
 		Logger:                  setupLog,
 		Scheme:                  controlplanev1alpha1.Scheme,
 		GracefulShutdownTimeout: ptr.To(5 * time.Second),
 		Cache: cache.Options{
 			SyncPeriod: &syncPeriod,
+			ByObject: map[client.Object]cache.ByObject{
+				&gardenercorev1beta1.Shoot{}: shootCacheOptions(projectNamespaceList),
+			},
+		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// Only single ConfigMaps of the projects are read, caching all ConfigMaps of the garden is not worth it.
+				DisableFor: []client.Object{&corev1.ConfigMap{}},
+			},
 		},
 		BaseContext: func() context.Context {
 			return mgrContext
 		},
-	})
+	}
+	if sharder != nil {
+		// Every replica only caches the Shoots of its shards.
+		gardenOptions.NewCache = sharder.NewCache(&gardenercorev1beta1.Shoot{}, controlplanev1alpha2.ShootShardLabel)
+	}
+	gardenMgr, err := mcmanager.New(gardenRestConfig, nil, gardenOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to build Gardener rest config")
 		os.Exit(1)
@@ -280,66 +379,134 @@ func main() {
 	}
 
 	localGardenManager := gardenMgr.GetLocalManager()
+	if err = providermetrics.RegisterGardenCacheMetrics(localGardenManager.GetCache()); err != nil {
+		setupLog.Error(err, "unable to register garden cache metrics")
+		os.Exit(1)
+	}
+	// The webhooks validate changes of all Shoots, not only of the Shoots cached by the replica.
+	gardenWebhookClient := localGardenManager.GetClient()
+	if sharder != nil {
+		if gardenWebhookClient, err = client.New(gardenRestConfig, client.Options{
+			HTTPClient: localGardenManager.GetHTTPClient(),
+			Scheme:     localGardenManager.GetScheme(),
+			Mapper:     localGardenManager.GetRESTMapper(),
+			Cache: &client.CacheOptions{
+				Reader:     localGardenManager.GetCache(),
+				DisableFor: []client.Object{&corev1.ConfigMap{}, &gardenercorev1beta1.Shoot{}},
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to create Gardener client for webhooks")
+			os.Exit(1)
+		}
+	}
 	localManager := mgr.GetLocalManager()
+	// The events of the controllers are emitted with the recorder of Cluster API, which drops them until initialized.
+	record.InitFromRecorder(localManager.GetEventRecorderFor("cluster-api-provider-gardener")) //nolint:staticcheck
+
+	workloadClusterCache := clustercache.New(localManager.GetScheme())
+	if err = localManager.Add(workloadClusterCache); err != nil {
+		setupLog.Error(err, "unable to add workload cluster cache to manager")
+		os.Exit(1)
+	}
 
 	// Create reconcilers
-	if isKcp {
-		setupLog.Info("Setting up Cluster reconciler, because KCP API Group is present")
+	if isKcp || embeddedCAPICore {
+		setupLog.Info("Setting up Cluster reconciler, because KCP API Group is present or embedded Cluster API core is enabled")
 		if err = (&controllercluster.ClusterController{
-			Manager: mgr,
+			Manager:      mgr,
+			ClusterCache: workloadClusterCache,
+			Sharder:      sharder,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "Cluster")
 			os.Exit(1)
 		}
 
-		setupLog.Info("Setting up MachinePool reconciler, because KCP API Group is present")
+		setupLog.Info("Setting up MachinePool reconciler, because KCP API Group is present or embedded Cluster API core is enabled")
 		if err = (&controllercluster.MachinePoolController{
 			Manager: mgr,
+			Sharder: sharder,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "MachinePool")
 			os.Exit(1)
 		}
 	}
 
-	if err = (&controlplanecontroller.GardenerShootControlPlaneReconciler{
+	if workspaceProjects {
+		setupLog.Info("Setting up WorkspaceProject reconciler, because workspace projects are enabled")
+		if err = (&controlplanecontroller.WorkspaceProjectReconciler{
+			Manager:       mgr,
+			APIExportName: apiExportName,
+			Sharder:       sharder,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "WorkspaceProject")
+			os.Exit(1)
+		}
+	}
+
+	if err = (&controlplanecontroller.GardenerProjectReconciler{
 		Manager:        mgr,
 		GardenerClient: localGardenManager.GetClient(),
-		IsKCP:          isKcp,
+		Sharder:        sharder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GardenerProject")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookcontrolplanev1alpha2.SetupGardenerProjectWebhookWithManager(localManager); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GardenerProject")
+			os.Exit(1)
+		}
+	}
+
+	if err = (&controlplanecontroller.GardenerShootControlPlaneReconciler{
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		ViewerKubeconfig:  viewerKubeconfig,
+		Sharder:           sharder,
+		WorkspaceProjects: workspaceProjects,
+		ClusterCache:      workloadClusterCache,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerShootControlPlane")
 		os.Exit(1)
 	}
 	if err = (&controlplanecontroller.GardenerShootControlPlaneReconciler{
-		Manager:         mgr,
-		GardenerClient:  localGardenManager.GetClient(),
-		IsKCP:           isKcp,
-		PrioritizeShoot: true,
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		PrioritizeShoot:   true,
+		ViewerKubeconfig:  viewerKubeconfig,
+		Sharder:           sharder,
+		WorkspaceProjects: workspaceProjects,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerShootControlPlane (prioritized Shoot)")
 		os.Exit(1)
 	}
 
 	if err = (&infrastructurecontroller.GardenerShootClusterReconciler{
-		Manager:        mgr,
-		GardenerClient: localGardenManager.GetClient(),
-		IsKCP:          isKcp,
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		Sharder:           sharder,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerShootCluster")
 		os.Exit(1)
 	}
 	if err = (&infrastructurecontroller.GardenerShootClusterReconciler{
-		Manager:         mgr,
-		GardenerClient:  localGardenManager.GetClient(),
-		IsKCP:           isKcp,
-		PrioritizeShoot: true,
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		PrioritizeShoot:   true,
+		Sharder:           sharder,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerShootCluster (prioritized Shoot)")
 		os.Exit(1)
 	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = webhookcontrolplanev1alpha1.
-			SetupGardenerShootControlPlaneWebhookWithManager(localManager, localGardenManager.GetClient()); err != nil {
+		if err = webhookcontrolplanev1alpha2.
+			SetupGardenerShootControlPlaneWebhookWithManager(localManager, gardenWebhookClient, projectNamespaceList); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "GardenerShootControlPlane")
 			os.Exit(1)
 		}
@@ -348,36 +515,45 @@ func main() {
 	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = webhookinfrastructurev1alpha1.
-			SetupGardenerShootClusterWebhookWithManager(localManager, localGardenManager.GetClient()); err != nil {
+		if err = webhookinfrastructurev1alpha2.
+			SetupGardenerShootClusterWebhookWithManager(localManager, gardenWebhookClient); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "GardenerShootCluster")
 			os.Exit(1)
 		}
 	}
 
 	if err = (&infrastructurecontroller.GardenerWorkerPoolReconciler{
-		Manager:        mgr,
-		GardenerClient: localGardenManager.GetClient(),
-		Scheme:         localManager.GetScheme(),
-		IsKCP:          isKcp,
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		Sharder:           sharder,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerWorkerPool")
 		os.Exit(1)
 	}
 	if err = (&infrastructurecontroller.GardenerWorkerPoolReconciler{
-		Manager:         mgr,
-		GardenerClient:  localGardenManager.GetClient(),
-		Scheme:          localManager.GetScheme(),
-		IsKCP:           isKcp,
-		PrioritizeShoot: true,
+		Manager:           mgr,
+		GardenerClient:    localGardenManager.GetClient(),
+		GardenerAPIReader: localGardenManager.GetAPIReader(),
+		PrioritizeShoot:   true,
+		ClusterCache:      workloadClusterCache,
+		Sharder:           sharder,
 	}).SetupWithManager(mgr, localGardenManager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GardenerWorkerPool (prioritized Shoot)")
 		os.Exit(1)
 	}
+	if err = (&infrastructurecontroller.GardenerMachineReconciler{
+		Manager:      mgr,
+		ClusterCache: workloadClusterCache,
+		Sharder:      sharder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GardenerMachine")
+		os.Exit(1)
+	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = webhookinfrastructurev1alpha1.
-			SetupGardenerWorkerPoolWebhookWithManager(localManager, localGardenManager.GetClient()); err != nil {
+		if err = webhookinfrastructurev1alpha2.
+			SetupGardenerWorkerPoolWebhookWithManager(localManager, gardenWebhookClient); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "GardenerWorkerPool")
 			os.Exit(1)
 		}
@@ -435,3 +611,41 @@ func ignoreCanceled(err error) error {
 	}
 	return err
 }
+
+// shootCacheOptions restricts the garden cache to Shoots managed by the provider, i.e. Shoots carrying the owner hash
+// label, in the given project namespaces. All namespaces are cached if no project namespace is given.
+func shootCacheOptions(projectNamespaces []string) cache.ByObject {
+	byObject := cache.ByObject{
+		Label: util.ManagedShootSelector(),
+	}
+	if len(projectNamespaces) > 0 {
+		byObject.Namespaces = map[string]cache.Config{}
+		for _, namespace := range projectNamespaces {
+			byObject.Namespaces[namespace] = cache.Config{}
+		}
+	}
+	return byObject
+}
+
+// setupSharder creates a Sharder whose Leases are maintained in the given namespace, or the namespace of the Pod.
+func setupSharder(config *rest.Config, shards int, namespace string) (*sharding.Sharder, error) {
+	c, err := client.New(config, client.Options{Scheme: controlplanev1alpha1.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	if namespace == "" {
+		if namespace, err = sharding.InClusterNamespace(); err != nil {
+			return nil, err
+		}
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine identity: %w", err)
+	}
+	return sharding.New(c, sharding.Options{
+		Shards:    shards,
+		Namespace: namespace,
+		Name:      "cluster-api-provider-gardener",
+		Identity:  identity,
+	})
+}
@@ -10,9 +10,18 @@ CAPI_DIR="$2"
 TMP_DIR=${REPO_ROOT}/tmp
 EXPORT_FILE=${REPO_ROOT}/schemas/gardener/apiexport-controlplane.cluster.x-k8s.io.yaml
 
+echo "📂 Copying Gardener CRDs"
+mkdir -p ${TMP_DIR}/gardener
+cp ${REPO_ROOT}/config/crd/bases/*.yaml ${TMP_DIR}/gardener -f
+
+# kcp cannot call conversion webhooks, hence only the storage version is served in kcp.
+echo "🚫 Removing non stored Gardener CRD versions"
+for gardener_crd in ${TMP_DIR}/gardener/*.yaml; do
+  yq -i '.spec.versions |= map(select(.storage == true))' "${gardener_crd}"
+done
+
 echo "⚙️ Generating APIResouceSchemas for gardener"
-mkdir -p ${TMP_DIR}
-apigen --input-dir ${REPO_ROOT}/config/crd/bases --output-dir ${TMP_DIR}
+apigen --input-dir ${TMP_DIR}/gardener --output-dir ${TMP_DIR}
 
 new_schema_files=$(find ${TMP_DIR} -type f | grep -E 'apiresourceschema.*\.yaml$')
 
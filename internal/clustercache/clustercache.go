@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clustercache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+const (
+	// cacheSyncTimeout is the time to wait for the caches of a workload cluster to be synced.
+	cacheSyncTimeout = 30 * time.Second
+	// eventBufferSize is the size of the buffer for node events which are not yet consumed by the controller.
+	eventBufferSize = 1024
+)
+
+// ErrClusterNotConnected is returned if there is no kubeconfig Secret for the workload cluster (yet).
+var ErrClusterNotConnected = errors.New("workload cluster is not connected")
+
+// Key identifies a CAPI Cluster, in the logical cluster with the given name if running against kcp.
+type Key struct {
+	ClusterName multicluster.ClusterName
+	client.ObjectKey
+}
+
+// ClusterCache maintains a client and a cache for the workload cluster (the Shoot) of every CAPI Cluster, similar to
+// the ClusterCache of CAPI. The access is created from the `<cluster>-kubeconfig` Secret and recreated whenever the
+// kubeconfig is rotated. Node events of all workload clusters are emitted as GardenerWorkerPool reconcile requests.
+type ClusterCache struct {
+	scheme *runtime.Scheme
+
+	// lock guards ctx, accessors and keyLocks. It is never held while connecting to a workload cluster.
+	lock      sync.Mutex
+	ctx       context.Context
+	accessors map[Key]*accessor
+	// keyLocks serialize connecting to and disconnecting from the same workload cluster, so that an unreachable
+	// workload cluster only blocks the reconcilers of its own CAPI Cluster. They are removed once the workload cluster
+	// is disconnected and the lock is not held or waited for anymore.
+	keyLocks map[Key]*keyLock
+	events   chan event.TypedGenericEvent[mcreconcile.Request]
+}
+
+type keyLock struct {
+	sync.Mutex
+	// users is the number of callers holding or waiting for the lock. It is guarded by the lock of the ClusterCache.
+	users int
+}
+
+type accessor struct {
+	kubeconfigChecksum string
+	cluster            cluster.Cluster
+	cancel             context.CancelFunc
+}
+
+// New creates a new ClusterCache. It must be added to the manager to be started.
+func New(scheme *runtime.Scheme) *ClusterCache {
+	return &ClusterCache{
+		scheme:    scheme,
+		accessors: map[Key]*accessor{},
+		keyLocks:  map[Key]*keyLock{},
+		events:    make(chan event.TypedGenericEvent[mcreconcile.Request], eventBufferSize),
+	}
+}
+
+// Start implements manager.Runnable. It blocks until the context is cancelled and stops the caches of all workload
+// clusters afterwards.
+func (cc *ClusterCache) Start(ctx context.Context) error {
+	cc.lock.Lock()
+	cc.ctx = ctx
+	cc.lock.Unlock()
+
+	<-ctx.Done()
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	for key, a := range cc.accessors {
+		a.cancel()
+		delete(cc.accessors, key)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The workload clusters are only accessed by
+// reconcilers, which require leader election.
+func (cc *ClusterCache) NeedLeaderElection() bool {
+	return true
+}
+
+// GetClient returns a client for the workload cluster of the given CAPI Cluster, which reads from the cache. The
+// access is created, or recreated after a kubeconfig rotation, from the kubeconfig Secret read with the given client.
+// Returns ErrClusterNotConnected if the kubeconfig Secret does not exist.
+func (cc *ClusterCache) GetClient(ctx context.Context, c client.Client, key Key) (client.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: key.Name + "-kubeconfig"}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			cc.Disconnect(key)
+			return nil, ErrClusterNotConnected
+		}
+		return nil, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+	kubeconfig, ok := secret.Data["value"]
+	if !ok {
+		return nil, fmt.Errorf("could not find kubeconfig in Secret %s", client.ObjectKeyFromObject(secret))
+	}
+	checksum := sha256.Sum256(kubeconfig)
+
+	cc.lockKey(key)
+	defer cc.unlockKey(key)
+
+	cc.lock.Lock()
+	cacheCtx := cc.ctx
+	a, ok := cc.accessors[key]
+	if ok && a.kubeconfigChecksum != hex.EncodeToString(checksum[:]) {
+		runtimelog.FromContext(ctx).Info("Kubeconfig of workload cluster changed, recreating access", "key", key)
+		a.cancel()
+		delete(cc.accessors, key)
+		ok = false
+	}
+	cc.lock.Unlock()
+
+	if cacheCtx == nil {
+		return nil, errors.New("cluster cache is not started")
+	}
+	if ok {
+		return a.cluster.GetClient(), nil
+	}
+
+	a, err := cc.connect(cacheCtx, key, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	a.kubeconfigChecksum = hex.EncodeToString(checksum[:])
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	if cc.ctx == nil || cc.ctx.Err() != nil {
+		// The cluster cache was stopped while connecting.
+		a.cancel()
+		return nil, errors.New("cluster cache is stopped")
+	}
+	cc.accessors[key] = a
+	return a.cluster.GetClient(), nil
+}
+
+// Disconnect stops the cache of the workload cluster of the given CAPI Cluster, if any. It must be called once the CAPI
+// Cluster is deleted, so that the informers of the workload cluster are stopped.
+func (cc *ClusterCache) Disconnect(key Key) {
+	cc.lockKey(key)
+	defer cc.unlockKey(key)
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	if a, ok := cc.accessors[key]; ok {
+		a.cancel()
+		delete(cc.accessors, key)
+	}
+}
+
+// lockKey acquires the lock for connecting to and disconnecting from the workload cluster of the given CAPI Cluster.
+// It must be released with unlockKey.
+func (cc *ClusterCache) lockKey(key Key) {
+	cc.lock.Lock()
+	l, ok := cc.keyLocks[key]
+	if !ok {
+		l = &keyLock{}
+		cc.keyLocks[key] = l
+	}
+	l.users++
+	cc.lock.Unlock()
+
+	l.Lock()
+}
+
+// unlockKey releases the lock acquired with lockKey. The lock is removed if the workload cluster is not connected and
+// nobody else holds or waits for the lock.
+func (cc *ClusterCache) unlockKey(key Key) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	l := cc.keyLocks[key]
+	l.Unlock()
+	l.users--
+	if _, connected := cc.accessors[key]; !connected && l.users == 0 {
+		delete(cc.keyLocks, key)
+	}
+}
+
+// WorkerPoolSource returns a source which emits a reconcile request for the GardenerWorkerPool of a node whenever a node
+// in a workload cluster is added, removed or changes in a relevant way. It must only be used by a single controller.
+func (cc *ClusterCache) WorkerPoolSource() source.TypedSource[mcreconcile.Request] {
+	return source.TypedChannel[mcreconcile.Request, mcreconcile.Request](
+		cc.events,
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, req mcreconcile.Request) []mcreconcile.Request {
+			return []mcreconcile.Request{req}
+		}),
+	)
+}
+
+// connect creates and starts the cache for the workload cluster and registers the node event handler. The caller must
+// hold the lock of the key, but not the lock of the ClusterCache, as waiting for the cache to sync may take a while.
+func (cc *ClusterCache) connect(cacheCtx context.Context, key Key, kubeconfig []byte) (*accessor, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	cl, err := cluster.New(config, func(o *cluster.Options) {
+		o.Scheme = cc.scheme
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workload cluster: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(cacheCtx)
+	go func() {
+		if err := cl.Start(ctx); err != nil {
+			runtimelog.FromContext(ctx).Error(err, "Cache of workload cluster stopped", "key", key)
+		}
+	}()
+
+	informer, err := cl.GetCache().GetInformer(ctx, &corev1.Node{})
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to get node informer: %w", err)
+	}
+	if _, err := informer.AddEventHandler(cc.nodeEventHandler(ctx, key)); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to add node event handler: %w", err)
+	}
+
+	syncCtx, syncCancel := context.WithTimeout(ctx, cacheSyncTimeout)
+	defer syncCancel()
+	if !cl.GetCache().WaitForCacheSync(syncCtx) {
+		cancel()
+		return nil, fmt.Errorf("failed to sync cache of workload cluster %s", key)
+	}
+
+	return &accessor{cluster: cl, cancel: cancel}, nil
+}
+
+func (cc *ClusterCache) nodeEventHandler(ctx context.Context, key Key) toolscache.ResourceEventHandler {
+	enqueue := func(obj any) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		node, ok := obj.(*corev1.Node)
+		if !ok {
+			return
+		}
+		pool, ok := node.Labels[v1beta1constants.LabelWorkerPool]
+		if !ok {
+			return
+		}
+		select {
+		case cc.events <- event.TypedGenericEvent[mcreconcile.Request]{Object: mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKey{Namespace: key.Namespace, Name: pool}},
+			ClusterName: key.ClusterName,
+		}}:
+		case <-ctx.Done():
+		}
+	}
+
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(oldObj, newObj any) {
+			oldNode, ok := oldObj.(*corev1.Node)
+			if !ok {
+				return
+			}
+			newNode, ok := newObj.(*corev1.Node)
+			if !ok {
+				return
+			}
+			if nodeChanged(oldNode, newNode) {
+				enqueue(oldNode)
+				enqueue(newNode)
+			}
+		},
+		DeleteFunc: enqueue,
+	}
+}
+
+// nodeChanged returns true if the node changed in a way which is relevant for its GardenerWorkerPool. Status updates
+// like heartbeats are ignored.
+func nodeChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Spec.ProviderID != newNode.Spec.ProviderID ||
+		oldNode.Labels[v1beta1constants.LabelWorkerPool] != newNode.Labels[v1beta1constants.LabelWorkerPool] ||
+		providerutil.IsNodeReady(oldNode) != providerutil.IsNodeReady(newNode)
+}
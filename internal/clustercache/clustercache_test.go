@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clustercache
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ClusterCache", func() {
+	var (
+		cc      *ClusterCache
+		handler toolscache.ResourceEventHandler
+		node    *corev1.Node
+		key     = Key{ClusterName: "root:org", ObjectKey: client.ObjectKey{Namespace: "default", Name: "foo"}}
+	)
+
+	BeforeEach(func() {
+		cc = New(runtime.NewScheme())
+		handler = cc.nodeEventHandler(context.Background(), key)
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: map[string]string{"worker.gardener.cloud/pool": "pool-1"},
+			},
+			Spec: corev1.NodeSpec{ProviderID: "aws:///eu-west-1a/i-1"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+			},
+		}
+	})
+
+	poolRequest := func(name string) mcreconcile.Request {
+		return mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: name}},
+			ClusterName: "root:org",
+		}
+	}
+
+	It("should emit a request for the worker pool of an added node", func() {
+		handler.OnAdd(node, false)
+
+		Expect(cc.events).To(Receive(HaveField("Object", Equal(poolRequest("pool-1")))))
+	})
+
+	It("should emit a request for the worker pool of a deleted node", func() {
+		handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "node-1", Obj: node})
+
+		Expect(cc.events).To(Receive(HaveField("Object", Equal(poolRequest("pool-1")))))
+	})
+
+	It("should ignore nodes without worker pool", func() {
+		delete(node.Labels, "worker.gardener.cloud/pool")
+		handler.OnAdd(node, false)
+
+		Expect(cc.events).NotTo(Receive())
+	})
+
+	It("should ignore irrelevant node updates", func() {
+		newNode := node.DeepCopy()
+		newNode.Status.Conditions[0].LastHeartbeatTime = metav1.Now()
+		handler.OnUpdate(node, newNode)
+
+		Expect(cc.events).NotTo(Receive())
+	})
+
+	It("should emit a request if the node becomes ready", func() {
+		newNode := node.DeepCopy()
+		newNode.Status.Conditions[0].Status = corev1.ConditionTrue
+		handler.OnUpdate(node, newNode)
+
+		Expect(cc.events).To(Receive(HaveField("Object", Equal(poolRequest("pool-1")))))
+	})
+
+	It("should emit requests for both worker pools if the node moves", func() {
+		newNode := node.DeepCopy()
+		newNode.Labels["worker.gardener.cloud/pool"] = "pool-2"
+		handler.OnUpdate(node, newNode)
+
+		Expect(cc.events).To(Receive(HaveField("Object", Equal(poolRequest("pool-1")))))
+		Expect(cc.events).To(Receive(HaveField("Object", Equal(poolRequest("pool-2")))))
+	})
+
+	It("should fail to get a client before it is started", func() {
+		c := fakeClientWithKubeconfigSecret()
+		_, err := cc.GetClient(context.Background(), c, key)
+		Expect(err).To(MatchError("cluster cache is not started"))
+	})
+
+	It("should report that the cluster is not connected without kubeconfig Secret", func() {
+		c := fakeclient.NewClientBuilder().Build()
+		_, err := cc.GetClient(context.Background(), c, key)
+		Expect(err).To(MatchError(ErrClusterNotConnected))
+	})
+
+	It("should not block other workload clusters while connecting to a workload cluster", func() {
+		unreachable := Key{ObjectKey: client.ObjectKey{Namespace: "default", Name: "unreachable"}}
+		cc.lockKey(unreachable)
+		defer cc.unlockKey(unreachable)
+
+		c := fakeClientWithKubeconfigSecret()
+		_, err := cc.GetClient(context.Background(), c, key)
+		Expect(err).To(MatchError("cluster cache is not started"))
+	})
+
+	It("should stop the cache of a disconnected workload cluster", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cc.accessors[key] = &accessor{cancel: cancel}
+
+		cc.Disconnect(key)
+
+		Expect(cc.accessors).NotTo(HaveKey(key))
+		Expect(ctx.Err()).To(MatchError(context.Canceled))
+		Expect(cc.keyLocks).NotTo(HaveKey(key))
+	})
+
+	It("should keep the lock of a workload cluster while it is connected or the lock is held", func() {
+		cc.accessors[key] = &accessor{cancel: func() {}}
+		cc.lockKey(key)
+		cc.unlockKey(key)
+		Expect(cc.keyLocks).To(HaveKey(key))
+
+		cc.lockKey(key)
+		disconnected := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			cc.Disconnect(key)
+			close(disconnected)
+		}()
+		Eventually(func() int {
+			cc.lock.Lock()
+			defer cc.lock.Unlock()
+			return cc.keyLocks[key].users
+		}).Should(Equal(2))
+		cc.unlockKey(key)
+
+		Eventually(disconnected).Should(BeClosed())
+		Expect(cc.keyLocks).NotTo(HaveKey(key))
+	})
+
+	It("should remove the lock of a workload cluster which could not be connected", func() {
+		c := fakeClientWithKubeconfigSecret()
+		_, err := cc.GetClient(context.Background(), c, key)
+		Expect(err).To(HaveOccurred())
+
+		Expect(cc.keyLocks).NotTo(HaveKey(key))
+	})
+})
+
+func fakeClientWithKubeconfigSecret() client.Client {
+	return fakeclient.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-kubeconfig", Namespace: "default"},
+		Data:       map[string][]byte{"value": []byte("kubeconfig")},
+	}).Build()
+}
@@ -15,27 +15,47 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/clustercache"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 // ClusterController mocks the cluster-api Cluster controller.
-// This _ONLY_ works with the Gardener provider, as no dynamic watching is being done here.
+// This _ONLY_ works with the Gardener provider, as no dynamic watching is being done here. The status of the Cluster is
+// aggregated from the GardenerShootControlPlane, the GardenerShootCluster and the GardenerWorkerPools of its
+// MachinePools.
 type ClusterController struct {
 	Manager mcmanager.Manager
+	// ClusterCache is disconnected from the workload cluster once the Cluster is deleted. It is optional.
+	ClusterCache *clustercache.ClusterCache
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
 }
 
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters/finalizers,verbs=update
+
 // Reconcile reconciles the Cluster resource.
 func (r *ClusterController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("cluster-object", req.NamespacedName, "cluster", req.ClusterName)
 
+	if !r.Sharder.Handles("cluster", req) {
+		return ctrl.Result{}, nil
+	}
+
 	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -47,11 +67,17 @@ func (r *ClusterController) Reconcile(ctx context.Context, req mcreconcile.Reque
 	if err := c.Get(ctx, req.NamespacedName, &cluster); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("resource no longer exists")
+			r.Sharder.Forget("cluster", req)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	if setPausedCondition(&cluster) {
+		log.Info("Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, c.Status().Update(ctx, &cluster)
+	}
+
 	patch := client.MergeFrom(cluster.DeepCopy())
 	if controllerutil.AddFinalizer(&cluster, clusterv1beta2.ClusterFinalizer) {
 		if err := c.Patch(ctx, &cluster, patch); err != nil {
@@ -59,103 +85,109 @@ func (r *ClusterController) Reconcile(ctx context.Context, req mcreconcile.Reque
 		}
 	}
 
-	// Check if the cluster is being deleted
 	if !cluster.DeletionTimestamp.IsZero() {
-		log.Info("Cluster is being deleted")
-		if cluster.Status.Phase != string(clusterv1beta2.ClusterPhaseDeleting) {
-			cluster.Status.Phase = string(clusterv1beta2.ClusterPhaseDeleting)
-			if err := c.Status().Update(ctx, &cluster); err != nil {
-				log.Error(err, "unable to update cluster status")
-				return ctrl.Result{}, err
-			}
-		}
-		// Check whether the gscp and gsc are still present
-		gscp := &controlplanev1alpha1.GardenerShootControlPlane{}
-		gscpErr := c.Get(ctx, client.ObjectKey{
-			Name:      cluster.Spec.ControlPlaneRef.Name,
-			Namespace: cluster.Namespace,
-		}, gscp)
-		if gscpErr == nil {
-			if err := c.Delete(ctx, gscp); err != nil {
-				log.Error(err, "unable to delete gscp")
-				return ctrl.Result{}, err
-			}
-		}
-
-		infraCluster := &infrastructurev1alpha1.GardenerShootCluster{}
-		infrErr := c.Get(ctx, client.ObjectKey{
-			Name:      cluster.Spec.InfrastructureRef.Name,
-			Namespace: cluster.Namespace,
-		}, infraCluster)
-		if infrErr == nil {
-			if err := c.Delete(ctx, infraCluster); err != nil {
-				log.Error(err, "unable to delete gsc")
-				return ctrl.Result{}, err
-			}
-		}
-
-		if apierrors.IsNotFound(gscpErr) && apierrors.IsNotFound(infrErr) {
-			log.Info("Cluster deletion complete")
-			controllerutil.RemoveFinalizer(&cluster, clusterv1beta2.ClusterFinalizer)
-			if err := c.Update(ctx, &cluster); err != nil {
-				log.Error(err, "unable to remove finalizer")
-				return ctrl.Result{}, err
-			}
-		}
+		return r.reconcileDelete(runtimelog.IntoContext(ctx, log), c, &cluster, req.ClusterName)
 	}
 
 	// Mocking setting the Owner reference for GardenerShootControlPlanes
-	gscp := &controlplanev1alpha1.GardenerShootControlPlane{}
+	gscp := &controlplanev1alpha2.GardenerShootControlPlane{}
 	if err := c.Get(ctx, client.ObjectKey{
 		Name:      cluster.Spec.ControlPlaneRef.Name,
 		Namespace: cluster.Namespace,
 	}, gscp); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("could not find respective GSCP. Requeueing.")
-			return ctrl.Result{Requeue: true}, nil
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, err
-	}
-
-	if err := ensureOwnerRef(ctx, c, gscp, &cluster); err != nil {
-		log.Error(err, "unable to ensure OwnerRef on GSC")
+		gscp = nil
+	} else if err := ensureOwnerRef(ctx, c, gscp, &cluster); err != nil {
+		log.Error(err, "unable to ensure OwnerRef on GSCP")
 		return ctrl.Result{}, err
 	}
 
 	// Mocking setting the Owner reference for GardenerShootClusters
-	infraCluster := &infrastructurev1alpha1.GardenerShootCluster{}
+	infraCluster := &infrastructurev1alpha2.GardenerShootCluster{}
 	if err := c.Get(ctx, client.ObjectKey{
 		Name:      cluster.Spec.InfrastructureRef.Name,
 		Namespace: cluster.Namespace,
 	}, infraCluster); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("could not find respective GSC. Requeueing.")
-			return ctrl.Result{Requeue: true}, nil
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
 		}
+		infraCluster = nil
+	} else if err := ensureOwnerRef(ctx, c, infraCluster, &cluster); err != nil {
+		log.Error(err, "unable to ensure OwnerRef on GSC")
 		return ctrl.Result{}, err
 	}
 
-	if err := ensureOwnerRef(ctx, c, infraCluster, &cluster); err != nil {
-		log.Error(err, "unable to ensure OwnerRef on GSC")
+	workers, err := getWorkers(ctx, c, &cluster)
+	if err != nil {
+		log.Error(err, "unable to get workers")
 		return ctrl.Result{}, err
 	}
 
-	cluster.Status = clusterv1beta2.ClusterStatus{
-		Phase: string(clusterv1beta2.ClusterPhaseProvisioned),
-		Conditions: []metav1.Condition{
-			{Type: clusterv1beta2.ClusterInfrastructureReadyCondition, Status: metav1.ConditionTrue, Reason: clusterv1beta2.ReadyReason},
-			{Type: clusterv1beta2.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue, Reason: clusterv1beta2.ClusterControlPlaneInitializedReason},
-		},
-		ObservedGeneration: cluster.Generation,
+	setStatus(&cluster, gscp, infraCluster, workers)
+	if err := c.Status().Update(ctx, &cluster); err != nil {
+		log.Error(err, "unable to update cluster status")
+		return ctrl.Result{}, err
 	}
-	if !gscp.Status.Initialized || !infraCluster.Status.Ready {
-		cluster.Status.Phase = string(clusterv1beta2.ClusterPhaseProvisioning)
+
+	if gscp == nil || infraCluster == nil {
+		log.Info("could not find respective GSCP or GSC. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
 	}
-	if err := c.Status().Update(ctx, &cluster); err != nil {
+	return ctrl.Result{}, nil
+}
+
+func (r *ClusterController) reconcileDelete(ctx context.Context, c client.Client, cluster *clusterv1beta2.Cluster, clusterName multicluster.ClusterName) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx)
+	log.Info("Cluster is being deleted")
+
+	// Check whether the gscp and gsc are still present
+	gscp := &controlplanev1alpha2.GardenerShootControlPlane{}
+	gscpErr := c.Get(ctx, client.ObjectKey{
+		Name:      cluster.Spec.ControlPlaneRef.Name,
+		Namespace: cluster.Namespace,
+	}, gscp)
+	if gscpErr == nil && gscp.DeletionTimestamp.IsZero() {
+		if err := c.Delete(ctx, gscp); err != nil {
+			log.Error(err, "unable to delete gscp")
+			return ctrl.Result{}, err
+		}
+	} else if gscpErr != nil && !apierrors.IsNotFound(gscpErr) {
+		return ctrl.Result{}, gscpErr
+	}
+
+	infraCluster := &infrastructurev1alpha2.GardenerShootCluster{}
+	infrErr := c.Get(ctx, client.ObjectKey{
+		Name:      cluster.Spec.InfrastructureRef.Name,
+		Namespace: cluster.Namespace,
+	}, infraCluster)
+	if infrErr == nil && infraCluster.DeletionTimestamp.IsZero() {
+		if err := c.Delete(ctx, infraCluster); err != nil {
+			log.Error(err, "unable to delete gsc")
+			return ctrl.Result{}, err
+		}
+	} else if infrErr != nil && !apierrors.IsNotFound(infrErr) {
+		return ctrl.Result{}, infrErr
+	}
+
+	setDeletingStatus(cluster, !apierrors.IsNotFound(gscpErr), !apierrors.IsNotFound(infrErr))
+	if err := c.Status().Update(ctx, cluster); err != nil {
 		log.Error(err, "unable to update cluster status")
 		return ctrl.Result{}, err
 	}
 
+	if apierrors.IsNotFound(gscpErr) && apierrors.IsNotFound(infrErr) {
+		log.Info("Cluster deletion complete")
+		controllerutil.RemoveFinalizer(cluster, clusterv1beta2.ClusterFinalizer)
+		if err := c.Update(ctx, cluster); err != nil {
+			log.Error(err, "unable to remove finalizer")
+			return ctrl.Result{}, err
+		}
+		if r.ClusterCache != nil {
+			r.ClusterCache.Disconnect(clustercache.Key{ClusterName: clusterName, ObjectKey: client.ObjectKeyFromObject(cluster)})
+		}
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -196,10 +228,48 @@ func (r *ClusterController) SetupWithManager(mgr mcmanager.Manager) error {
 	if r.Manager != nil {
 		r.Manager = mgr
 	}
-	return mcbuilder.ControllerManagedBy(mgr).
+	controller := mcbuilder.ControllerManagedBy(mgr).
 		For(&clusterv1beta2.Cluster{}).
 		Named("cluster").
-		Owns(&controlplanev1alpha1.GardenerShootControlPlane{}).
-		Owns(&infrastructurev1alpha1.GardenerShootCluster{}).
-		Complete(r)
+		Owns(&controlplanev1alpha2.GardenerShootControlPlane{}).
+		Owns(&infrastructurev1alpha2.GardenerShootCluster{}).
+		Watches(
+			&clusterv1beta2.MachinePool{},
+			mchandler.TypedEnqueueRequestsFromMapFunc[client.Object, mcreconcile.Request](mapMachinePoolToCluster),
+		).
+		Watches(&infrastructurev1alpha2.GardenerWorkerPool{}, mapWorkerPoolToCluster)
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source("cluster"))
+	}
+	return controller.Complete(r)
+}
+
+// mapMachinePoolToCluster maps a MachinePool to its Cluster.
+func mapMachinePoolToCluster(_ context.Context, obj client.Object) []mcreconcile.Request {
+	machinePool, ok := obj.(*clusterv1beta2.MachinePool)
+	if !ok || machinePool.Spec.ClusterName == "" {
+		return nil
+	}
+	return []mcreconcile.Request{{Request: reconcile.Request{NamespacedName: client.ObjectKey{
+		Namespace: machinePool.Namespace,
+		Name:      machinePool.Spec.ClusterName,
+	}}}}
+}
+
+// mapWorkerPoolToCluster maps a GardenerWorkerPool to the Cluster of its owning MachinePool.
+func mapWorkerPoolToCluster(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		workerPool, ok := obj.(*infrastructurev1alpha2.GardenerWorkerPool)
+		if !ok {
+			return nil
+		}
+		machinePool, err := providerutil.GetMachinePoolForWorkerPool(ctx, cl.GetClient(), workerPool)
+		if err != nil || machinePool == nil || machinePool.Spec.ClusterName == "" {
+			return nil
+		}
+		return []mcreconcile.Request{{ClusterName: clusterName, Request: reconcile.Request{NamespacedName: client.ObjectKey{
+			Namespace: machinePool.Namespace,
+			Name:      machinePool.Spec.ClusterName,
+		}}}}
+	})
 }
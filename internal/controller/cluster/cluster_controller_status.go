@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// worker is a MachinePool of a Cluster together with its GardenerWorkerPool. The GardenerWorkerPool is nil if the
+// MachinePool does not reference one or if it does not exist.
+type worker struct {
+	machinePool clusterv1beta2.MachinePool
+	workerPool  *infrastructurev1alpha2.GardenerWorkerPool
+}
+
+// getWorkers returns the MachinePools of the given Cluster together with their GardenerWorkerPools.
+func getWorkers(ctx context.Context, c client.Client, cluster *clusterv1beta2.Cluster) ([]worker, error) {
+	machinePools := &clusterv1beta2.MachinePoolList{}
+	if err := c.List(ctx, machinePools, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var workers []worker
+	for _, machinePool := range machinePools.Items {
+		if machinePool.Spec.ClusterName != cluster.Name {
+			continue
+		}
+		w := worker{machinePool: machinePool}
+		if isWorkerPoolRef(machinePool.Spec.Template.Spec.InfrastructureRef) {
+			workerPool := &infrastructurev1alpha2.GardenerWorkerPool{}
+			if err := c.Get(ctx, client.ObjectKey{
+				Name:      machinePool.Spec.Template.Spec.InfrastructureRef.Name,
+				Namespace: machinePool.Namespace,
+			}, workerPool); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+			} else {
+				w.workerPool = workerPool
+			}
+		}
+		workers = append(workers, w)
+	}
+	return workers, nil
+}
+
+func isWorkerPoolRef(ref clusterv1beta2.ContractVersionedObjectReference) bool {
+	return ref.GroupKind() == infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind()
+}
+
+// setPausedCondition sets the Paused condition of the Cluster and returns whether it is paused.
+func setPausedCondition(cluster *clusterv1beta2.Cluster) bool {
+	if annotations.IsPaused(cluster, cluster) {
+		setCondition(cluster, clusterv1beta2.PausedCondition, metav1.ConditionTrue, clusterv1beta2.PausedReason, "")
+		return true
+	}
+	setCondition(cluster, clusterv1beta2.PausedCondition, metav1.ConditionFalse, clusterv1beta2.NotPausedReason, "")
+	return false
+}
+
+// setStatus aggregates the status of the Cluster from its GardenerShootControlPlane, GardenerShootCluster and workers,
+// similar to the Cluster controller of Cluster API. The GardenerShootControlPlane and GardenerShootCluster are nil if
+// they do not exist.
+func setStatus(
+	cluster *clusterv1beta2.Cluster,
+	gscp *controlplanev1alpha2.GardenerShootControlPlane,
+	infraCluster *infrastructurev1alpha2.GardenerShootCluster,
+	workers []worker,
+) {
+	// The initialization fields are never reset once provisioning is completed.
+	if infraCluster != nil && infraCluster.Status.Ready {
+		cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	}
+	if gscp != nil && gscp.Status.Initialized {
+		cluster.Status.Initialization.ControlPlaneInitialized = ptr.To(true)
+	}
+
+	setInfrastructureReadyCondition(cluster, infraCluster)
+	setControlPlaneInitializedCondition(cluster, gscp)
+	setControlPlaneAvailableCondition(cluster, gscp)
+	setWorkersAvailableCondition(cluster, workers)
+	setWorkersReplicas(cluster, workers)
+	setCondition(cluster, clusterv1beta2.DeletingCondition, metav1.ConditionFalse, clusterv1beta2.NotDeletingReason, "")
+
+	switch {
+	case ptr.Deref(cluster.Status.Initialization.InfrastructureProvisioned, false) &&
+		ptr.Deref(cluster.Status.Initialization.ControlPlaneInitialized, false):
+		cluster.Status.Phase = string(clusterv1beta2.ClusterPhaseProvisioned)
+	case cluster.Spec.InfrastructureRef.IsDefined() || cluster.Spec.ControlPlaneRef.IsDefined():
+		cluster.Status.Phase = string(clusterv1beta2.ClusterPhaseProvisioning)
+	default:
+		cluster.Status.Phase = string(clusterv1beta2.ClusterPhasePending)
+	}
+	cluster.Status.ObservedGeneration = cluster.Generation
+}
+
+// setDeletingStatus sets the phase and the Deleting condition of a Cluster which is being deleted.
+func setDeletingStatus(cluster *clusterv1beta2.Cluster, controlPlaneExists, infrastructureExists bool) {
+	cluster.Status.Phase = string(clusterv1beta2.ClusterPhaseDeleting)
+	switch {
+	case controlPlaneExists:
+		setCondition(cluster, clusterv1beta2.DeletingCondition, metav1.ConditionTrue, clusterv1beta2.ClusterDeletingWaitingForControlPlaneDeletionReason,
+			fmt.Sprintf("* GardenerShootControlPlane %s", cluster.Spec.ControlPlaneRef.Name))
+	case infrastructureExists:
+		setCondition(cluster, clusterv1beta2.DeletingCondition, metav1.ConditionTrue, clusterv1beta2.ClusterDeletingWaitingForInfrastructureDeletionReason,
+			fmt.Sprintf("* GardenerShootCluster %s", cluster.Spec.InfrastructureRef.Name))
+	default:
+		setCondition(cluster, clusterv1beta2.DeletingCondition, metav1.ConditionTrue, clusterv1beta2.ClusterDeletingDeletionCompletedReason, "")
+	}
+	cluster.Status.ObservedGeneration = cluster.Generation
+}
+
+func setInfrastructureReadyCondition(cluster *clusterv1beta2.Cluster, infraCluster *infrastructurev1alpha2.GardenerShootCluster) {
+	switch {
+	case infraCluster == nil:
+		setCondition(cluster, clusterv1beta2.ClusterInfrastructureReadyCondition, metav1.ConditionFalse, clusterv1beta2.ClusterInfrastructureDoesNotExistReason,
+			fmt.Sprintf("GardenerShootCluster %s does not exist", cluster.Spec.InfrastructureRef.Name))
+	case infraCluster.Status.Ready:
+		setCondition(cluster, clusterv1beta2.ClusterInfrastructureReadyCondition, metav1.ConditionTrue, clusterv1beta2.ClusterInfrastructureReadyReason, "")
+	default:
+		setCondition(cluster, clusterv1beta2.ClusterInfrastructureReadyCondition, metav1.ConditionFalse, clusterv1beta2.ClusterInfrastructureNotReadyReason,
+			fmt.Sprintf("GardenerShootCluster %s is not ready", infraCluster.Name))
+	}
+}
+
+func setControlPlaneInitializedCondition(cluster *clusterv1beta2.Cluster, gscp *controlplanev1alpha2.GardenerShootControlPlane) {
+	if ptr.Deref(cluster.Status.Initialization.ControlPlaneInitialized, false) {
+		setCondition(cluster, clusterv1beta2.ClusterControlPlaneInitializedCondition, metav1.ConditionTrue, clusterv1beta2.ClusterControlPlaneInitializedReason, "")
+		return
+	}
+	if gscp == nil {
+		setCondition(cluster, clusterv1beta2.ClusterControlPlaneInitializedCondition, metav1.ConditionFalse, clusterv1beta2.ClusterControlPlaneDoesNotExistReason,
+			fmt.Sprintf("GardenerShootControlPlane %s does not exist", cluster.Spec.ControlPlaneRef.Name))
+		return
+	}
+	setCondition(cluster, clusterv1beta2.ClusterControlPlaneInitializedCondition, metav1.ConditionFalse, clusterv1beta2.ClusterControlPlaneNotInitializedReason,
+		"Control plane not yet initialized")
+}
+
+func setControlPlaneAvailableCondition(cluster *clusterv1beta2.Cluster, gscp *controlplanev1alpha2.GardenerShootControlPlane) {
+	switch {
+	case gscp == nil:
+		setCondition(cluster, clusterv1beta2.ClusterControlPlaneAvailableCondition, metav1.ConditionFalse, clusterv1beta2.ClusterControlPlaneDoesNotExistReason,
+			fmt.Sprintf("GardenerShootControlPlane %s does not exist", cluster.Spec.ControlPlaneRef.Name))
+	case gscp.Status.Ready:
+		setCondition(cluster, clusterv1beta2.ClusterControlPlaneAvailableCondition, metav1.ConditionTrue, clusterv1beta2.ClusterControlPlaneAvailableReason, "")
+	default:
+		message := fmt.Sprintf("GardenerShootControlPlane %s is not ready", gscp.Name)
+		if lastOperation := gscp.Status.ShootStatus.LastOperation; lastOperation != nil && lastOperation.Description != "" {
+			message += ": " + lastOperation.Description
+		}
+		setCondition(cluster, clusterv1beta2.ClusterControlPlaneAvailableCondition, metav1.ConditionFalse, clusterv1beta2.ClusterControlPlaneNotAvailableReason, message)
+	}
+}
+
+func setWorkersAvailableCondition(cluster *clusterv1beta2.Cluster, workers []worker) {
+	if len(workers) == 0 {
+		setCondition(cluster, clusterv1beta2.ClusterWorkersAvailableCondition, metav1.ConditionTrue, clusterv1beta2.ClusterWorkersAvailableNoWorkersReason, "")
+		return
+	}
+
+	var messages []string
+	for _, w := range workers {
+		if !isWorkerPoolRef(w.machinePool.Spec.Template.Spec.InfrastructureRef) {
+			continue
+		}
+		switch {
+		case w.workerPool == nil:
+			messages = append(messages, fmt.Sprintf("* MachinePool %s: GardenerWorkerPool %s does not exist",
+				w.machinePool.Name, w.machinePool.Spec.Template.Spec.InfrastructureRef.Name))
+		case !w.workerPool.Status.Ready:
+			messages = append(messages, fmt.Sprintf("* MachinePool %s: GardenerWorkerPool %s is not ready", w.machinePool.Name, w.workerPool.Name))
+		}
+	}
+	if len(messages) > 0 {
+		setCondition(cluster, clusterv1beta2.ClusterWorkersAvailableCondition, metav1.ConditionFalse, clusterv1beta2.ClusterWorkersNotAvailableReason,
+			strings.Join(messages, "\n"))
+		return
+	}
+	setCondition(cluster, clusterv1beta2.ClusterWorkersAvailableCondition, metav1.ConditionTrue, clusterv1beta2.ClusterWorkersAvailableReason, "")
+}
+
+// setWorkersReplicas sums up the replica counters of the MachinePools of the Cluster. Counters which are not reported by
+// any MachinePool stay unset.
+func setWorkersReplicas(cluster *clusterv1beta2.Cluster, workers []worker) {
+	var desiredReplicas, replicas, readyReplicas, availableReplicas, upToDateReplicas *int32
+	for _, w := range workers {
+		desiredReplicas = addReplicas(desiredReplicas, w.machinePool.Spec.Replicas)
+		replicas = addReplicas(replicas, w.machinePool.Status.Replicas)
+		readyReplicas = addReplicas(readyReplicas, w.machinePool.Status.ReadyReplicas)
+		availableReplicas = addReplicas(availableReplicas, w.machinePool.Status.AvailableReplicas)
+		upToDateReplicas = addReplicas(upToDateReplicas, w.machinePool.Status.UpToDateReplicas)
+	}
+
+	if cluster.Status.Workers == nil {
+		cluster.Status.Workers = &clusterv1beta2.WorkersStatus{}
+	}
+	cluster.Status.Workers.DesiredReplicas = desiredReplicas
+	cluster.Status.Workers.Replicas = replicas
+	cluster.Status.Workers.ReadyReplicas = readyReplicas
+	cluster.Status.Workers.AvailableReplicas = availableReplicas
+	cluster.Status.Workers.UpToDateReplicas = upToDateReplicas
+}
+
+func addReplicas(sum, replicas *int32) *int32 {
+	if replicas == nil {
+		return sum
+	}
+	return ptr.To(ptr.Deref(sum, 0) + *replicas)
+}
+
+func setCondition(cluster *clusterv1beta2.Cluster, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+var _ = Describe("Cluster status", func() {
+	var (
+		cluster      *clusterv1beta2.Cluster
+		gscp         *controlplanev1alpha2.GardenerShootControlPlane
+		infraCluster *infrastructurev1alpha2.GardenerShootCluster
+		workers      []worker
+	)
+
+	condition := func(conditionType string) *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions, conditionType)
+	}
+
+	newWorker := func(name string, ready bool, replicas int32) worker {
+		return worker{
+			machinePool: clusterv1beta2.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: clusterv1beta2.MachinePoolSpec{
+					ClusterName: "foo",
+					Replicas:    ptr.To(replicas),
+					Template: clusterv1beta2.MachineTemplateSpec{Spec: clusterv1beta2.MachineSpec{
+						InfrastructureRef: clusterv1beta2.ContractVersionedObjectReference{
+							APIGroup: infrastructurev1alpha2.SchemeGroupVersion.Group,
+							Kind:     "GardenerWorkerPool",
+							Name:     name,
+						},
+					}},
+				},
+				Status: clusterv1beta2.MachinePoolStatus{Replicas: ptr.To(replicas)},
+			},
+			workerPool: &infrastructurev1alpha2.GardenerWorkerPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status:     infrastructurev1alpha2.GardenerWorkerPoolStatus{Ready: ready},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &clusterv1beta2.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Generation: 2},
+			Spec: clusterv1beta2.ClusterSpec{
+				ControlPlaneRef: clusterv1beta2.ContractVersionedObjectReference{
+					APIGroup: controlplanev1alpha2.GroupVersion.Group,
+					Kind:     "GardenerShootControlPlane",
+					Name:     "foo-cp",
+				},
+				InfrastructureRef: clusterv1beta2.ContractVersionedObjectReference{
+					APIGroup: infrastructurev1alpha2.SchemeGroupVersion.Group,
+					Kind:     "GardenerShootCluster",
+					Name:     "foo-infra",
+				},
+			},
+		}
+		gscp = &controlplanev1alpha2.GardenerShootControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "foo-cp"}}
+		infraCluster = &infrastructurev1alpha2.GardenerShootCluster{ObjectMeta: metav1.ObjectMeta{Name: "foo-infra"}}
+		workers = nil
+	})
+
+	It("should report a provisioning Cluster", func() {
+		setStatus(cluster, gscp, infraCluster, workers)
+
+		Expect(cluster.Status.Phase).To(Equal(string(clusterv1beta2.ClusterPhaseProvisioning)))
+		Expect(cluster.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(cluster.Status.Initialization.InfrastructureProvisioned).To(BeNil())
+		Expect(cluster.Status.Initialization.ControlPlaneInitialized).To(BeNil())
+		Expect(condition(clusterv1beta2.ClusterInfrastructureReadyCondition)).To(And(
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", clusterv1beta2.ClusterInfrastructureNotReadyReason),
+		))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneInitializedCondition).Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneAvailableCondition).Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition(clusterv1beta2.ClusterWorkersAvailableCondition).Reason).To(Equal(clusterv1beta2.ClusterWorkersAvailableNoWorkersReason))
+		Expect(condition(clusterv1beta2.DeletingCondition).Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should report missing objects", func() {
+		setStatus(cluster, nil, nil, workers)
+
+		Expect(condition(clusterv1beta2.ClusterInfrastructureReadyCondition).Reason).To(Equal(clusterv1beta2.ClusterInfrastructureDoesNotExistReason))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneAvailableCondition).Reason).To(Equal(clusterv1beta2.ClusterControlPlaneDoesNotExistReason))
+	})
+
+	It("should report a provisioned Cluster and keep the initialization once provisioned", func() {
+		infraCluster.Status.Ready = true
+		gscp.Status.Initialized = true
+		gscp.Status.Ready = true
+		setStatus(cluster, gscp, infraCluster, workers)
+
+		Expect(cluster.Status.Phase).To(Equal(string(clusterv1beta2.ClusterPhaseProvisioned)))
+		Expect(cluster.Status.Initialization.InfrastructureProvisioned).To(Equal(ptr.To(true)))
+		Expect(cluster.Status.Initialization.ControlPlaneInitialized).To(Equal(ptr.To(true)))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneAvailableCondition).Status).To(Equal(metav1.ConditionTrue))
+
+		gscp.Status.Ready = false
+		setStatus(cluster, gscp, infraCluster, workers)
+		Expect(cluster.Status.Initialization.ControlPlaneInitialized).To(Equal(ptr.To(true)))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneInitializedCondition).Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition(clusterv1beta2.ClusterControlPlaneAvailableCondition).Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should aggregate the workers", func() {
+		workers = []worker{newWorker("pool-a", true, 2), newWorker("pool-b", false, 3)}
+		setStatus(cluster, gscp, infraCluster, workers)
+
+		Expect(condition(clusterv1beta2.ClusterWorkersAvailableCondition)).To(And(
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", clusterv1beta2.ClusterWorkersNotAvailableReason),
+			HaveField("Message", "* MachinePool pool-b: GardenerWorkerPool pool-b is not ready"),
+		))
+		Expect(cluster.Status.Workers.DesiredReplicas).To(Equal(ptr.To[int32](5)))
+		Expect(cluster.Status.Workers.Replicas).To(Equal(ptr.To[int32](5)))
+		Expect(cluster.Status.Workers.ReadyReplicas).To(BeNil())
+
+		workers[1].workerPool.Status.Ready = true
+		setStatus(cluster, gscp, infraCluster, workers)
+		Expect(condition(clusterv1beta2.ClusterWorkersAvailableCondition).Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("should report the paused state", func() {
+		Expect(setPausedCondition(cluster)).To(BeFalse())
+		Expect(condition(clusterv1beta2.PausedCondition).Status).To(Equal(metav1.ConditionFalse))
+
+		cluster.Spec.Paused = ptr.To(true)
+		Expect(setPausedCondition(cluster)).To(BeTrue())
+		Expect(condition(clusterv1beta2.PausedCondition).Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("should report what the deletion waits for", func() {
+		setDeletingStatus(cluster, true, true)
+		Expect(cluster.Status.Phase).To(Equal(string(clusterv1beta2.ClusterPhaseDeleting)))
+		Expect(condition(clusterv1beta2.DeletingCondition).Reason).To(Equal(clusterv1beta2.ClusterDeletingWaitingForControlPlaneDeletionReason))
+
+		setDeletingStatus(cluster, false, true)
+		Expect(condition(clusterv1beta2.DeletingCondition).Reason).To(Equal(clusterv1beta2.ClusterDeletingWaitingForInfrastructureDeletionReason))
+
+		setDeletingStatus(cluster, false, false)
+		Expect(condition(clusterv1beta2.DeletingCondition).Reason).To(Equal(clusterv1beta2.ClusterDeletingDeletionCompletedReason))
+	})
+})
@@ -7,32 +7,57 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 // MachinePoolController is a controller for managing MachinePool resources.
+// It mocks the lifecycle management of the cluster-api MachinePool controller for MachinePools backed by
+// GardenerWorkerPools.
 type MachinePoolController struct {
 	Manager mcmanager.Manager
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
 }
 
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools/finalizers,verbs=update
+
 // Reconcile reconciles the MachinePool resource.
 func (r *MachinePoolController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("machinepool-object", req.NamespacedName, "cluster", req.ClusterName)
 
+	if !r.Sharder.Handles("machinepool", req) {
+		return ctrl.Result{}, nil
+	}
+
 	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -45,11 +70,23 @@ func (r *MachinePoolController) Reconcile(ctx context.Context, req mcreconcile.R
 	if err := c.Get(ctx, req.NamespacedName, &machinePool); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("resource no longer exists")
+			r.Sharder.Forget("machinepool", req)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	if !machinePool.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(runtimelog.IntoContext(ctx, log), c, &machinePool)
+	}
+
+	patch := client.MergeFrom(machinePool.DeepCopy())
+	if controllerutil.AddFinalizer(&machinePool, clusterv1beta2.MachinePoolFinalizer) {
+		if err := c.Patch(ctx, &machinePool, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	clusterName := machinePool.Spec.ClusterName
 	if clusterName == "" {
 		log.Info("ClusterName is empty")
@@ -69,36 +106,157 @@ func (r *MachinePoolController) Reconcile(ctx context.Context, req mcreconcile.R
 		return ctrl.Result{}, err
 	}
 
+	if annotations.IsPaused(cluster, &machinePool) {
+		log.Info("MachinePool or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
 	if err := ensureOwnerRef(ctx, c, &machinePool, cluster); err != nil {
 		log.Error(err, "unable to set OwnerRef on MachinePool")
 		return ctrl.Result{}, err
 	}
 
-	if infraRefGroupKind := machinePool.Spec.Template.Spec.InfrastructureRef.GroupKind(); infraRefGroupKind != infrastructurev1alpha1.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind() {
-		log.Info(fmt.Sprintf("%s is not a GardenerWorkerPool", infraRefGroupKind))
+	if !isWorkerPoolRef(machinePool.Spec.Template.Spec.InfrastructureRef) {
+		log.Info(fmt.Sprintf("%s is not a GardenerWorkerPool", machinePool.Spec.Template.Spec.InfrastructureRef.GroupKind()))
 		return ctrl.Result{}, nil
 	}
 
-	gsw := &infrastructurev1alpha1.GardenerWorkerPool{}
+	gsw := &infrastructurev1alpha2.GardenerWorkerPool{}
 	if err := c.Get(ctx, client.ObjectKey{
 		Name:      machinePool.Spec.Template.Spec.InfrastructureRef.Name,
 		Namespace: machinePool.Namespace,
 	}, gsw); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("resource no longer exists")
-			return ctrl.Result{}, nil
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
 		}
+		log.Info("GardenerWorkerPool does not exist yet")
+		gsw = nil
+	} else if err := ensureMachinePoolOwnerRef(ctx, c, gsw, &machinePool); err != nil {
+		log.Error(err, "unable to set OwnerRef on MachinePool")
 		return ctrl.Result{}, err
 	}
 
-	if err := ensureMachinePoolOwnerRef(ctx, c, gsw, &machinePool); err != nil {
-		log.Error(err, "unable to set OwnerRef on MachinePool")
-		return ctrl.Result{}, err
+	var machines []infrastructurev1alpha2.GardenerMachine
+	if gsw != nil {
+		machineList := &infrastructurev1alpha2.GardenerMachineList{}
+		if err := c.List(ctx, machineList, client.InNamespace(machinePool.Namespace), client.MatchingLabels{
+			clusterv1beta2.ClusterNameLabel:     cluster.Name,
+			clusterv1beta2.MachinePoolNameLabel: format.MustFormatValue(machinePool.Name),
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		machines = machineList.Items
+
+		// The provider IDs are part of the spec of the MachinePool, see the MachinePool contract of Cluster API.
+		if !slices.Equal(machinePool.Spec.ProviderIDList, gsw.Spec.ProviderIDList) {
+			patch := client.MergeFrom(machinePool.DeepCopy())
+			machinePool.Spec.ProviderIDList = gsw.Spec.ProviderIDList
+			if err := c.Patch(ctx, &machinePool, patch); err != nil {
+				log.Error(err, "unable to update provider IDs of MachinePool")
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	original := machinePool.DeepCopy()
+	setMachinePoolStatus(&machinePool, gsw, machines)
+	if !apiequality.Semantic.DeepEqual(original.Status, machinePool.Status) {
+		if err := c.Status().Patch(ctx, &machinePool, client.MergeFrom(original)); err != nil {
+			log.Error(err, "unable to update MachinePool status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *MachinePoolController) reconcileDelete(ctx context.Context, c client.Client, machinePool *clusterv1beta2.MachinePool) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx)
+	log.Info("MachinePool is being deleted")
+
+	if machinePool.Status.Phase != string(clusterv1beta2.MachinePoolPhaseDeleting) {
+		patch := client.MergeFrom(machinePool.DeepCopy())
+		machinePool.Status.Phase = string(clusterv1beta2.MachinePoolPhaseDeleting)
+		if err := c.Status().Patch(ctx, machinePool, patch); err != nil {
+			log.Error(err, "unable to update MachinePool status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Delete the infrastructure reference and wait until it is gone.
+	if ref := machinePool.Spec.Template.Spec.InfrastructureRef; isWorkerPoolRef(ref) {
+		gsw := &infrastructurev1alpha2.GardenerWorkerPool{}
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: machinePool.Namespace}, gsw); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		} else {
+			if gsw.DeletionTimestamp.IsZero() {
+				log.Info("Deleting GardenerWorkerPool", "gardenerworkerpool", ref.Name)
+				if err := c.Delete(ctx, gsw); client.IgnoreNotFound(err) != nil {
+					log.Error(err, "unable to delete GardenerWorkerPool")
+					return ctrl.Result{}, err
+				}
+			}
+			return ctrl.Result{}, nil
+		}
 	}
 
+	log.Info("MachinePool deletion complete")
+	patch := client.MergeFrom(machinePool.DeepCopy())
+	if controllerutil.RemoveFinalizer(machinePool, clusterv1beta2.MachinePoolFinalizer) {
+		if err := c.Patch(ctx, machinePool, patch); err != nil {
+			log.Error(err, "unable to remove finalizer")
+			return ctrl.Result{}, err
+		}
+	}
 	return ctrl.Result{}, nil
 }
 
+// setMachinePoolStatus mirrors the status of the GardenerWorkerPool and its GardenerMachines to the MachinePool and
+// computes its phase, similar to the MachinePool controller of Cluster API. The GardenerWorkerPool is nil if it does not
+// exist.
+func setMachinePoolStatus(machinePool *clusterv1beta2.MachinePool, workerPool *infrastructurev1alpha2.GardenerWorkerPool, machines []infrastructurev1alpha2.GardenerMachine) {
+	machinePool.Status.ObservedGeneration = machinePool.Generation
+	if workerPool == nil {
+		machinePool.Status.Phase = string(clusterv1beta2.MachinePoolPhasePending)
+		return
+	}
+
+	// The initialization fields are never reset once provisioning is completed.
+	if workerPool.Status.Ready {
+		machinePool.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	}
+
+	slices.SortFunc(machines, func(a, b infrastructurev1alpha2.GardenerMachine) int {
+		return strings.Compare(a.Spec.NodeName, b.Spec.NodeName)
+	})
+	var nodeRefs []corev1.ObjectReference
+	var readyReplicas int32
+	for _, machine := range machines {
+		if machine.Spec.NodeName == "" || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nodeRefs = append(nodeRefs, corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: machine.Spec.NodeName})
+		if machine.Status.NodeReady {
+			readyReplicas++
+		}
+	}
+	machinePool.Status.NodeRefs = nodeRefs
+	machinePool.Status.Replicas = ptr.To(int32(len(workerPool.Spec.ProviderIDList))) // #nosec G115
+	machinePool.Status.ReadyReplicas = ptr.To(readyReplicas)
+	machinePool.Status.AvailableReplicas = ptr.To(readyReplicas)
+
+	switch {
+	case !ptr.Deref(machinePool.Status.Initialization.InfrastructureProvisioned, false):
+		machinePool.Status.Phase = string(clusterv1beta2.MachinePoolPhaseProvisioning)
+	case machinePool.Spec.Replicas != nil && *machinePool.Spec.Replicas != *machinePool.Status.Replicas && !annotations.ReplicasManagedByExternalAutoscaler(machinePool):
+		machinePool.Status.Phase = string(clusterv1beta2.MachinePoolPhaseScaling)
+	default:
+		machinePool.Status.Phase = string(clusterv1beta2.MachinePoolPhaseRunning)
+	}
+}
+
 func ensureMachinePoolOwnerRef(ctx context.Context, c client.Client, obj metav1.Object, machinePool *clusterv1beta2.MachinePool) error {
 	desiredOwnerRef := metav1.OwnerReference{
 		APIVersion: machinePool.APIVersion,
@@ -124,7 +282,36 @@ func ensureMachinePoolOwnerRef(ctx context.Context, c client.Client, obj metav1.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MachinePoolController) SetupWithManager(mgr mcmanager.Manager) error {
-	return mcbuilder.ControllerManagedBy(mgr).
+	controller := mcbuilder.ControllerManagedBy(mgr).
 		For(&clusterv1beta2.MachinePool{}).
-		Complete(r)
+		Watches(
+			&infrastructurev1alpha2.GardenerWorkerPool{},
+			mchandler.TypedEnqueueRequestForOwner[client.Object](&clusterv1beta2.MachinePool{}, handler.OnlyControllerOwner()),
+		).
+		Watches(&infrastructurev1alpha2.GardenerMachine{}, mapMachineToMachinePool)
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source("machinepool"))
+	}
+	return controller.Complete(r)
+}
+
+// mapMachineToMachinePool maps a GardenerMachine to the MachinePool of its GardenerWorkerPool.
+func mapMachineToMachinePool(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		idx := slices.IndexFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.Kind == "GardenerWorkerPool" && ref.APIVersion == infrastructurev1alpha2.SchemeGroupVersion.String()
+		})
+		if idx < 0 {
+			return nil
+		}
+		workerPool := &infrastructurev1alpha2.GardenerWorkerPool{}
+		if err := cl.GetClient().Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetOwnerReferences()[idx].Name}, workerPool); err != nil {
+			return nil
+		}
+		machinePool, err := providerutil.GetMachinePoolForWorkerPool(ctx, cl.GetClient(), workerPool)
+		if err != nil || machinePool == nil || machinePool.Name == "" {
+			return nil
+		}
+		return []mcreconcile.Request{{ClusterName: clusterName, Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(machinePool)}}}
+	})
 }
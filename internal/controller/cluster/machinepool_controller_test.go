@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+var _ = Describe("MachinePool status", func() {
+	var (
+		machinePool *clusterv1beta2.MachinePool
+		workerPool  *infrastructurev1alpha2.GardenerWorkerPool
+		machines    []infrastructurev1alpha2.GardenerMachine
+	)
+
+	newMachine := func(nodeName string, nodeReady bool) infrastructurev1alpha2.GardenerMachine {
+		return infrastructurev1alpha2.GardenerMachine{
+			Spec:   infrastructurev1alpha2.GardenerMachineSpec{NodeName: nodeName},
+			Status: infrastructurev1alpha2.GardenerMachineStatus{Ready: true, NodeReady: nodeReady},
+		}
+	}
+
+	BeforeEach(func() {
+		machinePool = &clusterv1beta2.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 3},
+			Spec:       clusterv1beta2.MachinePoolSpec{Replicas: ptr.To[int32](2)},
+		}
+		workerPool = &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+		machines = nil
+	})
+
+	It("should be pending without GardenerWorkerPool", func() {
+		setMachinePoolStatus(machinePool, nil, machines)
+		Expect(machinePool.Status.Phase).To(Equal(string(clusterv1beta2.MachinePoolPhasePending)))
+		Expect(machinePool.Status.ObservedGeneration).To(Equal(int64(3)))
+	})
+
+	It("should be provisioning until the GardenerWorkerPool is ready", func() {
+		setMachinePoolStatus(machinePool, workerPool, machines)
+		Expect(machinePool.Status.Phase).To(Equal(string(clusterv1beta2.MachinePoolPhaseProvisioning)))
+		Expect(machinePool.Status.Initialization.InfrastructureProvisioned).To(BeNil())
+	})
+
+	It("should mirror the nodes and be scaling until the desired replicas are reached", func() {
+		workerPool.Status.Ready = true
+		workerPool.Spec.ProviderIDList = []string{"aws:///eu-west-1/i-b"}
+		machines = []infrastructurev1alpha2.GardenerMachine{newMachine("node-b", false)}
+		setMachinePoolStatus(machinePool, workerPool, machines)
+
+		Expect(machinePool.Status.Phase).To(Equal(string(clusterv1beta2.MachinePoolPhaseScaling)))
+		Expect(machinePool.Status.Initialization.InfrastructureProvisioned).To(Equal(ptr.To(true)))
+		Expect(machinePool.Status.Replicas).To(Equal(ptr.To[int32](1)))
+		Expect(machinePool.Status.ReadyReplicas).To(Equal(ptr.To[int32](0)))
+
+		workerPool.Spec.ProviderIDList = append(workerPool.Spec.ProviderIDList, "aws:///eu-west-1/i-a")
+		machines = append(machines, newMachine("node-a", true))
+		setMachinePoolStatus(machinePool, workerPool, machines)
+
+		Expect(machinePool.Status.Phase).To(Equal(string(clusterv1beta2.MachinePoolPhaseRunning)))
+		Expect(machinePool.Status.Replicas).To(Equal(ptr.To[int32](2)))
+		Expect(machinePool.Status.ReadyReplicas).To(Equal(ptr.To[int32](1)))
+		Expect(machinePool.Status.AvailableReplicas).To(Equal(ptr.To[int32](1)))
+		Expect(machinePool.Status.NodeRefs).To(Equal([]corev1.ObjectReference{
+			{APIVersion: "v1", Kind: "Node", Name: "node-a"},
+			{APIVersion: "v1", Kind: "Node", Name: "node-b"},
+		}))
+	})
+
+	It("should not be scaling if the replicas are managed by the cluster-autoscaler", func() {
+		machinePool.Annotations = map[string]string{clusterv1beta2.ReplicasManagedByAnnotation: "external-autoscaler"}
+		workerPool.Status.Ready = true
+		workerPool.Spec.ProviderIDList = []string{"aws:///eu-west-1/i-b"}
+		machines = []infrastructurev1alpha2.GardenerMachine{newMachine("node-b", true)}
+		setMachinePoolStatus(machinePool, workerPool, machines)
+
+		Expect(machinePool.Status.Phase).To(Equal(string(clusterv1beta2.MachinePoolPhaseRunning)))
+		Expect(machinePool.Status.Replicas).To(Equal(ptr.To[int32](1)))
+		Expect(machinePool.Spec.Replicas).To(Equal(ptr.To[int32](2)))
+	})
+})
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+// projectRequeueInterval is the interval in which Projects which are not ready yet are checked again.
+const projectRequeueInterval = 10 * time.Second
+
+// GardenerProjectReconciler reconciles a GardenerProject object
+type GardenerProjectReconciler struct {
+	Manager        mcmanager.Manager
+	GardenerClient client.Client
+
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
+}
+
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenerprojects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenerprojects/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenerprojects/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core.gardener.cloud,resources=projects,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile provisions the Gardener Project of a GardenerProject.
+func (r *GardenerProjectReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx).WithValues("gardenerproject", req.Name, "cluster", req.ClusterName)
+
+	if !r.Sharder.Handles("gardenerproject", req) {
+		return ctrl.Result{}, nil
+	}
+
+	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	c := cl.GetClient()
+
+	gardenerProject := &controlplanev1alpha2.GardenerProject{}
+	if err := c.Get(ctx, req.NamespacedName, gardenerProject); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("GardenerProject not found or already deleted")
+			r.Sharder.Forget("gardenerproject", req)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get GardenerProject")
+		return ctrl.Result{}, err
+	}
+
+	project := &gardenercorev1beta1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: providerutil.GardenerProjectName(req.ClusterName, gardenerProject),
+		},
+	}
+	owner := providerutil.ProjectOwner(req.ClusterName, gardenerProject)
+	log = log.WithValues("project", project.Name)
+	ctx = runtimelog.IntoContext(ctx, log)
+
+	if !gardenerProject.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, c, gardenerProject, project, owner)
+	}
+	return r.reconcile(ctx, c, gardenerProject, project, owner)
+}
+
+func (r *GardenerProjectReconciler) reconcile(
+	ctx context.Context,
+	c client.Client,
+	gardenerProject *controlplanev1alpha2.GardenerProject,
+	project *gardenercorev1beta1.Project,
+	owner string,
+) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx)
+
+	patch := client.MergeFrom(gardenerProject.DeepCopy())
+	if controllerutil.AddFinalizer(gardenerProject, controlplanev1alpha2.GardenerProjectFinalizer) {
+		if err := c.Patch(ctx, gardenerProject, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.GardenerClient.Get(ctx, client.ObjectKeyFromObject(project), project); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		log.Info("Project not found, creating it")
+		metav1.SetMetaDataAnnotation(&project.ObjectMeta, controlplanev1alpha2.ProjectOwnerAnnotation, owner)
+		project.Spec.Namespace = gardenerProject.Spec.Namespace
+		syncProjectSpec(gardenerProject, project)
+		if err := r.GardenerClient.Create(ctx, project); err != nil {
+			log.Error(err, "Failed to create Project")
+			return ctrl.Result{}, err
+		}
+	} else {
+		if project.Annotations[controlplanev1alpha2.ProjectOwnerAnnotation] != owner {
+			// Never adopt Projects which were not created for this GardenerProject.
+			return ctrl.Result{}, fmt.Errorf("project %q already exists and is not owned by GardenerProject %q", project.Name, owner)
+		}
+		originalProject := project.DeepCopy()
+		syncProjectSpec(gardenerProject, project)
+		if !apiequality.Semantic.DeepEqual(originalProject.Spec, project.Spec) {
+			log.Info("Updating Project")
+			if err := r.GardenerClient.Patch(ctx, project, client.MergeFrom(originalProject)); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	patch = client.MergeFrom(gardenerProject.DeepCopy())
+	gardenerProject.Status.ProjectName = project.Name
+	gardenerProject.Status.Namespace = ptr.Deref(project.Spec.Namespace, "")
+	gardenerProject.Status.Phase = project.Status.Phase
+	gardenerProject.Status.Ready = project.Status.Phase == gardenercorev1beta1.ProjectReady && gardenerProject.Status.Namespace != ""
+	if err := c.Status().Patch(ctx, gardenerProject, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !gardenerProject.Status.Ready {
+		return ctrl.Result{RequeueAfter: projectRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *GardenerProjectReconciler) reconcileDelete(
+	ctx context.Context,
+	c client.Client,
+	gardenerProject *controlplanev1alpha2.GardenerProject,
+	project *gardenercorev1beta1.Project,
+	owner string,
+) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx)
+
+	if err := r.GardenerClient.Get(ctx, client.ObjectKeyFromObject(project), project); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		project = nil
+	}
+
+	if project != nil && project.Annotations[controlplanev1alpha2.ProjectOwnerAnnotation] == owner {
+		if project.DeletionTimestamp.IsZero() {
+			log.Info("Deleting Project")
+			patch := client.MergeFrom(project.DeepCopy())
+			metav1.SetMetaDataAnnotation(&project.ObjectMeta, constants.ConfirmationDeletion, "true")
+			if err := r.GardenerClient.Patch(ctx, project, patch); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			// Gardener refuses to delete Projects which still contain Shoots.
+			if err := r.GardenerClient.Delete(ctx, project); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: projectRequeueInterval}, nil
+	}
+
+	patch := client.MergeFrom(gardenerProject.DeepCopy())
+	if controllerutil.RemoveFinalizer(gardenerProject, controlplanev1alpha2.GardenerProjectFinalizer) {
+		if err := c.Patch(ctx, gardenerProject, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncProjectSpec copies the spec of the GardenerProject to the Project. The owner and the creator of the Project stay
+// members, as Gardener adds them on its own. The namespace of a Project cannot be changed once it is set.
+func syncProjectSpec(gardenerProject *controlplanev1alpha2.GardenerProject, project *gardenercorev1beta1.Project) {
+	project.Spec.Description = gardenerProject.Spec.Description
+	project.Spec.Purpose = gardenerProject.Spec.Purpose
+	if gardenerProject.Spec.Owner != nil {
+		project.Spec.Owner = gardenerProject.Spec.Owner
+	}
+
+	members := make([]gardenercorev1beta1.ProjectMember, 0, len(gardenerProject.Spec.Members))
+	members = append(members, gardenerProject.Spec.Members...)
+	for _, member := range project.Spec.Members {
+		if !isSubject(member.Subject, project.Spec.Owner) && !isSubject(member.Subject, project.Spec.CreatedBy) {
+			continue
+		}
+		if !containsMember(members, member.Subject) {
+			members = append(members, member)
+		}
+	}
+	project.Spec.Members = members
+}
+
+func isSubject(subject rbacv1.Subject, other *rbacv1.Subject) bool {
+	return other != nil && subject.Kind == other.Kind && subject.Name == other.Name && subject.Namespace == other.Namespace
+}
+
+func containsMember(members []gardenercorev1beta1.ProjectMember, subject rbacv1.Subject) bool {
+	for _, member := range members {
+		if isSubject(member.Subject, &subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GardenerProjectReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	controller := mcbuilder.ControllerManagedBy(mgr).
+		Named("gardenerproject").
+		For(&controlplanev1alpha2.GardenerProject{})
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source("gardenerproject"))
+	}
+	return controller.Complete(r)
+}
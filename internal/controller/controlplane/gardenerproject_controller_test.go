@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+var _ = Describe("GardenerProject Controller", func() {
+	var (
+		ctx             context.Context
+		c               client.Client
+		gardenClient    client.Client
+		reconciler      *GardenerProjectReconciler
+		gardenerProject *controlplanev1alpha2.GardenerProject
+		project         *gardenercorev1beta1.Project
+
+		alice = rbacv1.Subject{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: "alice"}
+		bob   = rbacv1.Subject{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: "bob"}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gardenerProject = &controlplanev1alpha2.GardenerProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev"},
+			Spec: controlplanev1alpha2.GardenerProjectSpec{
+				Namespace:   ptr.To("garden-dev"),
+				Description: ptr.To("Development"),
+				Members:     []gardenercorev1beta1.ProjectMember{{Subject: alice, Role: "admin"}},
+			},
+		}
+		project = &gardenercorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "dev"}}
+		c = fakeclient.NewClientBuilder().
+			WithScheme(clientgoscheme.Scheme).
+			WithObjects(gardenerProject).
+			WithStatusSubresource(gardenerProject).
+			Build()
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+		reconciler = &GardenerProjectReconciler{GardenerClient: gardenClient}
+	})
+
+	It("should create the Project and wait for it to become ready", func() {
+		result, err := reconciler.reconcile(ctx, c, gardenerProject, project, "dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(projectRequeueInterval))
+
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "dev"}, project)).To(Succeed())
+		Expect(project.Annotations).To(HaveKeyWithValue("controlplane.cluster.x-k8s.io/project-owner", "dev"))
+		Expect(project.Spec.Namespace).To(Equal(ptr.To("garden-dev")))
+		Expect(project.Spec.Description).To(Equal(ptr.To("Development")))
+		Expect(project.Spec.Members).To(ConsistOf(HaveField("Subject", alice)))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "dev"}, gardenerProject)).To(Succeed())
+		Expect(gardenerProject.Finalizers).To(ConsistOf(controlplanev1alpha2.GardenerProjectFinalizer))
+		Expect(gardenerProject.Status.ProjectName).To(Equal("dev"))
+		Expect(gardenerProject.Status.Ready).To(BeFalse())
+	})
+
+	It("should report the namespace of a ready Project and keep its owner as member", func() {
+		project.Annotations = map[string]string{"controlplane.cluster.x-k8s.io/project-owner": "dev"}
+		project.Spec = gardenercorev1beta1.ProjectSpec{
+			Namespace: ptr.To("garden-dev"),
+			Owner:     &bob,
+			Members: []gardenercorev1beta1.ProjectMember{
+				{Subject: bob, Role: "owner"},
+				{Subject: rbacv1.Subject{Kind: "User", Name: "eve"}, Role: "viewer"},
+			},
+		}
+		project.Status.Phase = gardenercorev1beta1.ProjectReady
+		Expect(gardenClient.Create(ctx, project)).To(Succeed())
+
+		result, err := reconciler.reconcile(ctx, c, gardenerProject, project, "dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "dev"}, project)).To(Succeed())
+		Expect(project.Spec.Members).To(ConsistOf(HaveField("Subject", alice), HaveField("Subject", bob)))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "dev"}, gardenerProject)).To(Succeed())
+		Expect(gardenerProject.Status).To(Equal(controlplanev1alpha2.GardenerProjectStatus{
+			ProjectName: "dev",
+			Namespace:   "garden-dev",
+			Phase:       gardenercorev1beta1.ProjectReady,
+			Ready:       true,
+		}))
+	})
+
+	It("should not adopt Projects of others", func() {
+		Expect(gardenClient.Create(ctx, project)).To(Succeed())
+
+		_, err := reconciler.reconcile(ctx, c, gardenerProject, project, "dev")
+		Expect(err).To(MatchError(ContainSubstring("is not owned by GardenerProject")))
+	})
+
+	It("should confirm the deletion of the Project and remove the finalizer once it is gone", func() {
+		project.Annotations = map[string]string{"controlplane.cluster.x-k8s.io/project-owner": "dev"}
+		Expect(gardenClient.Create(ctx, project)).To(Succeed())
+		gardenerProject.Finalizers = []string{controlplanev1alpha2.GardenerProjectFinalizer}
+		Expect(c.Update(ctx, gardenerProject)).To(Succeed())
+
+		result, err := reconciler.reconcileDelete(ctx, c, gardenerProject, project.DeepCopy(), "dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(projectRequeueInterval))
+		Expect(apierrors.IsNotFound(gardenClient.Get(ctx, client.ObjectKey{Name: "dev"}, project))).To(BeTrue())
+
+		_, err = reconciler.reconcileDelete(ctx, c, gardenerProject, project.DeepCopy(), "dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "dev"}, gardenerProject)).To(Succeed())
+		Expect(gardenerProject.Finalizers).To(BeEmpty())
+	})
+
+	It("should keep Projects of others when deleting", func() {
+		Expect(gardenClient.Create(ctx, project)).To(Succeed())
+
+		_, err := reconciler.reconcileDelete(ctx, c, gardenerProject, project.DeepCopy(), "dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "dev"}, project)).To(Succeed())
+	})
+})
+
+var _ = Describe("Workspace project", func() {
+	var (
+		ctx        context.Context
+		c          client.Client
+		reconciler *GardenerShootControlPlaneReconciler
+		cpc        ControlPlaneContext
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cpc = ControlPlaneContext{
+			ctx: ctx,
+			shootControlPlane: &controlplanev1alpha2.GardenerShootControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-cp", Namespace: "default"},
+			},
+		}
+		c = fakeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(cpc.shootControlPlane).Build()
+		reconciler = &GardenerShootControlPlaneReconciler{WorkspaceProjects: true}
+	})
+
+	It("should wait for the GardenerProject of the workspace", func() {
+		Expect(reconciler.defaultWorkspaceProjectNamespace(cpc, c)).To(BeFalse())
+
+		Expect(c.Create(ctx, &controlplanev1alpha2.GardenerProject{
+			ObjectMeta: metav1.ObjectMeta{Name: controlplanev1alpha2.WorkspaceProjectName},
+			Status:     controlplanev1alpha2.GardenerProjectStatus{Namespace: "garden-ws12345678"},
+		})).To(Succeed())
+		Expect(reconciler.defaultWorkspaceProjectNamespace(cpc, c)).To(BeFalse())
+		Expect(cpc.shootControlPlane.Spec.ProjectNamespace).To(BeEmpty())
+	})
+
+	It("should default the project namespace to the namespace of the GardenerProject of the workspace", func() {
+		Expect(c.Create(ctx, &controlplanev1alpha2.GardenerProject{
+			ObjectMeta: metav1.ObjectMeta{Name: controlplanev1alpha2.WorkspaceProjectName},
+			Status:     controlplanev1alpha2.GardenerProjectStatus{Namespace: "garden-ws12345678", Ready: true},
+		})).To(Succeed())
+
+		Expect(reconciler.defaultWorkspaceProjectNamespace(cpc, c)).To(BeTrue())
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(cpc.shootControlPlane), cpc.shootControlPlane)).To(Succeed())
+		Expect(cpc.shootControlPlane.Spec.ProjectNamespace).To(Equal("garden-ws12345678"))
+	})
+})
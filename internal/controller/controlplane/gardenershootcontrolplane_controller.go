@@ -8,53 +8,84 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	gardenerauthenticationv1alpha1 "github.com/gardener/gardener/pkg/apis/authentication/v1alpha1"
 	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/gardener"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/clustercache"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
 	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 const (
 	// KubeConfigValiditySeconds defines the validity of the kubeconfig in seconds.
 	KubeConfigValiditySeconds = 6000
+	// ViewerKubeConfigValiditySeconds defines the validity of the viewer kubeconfig in seconds.
+	ViewerKubeConfigValiditySeconds = 86400
+
+	// shootCADataKey is the key of the CA bundle in the `<shoot>.ca-cluster` ConfigMap of the project.
+	shootCADataKey = "ca.crt"
+	// clusterCADataKey is the key of the CA certificate in the CAPI CA Secret.
+	clusterCADataKey = "tls.crt"
 )
 
 // GardenerShootControlPlaneReconciler reconciles a GardenerShootControlPlane object
 type GardenerShootControlPlaneReconciler struct {
 	Manager        mcmanager.Manager
 	GardenerClient client.Client
-	IsKCP          bool
+	// GardenerAPIReader reads from the garden without the cache. It is optional.
+	GardenerAPIReader client.Reader
 
 	PrioritizeShoot bool
+	// ViewerKubeconfig enables maintaining a read-only `<cluster>-viewer-kubeconfig` Secret for every Cluster.
+	ViewerKubeconfig bool
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
+	// WorkspaceProjects defaults the ProjectNamespace of GardenerShootControlPlanes in kcp workspaces to the namespace
+	// of the GardenerProject of the workspace, see WorkspaceProjectReconciler.
+	WorkspaceProjects bool
+	// ClusterCache is disconnected from the workload cluster once the GardenerShootControlPlane is deleted. It is
+	// optional.
+	ClusterCache *clustercache.ClusterCache
 }
 
 // ControlPlaneContext holds the context for the GardenerShootControlPlane reconciler.
@@ -62,18 +93,25 @@ type ControlPlaneContext struct {
 	ctx context.Context
 
 	cluster           *clusterv1beta2.Cluster
-	shootControlPlane *controlplanev1alpha1.GardenerShootControlPlane
+	shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane
 	shoot             *gardenercorev1beta1.Shoot
 	clusterName       string
+	// replicatedResources are the referenced Secrets and ConfigMaps which are replicated into the project namespace.
+	replicatedResources sets.Set[providerutil.ResourceReference]
 }
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenershootcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenershootcontrolplanes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenershootcontrolplanes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=gardenerprojects,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=core.gardener.cloud,resources=shoots/adminkubeconfig,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core.gardener.cloud,resources=shoots/viewerkubeconfig,verbs=create
 // +kubebuilder:rbac:groups=core.gardener.cloud,resources=shoots;shoots/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.gardener.cloud,resources=secretbindings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=security.gardener.cloud,resources=credentialsbindings,verbs=get;list;watch;create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -83,6 +121,10 @@ type ControlPlaneContext struct {
 func (r *GardenerShootControlPlaneReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("gardenershootcontrolplane", req.NamespacedName, "cluster", req.ClusterName)
 
+	if !r.Sharder.Handles("gardenershootcontrolplane", req) {
+		return ctrl.Result{}, nil
+	}
+
 	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -95,16 +137,23 @@ func (r *GardenerShootControlPlaneReconciler) Reconcile(ctx context.Context, req
 	}
 
 	log.Info("Reconciling GardenerShootControlPlane")
-	cpc.shootControlPlane = &controlplanev1alpha1.GardenerShootControlPlane{}
+	cpc.shootControlPlane = &controlplanev1alpha2.GardenerShootControlPlane{}
 	if err := c.Get(cpc.ctx, req.NamespacedName, cpc.shootControlPlane); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("GardenerShootControlPlane not found or already deleted")
+			r.Sharder.Forget("gardenershootcontrolplane", req)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get GardenerShootControlPlane")
 		return ctrl.Result{}, err
 	}
 
+	// `clusterctl move` pauses the objects before deleting them, hence this is handled before the pause gate. The Cluster
+	// might already be deleted as well.
+	if _, ok := cpc.shootControlPlane.Annotations[clusterctlv1.DeleteForMoveAnnotation]; ok && !cpc.shootControlPlane.DeletionTimestamp.IsZero() {
+		return r.reconcileDeleteForMove(cpc, c)
+	}
+
 	log.Info("Getting own cluster")
 	cpc.cluster, err = util.GetOwnerCluster(cpc.ctx, c, cpc.shootControlPlane.ObjectMeta)
 	if err != nil {
@@ -117,9 +166,30 @@ func (r *GardenerShootControlPlaneReconciler) Reconcile(ctx context.Context, req
 
 	if annotations.IsPaused(cpc.cluster, cpc.shootControlPlane) {
 		log.Info("GardenerShootControlPlane or linked Cluster is marked as paused. Won't reconcile")
+		if r.PrioritizeShoot {
+			patch := client.MergeFrom(cpc.shootControlPlane.DeepCopy())
+			if providerutil.SetPausedCondition(&cpc.shootControlPlane.Status.Conditions, cpc.shootControlPlane.Generation, true) {
+				return ctrl.Result{}, c.Status().Patch(cpc.ctx, cpc.shootControlPlane, patch)
+			}
+		}
 		return ctrl.Result{}, nil
 	}
 
+	if r.WorkspaceProjects && req.ClusterName != "" && cpc.shootControlPlane.Spec.ProjectNamespace == "" {
+		if r.PrioritizeShoot {
+			// The ProjectNamespace is defaulted by the other controller, there cannot be a Shoot yet.
+			return ctrl.Result{}, nil
+		}
+		defaulted, err := r.defaultWorkspaceProjectNamespace(cpc, c)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !defaulted {
+			log.Info("Waiting for GardenerProject of workspace to be ready", "gardenerproject", controlplanev1alpha2.WorkspaceProjectName)
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
 	// Setting the name and namespace of the shoot object here.
 	// This is needed to be able to delete the shoot, as well as fetch into this resource.
 	shootID := providerutil.ShootNameFromCAPIResources(*cpc.cluster, *cpc.shootControlPlane)
@@ -140,6 +210,25 @@ func (r *GardenerShootControlPlaneReconciler) Reconcile(ctx context.Context, req
 
 var errIncompleteSpecifications = fmt.Errorf("incomplete specifications")
 
+// defaultWorkspaceProjectNamespace sets the ProjectNamespace to the namespace of the GardenerProject of the workspace.
+// Returns false if the GardenerProject is not ready yet.
+func (r *GardenerShootControlPlaneReconciler) defaultWorkspaceProjectNamespace(cpc ControlPlaneContext, c client.Client) (bool, error) {
+	gardenerProject := &controlplanev1alpha2.GardenerProject{}
+	if err := c.Get(cpc.ctx, client.ObjectKey{Name: controlplanev1alpha2.WorkspaceProjectName}, gardenerProject); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if !gardenerProject.Status.Ready {
+		return false, nil
+	}
+
+	patch := client.MergeFrom(cpc.shootControlPlane.DeepCopy())
+	cpc.shootControlPlane.Spec.ProjectNamespace = gardenerProject.Status.Namespace
+	if err := c.Patch(cpc.ctx, cpc.shootControlPlane, patch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext, c client.Client) (ctrl.Result, error) {
 	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "reconcile")
 
@@ -152,11 +241,21 @@ func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext,
 		}
 	}
 
-	err := r.GardenerClient.Get(cpc.ctx, client.ObjectKeyFromObject(cpc.shoot), cpc.shoot)
+	err := r.getShoot(cpc)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
+		if !r.PrioritizeShoot {
+			// New Shoots are created with the CredentialsBinding right away.
+			if err := r.migrateSecretBinding(cpc, c, false); err != nil {
+				return ctrl.Result{}, err
+			}
+			if cpc.replicatedResources, err = r.reconcileReplicas(cpc, c); err != nil {
+				log.Error(err, "Failed to reconcile replicas")
+				return ctrl.Result{}, err
+			}
+		}
 		log.Info("Shoot not found, creating it")
 		if err := r.createShoot(cpc, c); err != nil {
 			if errors.Is(err, errIncompleteSpecifications) {
@@ -169,6 +268,11 @@ func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext,
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.reconcileShootOwner(cpc); err != nil {
+		log.Error(err, "failed to reconcile owner of Shoot")
+		return ctrl.Result{}, err
+	}
+
 	if r.PrioritizeShoot {
 		if err := r.updateStatus(cpc, c); err != nil {
 			log.Error(err, "failed to update status")
@@ -176,11 +280,30 @@ func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext,
 		}
 	}
 
+	if !r.PrioritizeShoot {
+		if err := r.migrateSecretBinding(cpc, c, true); err != nil {
+			log.Error(err, "failed to migrate SecretBinding")
+			return ctrl.Result{}, err
+		}
+		if cpc.replicatedResources, err = r.reconcileReplicas(cpc, c); err != nil {
+			log.Error(err, "failed to reconcile replicas")
+			return ctrl.Result{}, err
+		}
+	}
+
 	if err := r.syncControlPlaneSpecs(cpc, c); err != nil {
 		log.Error(err, "failed to sync control plane spec")
 		return ctrl.Result{}, err
 	}
 
+	if !r.PrioritizeShoot {
+		// Replicas are only deleted once the Shoot no longer references them.
+		if err := r.deleteReplicas(cpc, cpc.shoot, cpc.replicatedResources); err != nil {
+			log.Error(err, "failed to delete unused replicas")
+			return ctrl.Result{}, err
+		}
+	}
+
 	if !cpc.shootControlPlane.Status.Initialized {
 		// Wait until the shoot is initialized.
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
@@ -193,6 +316,20 @@ func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext,
 		return ctrl.Result{}, err
 	}
 
+	log.Info("Reconcile Shoot CA for ClusterAPI")
+	if err := r.reconcileShootCA(cpc, c); err != nil {
+		log.Error(err, "Error reconciling Shoot CA for ClusterAPI")
+		return ctrl.Result{}, err
+	}
+
+	if r.ViewerKubeconfig {
+		log.Info("Reconcile Shoot viewer access for ClusterAPI")
+		if err := r.reconcileShootViewerAccess(cpc, c); err != nil {
+			log.Error(err, "Error reconciling Shoot viewer access for ClusterAPI")
+			return ctrl.Result{}, err
+		}
+	}
+
 	log.Info("Reconcile shootControlEndpoint")
 	err = r.reconcileShootControlPlaneEndpoint(cpc, c)
 	if err != nil {
@@ -208,9 +345,14 @@ func (r *GardenerShootControlPlaneReconciler) reconcile(cpc ControlPlaneContext,
 	return ctrl.Result{}, nil
 }
 
+// getShoot reads the Shoot from the garden cache, or from the API server until reconcileShootOwner labeled it.
+func (r *GardenerShootControlPlaneReconciler) getShoot(cpc ControlPlaneContext) error {
+	return providerutil.GetShoot(cpc.ctx, r.GardenerClient, r.GardenerAPIReader, client.ObjectKeyFromObject(cpc.shoot), cpc.shoot)
+}
+
 func (r *GardenerShootControlPlaneReconciler) createShoot(cpc ControlPlaneContext, c client.Client) error {
 	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "createShoot")
-	infraCluster := &infrastructurev1alpha1.GardenerShootCluster{}
+	infraCluster := &infrastructurev1alpha2.GardenerShootCluster{}
 	if err := c.Get(cpc.ctx, types.NamespacedName{
 		Name:      cpc.cluster.Spec.InfrastructureRef.Name,
 		Namespace: cpc.cluster.Namespace,
@@ -233,26 +375,286 @@ func (r *GardenerShootControlPlaneReconciler) createShoot(cpc ControlPlaneContex
 		return err
 	} else if isShootWorkerless {
 		// If the shoot is supposed to be workerless, we should dismiss all worker pools that might be configured.
-		workers = []infrastructurev1alpha1.GardenerWorkerPool{}
+		workers = []infrastructurev1alpha2.GardenerWorkerPool{}
 	}
 
 	shoot := providerutil.ShootFromCAPIResources(*cpc.cluster, *cpc.shootControlPlane, *infraCluster, workers)
-	injectReferenceLabels(shoot, cpc.shootControlPlane, infraCluster, workers, r.IsKCP, cpc.clusterName)
+	providerutil.ReplaceReplicatedReferences(shoot, cpc.replicatedResources)
+	r.setShootOwner(cpc, shoot)
 	return r.GardenerClient.Create(cpc.ctx, shoot)
 }
 
+// reconcileShootOwner ensures that the Shoot references the CAPI Cluster owning it, without touching the Shoot spec.
+// The reference changes when the CAPI objects are moved to another namespace or logical cluster, e.g. by
+// `clusterctl move`. The shard label changes with the owner or the number of shards. Reference labels written by
+// earlier versions are removed.
+func (r *GardenerShootControlPlaneReconciler) reconcileShootOwner(cpc ControlPlaneContext) error {
+	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "reconcileShootOwner")
+
+	patch := client.MergeFrom(cpc.shoot.DeepCopy())
+	originalLabels := maps.Clone(cpc.shoot.Labels)
+	originalAnnotations := maps.Clone(cpc.shoot.Annotations)
+	removeLegacyReferenceLabels(cpc.shoot)
+	r.setShootOwner(cpc, cpc.shoot)
+	if maps.Equal(originalLabels, cpc.shoot.Labels) && maps.Equal(originalAnnotations, cpc.shoot.Annotations) {
+		return nil
+	}
+
+	log.Info("Updating owner of Shoot")
+	return r.GardenerClient.Patch(cpc.ctx, cpc.shoot, patch)
+}
+
+// migrateSecretBinding switches the GardenerShootControlPlane and its Shoot from the deprecated SecretBinding to an
+// equivalent CredentialsBinding, which is created if the project does not contain one yet. The Shoot is switched first,
+// so that Gardener verifies the CredentialsBinding before the GardenerShootControlPlane is changed. The result is
+// reported by the CredentialsBindingMigrated condition. A failed migration does not block the reconciliation, as the
+// SecretBinding keeps working until the migration succeeds in a later reconciliation.
+func (r *GardenerShootControlPlaneReconciler) migrateSecretBinding(cpc ControlPlaneContext, c client.Client, shootExists bool) error {
+	secretBindingName := cpc.shootControlPlane.Spec.SecretBindingName
+	if secretBindingName == nil {
+		return nil
+	}
+	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "migrateSecretBinding", "secretBinding", *secretBindingName)
+
+	credentialsBindingName, err := r.migrateShootSecretBinding(cpc, *secretBindingName, shootExists)
+	if err != nil {
+		log.Error(err, "Failed to migrate SecretBinding to CredentialsBinding")
+		record.Warnf(cpc.shootControlPlane, "CredentialsBindingMigrationFailed", "Failed to migrate SecretBinding %s: %v", *secretBindingName, err)
+		return r.setCredentialsBindingMigratedCondition(cpc, c, metav1.ConditionFalse, controlplanev1alpha2.CredentialsBindingMigrationFailedReason,
+			fmt.Sprintf("Failed to migrate SecretBinding %s: %v", *secretBindingName, err))
+	}
+
+	patch := client.MergeFrom(cpc.shootControlPlane.DeepCopy())
+	cpc.shootControlPlane.Spec.SecretBindingName = nil
+	cpc.shootControlPlane.Spec.CredentialsBindingName = &credentialsBindingName
+	if err := c.Patch(cpc.ctx, cpc.shootControlPlane, patch); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Migrated from SecretBinding %s to CredentialsBinding %s", *secretBindingName, credentialsBindingName)
+	log.Info(message)
+	record.Event(cpc.shootControlPlane, "CredentialsBindingMigrated", message)
+	return r.setCredentialsBindingMigratedCondition(cpc, c, metav1.ConditionTrue, controlplanev1alpha2.CredentialsBindingMigratedReason, message)
+}
+
+// migrateShootSecretBinding switches the Shoot to a CredentialsBinding which is equivalent to the given SecretBinding
+// and returns the name of the CredentialsBinding. A Shoot which already uses a CredentialsBinding is not changed, e.g.
+// if patching the GardenerShootControlPlane failed in an earlier reconciliation.
+func (r *GardenerShootControlPlaneReconciler) migrateShootSecretBinding(cpc ControlPlaneContext, secretBindingName string, shootExists bool) (string, error) {
+	if shootExists && cpc.shoot.Spec.SecretBindingName == nil && cpc.shoot.Spec.CredentialsBindingName != nil {
+		return *cpc.shoot.Spec.CredentialsBindingName, nil
+	}
+
+	credentialsBindingName, err := r.ensureCredentialsBinding(cpc, secretBindingName)
+	if err != nil || !shootExists {
+		return credentialsBindingName, err
+	}
+
+	patch := client.MergeFrom(cpc.shoot.DeepCopy())
+	cpc.shoot.Spec.SecretBindingName = nil
+	cpc.shoot.Spec.CredentialsBindingName = &credentialsBindingName
+	if err := r.GardenerClient.Patch(cpc.ctx, cpc.shoot, patch); err != nil {
+		return "", fmt.Errorf("could not switch Shoot to CredentialsBinding %s: %w", credentialsBindingName, err)
+	}
+	return credentialsBindingName, nil
+}
+
+// ensureCredentialsBinding returns the name of a CredentialsBinding in the project which references the same Secret as
+// the given SecretBinding. If there is none, it is created with the name of the SecretBinding.
+func (r *GardenerShootControlPlaneReconciler) ensureCredentialsBinding(cpc ControlPlaneContext, secretBindingName string) (string, error) {
+	reader := r.gardenReader()
+	secretBinding := &gardenercorev1beta1.SecretBinding{}
+	if err := reader.Get(cpc.ctx, client.ObjectKey{Name: secretBindingName, Namespace: cpc.shoot.Namespace}, secretBinding); err != nil {
+		return "", fmt.Errorf("could not get SecretBinding: %w", err)
+	}
+
+	providerType := cpc.shootControlPlane.Spec.Provider.Type
+	credentialsBindingList := &securityv1alpha1.CredentialsBindingList{}
+	if err := reader.List(cpc.ctx, credentialsBindingList, client.InNamespace(secretBinding.Namespace)); err != nil {
+		return "", fmt.Errorf("could not list CredentialsBindings: %w", err)
+	}
+	if credentialsBinding := providerutil.EquivalentCredentialsBinding(secretBinding, providerType, credentialsBindingList.Items); credentialsBinding != nil {
+		return credentialsBinding.Name, nil
+	}
+
+	credentialsBinding := providerutil.CredentialsBindingFromSecretBinding(secretBinding, providerType)
+	if err := r.GardenerClient.Create(cpc.ctx, credentialsBinding); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("CredentialsBinding %s already exists, but does not reference the Secret of the SecretBinding", credentialsBinding.Name)
+		}
+		return "", fmt.Errorf("could not create CredentialsBinding: %w", err)
+	}
+	runtimelog.FromContext(cpc.ctx).Info("Created CredentialsBinding", "credentialsBinding", client.ObjectKeyFromObject(credentialsBinding))
+	return credentialsBinding.Name, nil
+}
+
+// setCredentialsBindingMigratedCondition sets the CredentialsBindingMigrated condition. The status is patched with an
+// optimistic lock, as the other conditions are maintained by the reconciler prioritizing the Shoot.
+func (r *GardenerShootControlPlaneReconciler) setCredentialsBindingMigratedCondition(cpc ControlPlaneContext, c client.Client, status metav1.ConditionStatus, reason, message string) error {
+	patch := client.MergeFromWithOptions(cpc.shootControlPlane.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if !meta.SetStatusCondition(&cpc.shootControlPlane.Status.Conditions, metav1.Condition{
+		Type:               controlplanev1alpha2.CredentialsBindingMigratedCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cpc.shootControlPlane.Generation,
+	}) {
+		return nil
+	}
+	return c.Status().Patch(cpc.ctx, cpc.shootControlPlane, patch)
+}
+
+// reconcileReplicas creates or updates the replicas of the Secrets and ConfigMaps referenced by the
+// GardenerShootControlPlane which carry the ReplicateLabel in the project namespace, and returns the replicated objects.
+// Objects in the project namespace which are not replicas of this Shoot are never overwritten.
+func (r *GardenerShootControlPlaneReconciler) reconcileReplicas(cpc ControlPlaneContext, c client.Client) (sets.Set[providerutil.ResourceReference], error) {
+	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "reconcileReplicas")
+
+	replicated, err := providerutil.ReplicatedResources(cpc.ctx, c, cpc.shootControlPlane)
+	if err != nil {
+		return nil, err
+	}
+	owner := shootOwner(cpc)
+	for reference, source := range replicated {
+		replica := providerutil.NewReplica(source, cpc.shoot)
+		if err := r.gardenReader().Get(cpc.ctx, client.ObjectKeyFromObject(replica), replica); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("could not get replica of %s %s: %w", reference.Kind, reference.Name, err)
+			}
+		} else if !providerutil.IsReplicaOf(replica, cpc.shoot, source.GetName()) {
+			return nil, fmt.Errorf("%s %s already exists in the project namespace and is not a replica of %s %s", reference.Kind, replica.GetName(), reference.Kind, reference.Name)
+		}
+		if !providerutil.SyncReplica(replica, source, cpc.shoot, owner) {
+			continue
+		}
+
+		log.Info("Replicating into project namespace", "kind", reference.Kind, "name", reference.Name, "replica", client.ObjectKeyFromObject(replica))
+		if replica.GetResourceVersion() == "" {
+			err = r.GardenerClient.Create(cpc.ctx, replica)
+		} else {
+			err = r.GardenerClient.Update(cpc.ctx, replica)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not replicate %s %s: %w", reference.Kind, reference.Name, err)
+		}
+	}
+	return sets.KeySet(replicated), nil
+}
+
+// deleteReplicas deletes the replicas of the given Shoot in the project namespace whose source is not contained in keep.
+func (r *GardenerShootControlPlaneReconciler) deleteReplicas(cpc ControlPlaneContext, shoot *gardenercorev1beta1.Shoot, keep sets.Set[providerutil.ResourceReference]) error {
+	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "deleteReplicas")
+
+	listOptions := []client.ListOption{
+		client.InNamespace(shoot.Namespace),
+		client.MatchingLabels{controlplanev1alpha2.ReplicaShootLabel: shoot.Name},
+	}
+	secretList := &v1.SecretList{}
+	if err := r.gardenReader().List(cpc.ctx, secretList, listOptions...); err != nil {
+		return fmt.Errorf("could not list replicated Secrets: %w", err)
+	}
+	configMapList := &v1.ConfigMapList{}
+	if err := r.gardenReader().List(cpc.ctx, configMapList, listOptions...); err != nil {
+		return fmt.Errorf("could not list replicated ConfigMaps: %w", err)
+	}
+
+	var replicas []client.Object
+	for i := range secretList.Items {
+		replicas = append(replicas, &secretList.Items[i])
+	}
+	for i := range configMapList.Items {
+		replicas = append(replicas, &configMapList.Items[i])
+	}
+	for _, replica := range replicas {
+		reference := providerutil.ResourceReference{
+			Kind: providerutil.ResourceKind(replica),
+			Name: replica.GetAnnotations()[controlplanev1alpha2.ReplicaSourceAnnotation],
+		}
+		if keep.Has(reference) {
+			continue
+		}
+		log.Info("Deleting replica", "kind", reference.Kind, "replica", client.ObjectKeyFromObject(replica))
+		if err := r.GardenerClient.Delete(cpc.ctx, replica); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("could not delete replica %s %s: %w", reference.Kind, replica.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// gardenReader returns the reader for garden objects which are only read rarely, and hence are not worth caching.
+func (r *GardenerShootControlPlaneReconciler) gardenReader() client.Reader {
+	if r.GardenerAPIReader != nil {
+		return r.GardenerAPIReader
+	}
+	return r.GardenerClient
+}
+
+// disconnectWorkloadCluster stops the cache of the workload cluster, as it is no longer reconciled. The Cluster is
+// taken from the owner reference, as it might not exist anymore.
+func (r *GardenerShootControlPlaneReconciler) disconnectWorkloadCluster(cpc ControlPlaneContext) {
+	if r.ClusterCache == nil {
+		return
+	}
+	for _, ref := range cpc.shootControlPlane.OwnerReferences {
+		if ref.Kind != "Cluster" || !strings.HasPrefix(ref.APIVersion, clusterv1beta2.GroupVersion.Group+"/") {
+			continue
+		}
+		r.ClusterCache.Disconnect(clustercache.Key{
+			ClusterName: multicluster.ClusterName(cpc.clusterName),
+			ObjectKey:   client.ObjectKey{Namespace: cpc.shootControlPlane.Namespace, Name: ref.Name},
+		})
+	}
+}
+
+// setShootOwner references the CAPI Cluster owning the Shoot and labels the Shoot with the shard of the Cluster, so that
+// it is cached by the replica reconciling the Cluster.
+func (r *GardenerShootControlPlaneReconciler) setShootOwner(cpc ControlPlaneContext, shoot *gardenercorev1beta1.Shoot) {
+	owner := shootOwner(cpc)
+	providerutil.SetShootOwner(shoot, owner)
+	r.Sharder.SetShardLabel(shoot, controlplanev1alpha2.ShootShardLabel, owner.ClusterName, owner.Namespace)
+}
+
+func shootOwner(cpc ControlPlaneContext) providerutil.ShootOwner {
+	return providerutil.ShootOwner{
+		ClusterName: multicluster.ClusterName(cpc.clusterName),
+		ObjectKey:   client.ObjectKeyFromObject(cpc.cluster),
+	}
+}
+
+// reconcileDeleteForMove removes the finalizer of a GardenerShootControlPlane which was moved to another management
+// cluster by `clusterctl move`, which takes over the Shoot and the Secrets.
+func (r *GardenerShootControlPlaneReconciler) reconcileDeleteForMove(cpc ControlPlaneContext, c client.Client) (ctrl.Result, error) {
+	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "deleteForMove")
+	log.Info("GardenerShootControlPlane is deleted for move, keeping Shoot")
+
+	patch := client.MergeFrom(cpc.shootControlPlane.DeepCopy())
+	if controllerutil.RemoveFinalizer(cpc.shootControlPlane, clusterv1beta2.ClusterFinalizer) {
+		if err := c.Patch(cpc.ctx, cpc.shootControlPlane, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	r.disconnectWorkloadCluster(cpc)
+	return ctrl.Result{}, nil
+}
+
 func (r *GardenerShootControlPlaneReconciler) reconcileDelete(cpc ControlPlaneContext, c client.Client) (ctrl.Result, error) {
 	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "delete")
 	log.Info("Reconciling Delete GardenerShootControlPlane")
 
-	err := c.Delete(cpc.ctx, newEmptyShootAccessSecret(cpc.cluster))
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, err
+	for _, secret := range []*v1.Secret{
+		newEmptyShootAccessSecret(cpc.cluster),
+		newEmptyShootViewerAccessSecret(cpc.cluster),
+		newEmptyShootCASecret(cpc.cluster),
+	} {
+		if err := c.Delete(cpc.ctx, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			log.Info("Secret not found", "secret", client.ObjectKeyFromObject(secret))
 		}
-		log.Info("Shoot Access Secret not found")
 	}
-	err = r.GardenerClient.Get(cpc.ctx, client.ObjectKeyFromObject(cpc.shoot), cpc.shoot)
+	shoot := cpc.shoot
+	err := r.getShoot(cpc)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
@@ -278,6 +680,11 @@ func (r *GardenerShootControlPlaneReconciler) reconcileDelete(cpc ControlPlaneCo
 		return ctrl.Result{Requeue: true, RequeueAfter: 1 * time.Minute}, nil
 	}
 
+	// The Shoot is gone, hence the replicas are no longer referenced.
+	if err := r.deleteReplicas(cpc, shoot, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	patch := client.MergeFrom(cpc.shootControlPlane.DeepCopy())
 	if controllerutil.RemoveFinalizer(cpc.shootControlPlane, clusterv1beta2.ClusterFinalizer) {
 		if err = c.Patch(cpc.ctx, cpc.shootControlPlane, patch); err != nil {
@@ -285,15 +692,17 @@ func (r *GardenerShootControlPlaneReconciler) reconcileDelete(cpc ControlPlaneCo
 		}
 	}
 
+	r.disconnectWorkloadCluster(cpc)
+
 	log.Info("Successfully reconciled deletion of GardenerShootControlPlane")
 	record.Event(cpc.shootControlPlane, "GardenerShootControlPlaneReconcile", "Reconciled")
 	return ctrl.Result{}, nil
 }
 
-func (r *GardenerShootControlPlaneReconciler) getWorkerPoolsForCluster(cpc ControlPlaneContext, c client.Client) ([]infrastructurev1alpha1.GardenerWorkerPool, error) {
+func (r *GardenerShootControlPlaneReconciler) getWorkerPoolsForCluster(cpc ControlPlaneContext, c client.Client) ([]infrastructurev1alpha2.GardenerWorkerPool, error) {
 	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "getWorkerPoolsForCluster")
 	machinePools := &clusterv1beta2.MachinePoolList{}
-	var workers []infrastructurev1alpha1.GardenerWorkerPool
+	var workers []infrastructurev1alpha2.GardenerWorkerPool
 	if err := c.List(cpc.ctx, machinePools, client.InNamespace(cpc.cluster.Namespace)); err != nil {
 		log.Error(err, "Failed to list machine pools")
 		return nil, err
@@ -305,11 +714,11 @@ func (r *GardenerShootControlPlaneReconciler) getWorkerPoolsForCluster(cpc Contr
 		if machinePool.Spec.ClusterName != cpc.cluster.Name {
 			continue
 		}
-		if machinePool.Spec.Template.Spec.InfrastructureRef.GroupKind() != infrastructurev1alpha1.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind() {
+		if machinePool.Spec.Template.Spec.InfrastructureRef.GroupKind() != infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind() {
 			continue
 		}
 		workerRef := machinePool.Spec.Template.Spec.InfrastructureRef
-		workerPool := &infrastructurev1alpha1.GardenerWorkerPool{}
+		workerPool := &infrastructurev1alpha2.GardenerWorkerPool{}
 		if err := c.Get(cpc.ctx, client.ObjectKey{Name: workerRef.Name, Namespace: machinePool.Namespace}, workerPool); err != nil {
 			if apierrors.IsNotFound(err) {
 				// TODO(tobschli): Notify the user that the specified worker pool could not be found.
@@ -347,7 +756,36 @@ func (r *GardenerShootControlPlaneReconciler) reconcileShootControlPlaneEndpoint
 }
 
 func (r *GardenerShootControlPlaneReconciler) reconcileShootAccess(cpc ControlPlaneContext, c client.Client) error {
-	secret := newEmptyShootAccessSecret(cpc.cluster)
+	return r.reconcileKubeconfigSecret(cpc, c, newEmptyShootAccessSecret(cpc.cluster), KubeConfigValiditySeconds, func() ([]byte, error) {
+		adminKubeconfigRequest := &gardenerauthenticationv1alpha1.AdminKubeconfigRequest{
+			Spec: gardenerauthenticationv1alpha1.AdminKubeconfigRequestSpec{
+				ExpirationSeconds: ptr.To(int64(KubeConfigValiditySeconds)),
+			},
+		}
+		if err := r.GardenerClient.SubResource("adminkubeconfig").Create(cpc.ctx, cpc.shoot, adminKubeconfigRequest); err != nil {
+			return nil, err
+		}
+		return adminKubeconfigRequest.Status.Kubeconfig, nil
+	})
+}
+
+func (r *GardenerShootControlPlaneReconciler) reconcileShootViewerAccess(cpc ControlPlaneContext, c client.Client) error {
+	return r.reconcileKubeconfigSecret(cpc, c, newEmptyShootViewerAccessSecret(cpc.cluster), ViewerKubeConfigValiditySeconds, func() ([]byte, error) {
+		viewerKubeconfigRequest := &gardenerauthenticationv1alpha1.ViewerKubeconfigRequest{
+			Spec: gardenerauthenticationv1alpha1.ViewerKubeconfigRequestSpec{
+				ExpirationSeconds: ptr.To(int64(ViewerKubeConfigValiditySeconds)),
+			},
+		}
+		if err := r.GardenerClient.SubResource("viewerkubeconfig").Create(cpc.ctx, cpc.shoot, viewerKubeconfigRequest); err != nil {
+			return nil, err
+		}
+		return viewerKubeconfigRequest.Status.Kubeconfig, nil
+	})
+}
+
+// reconcileKubeconfigSecret ensures that the given Secret contains a kubeconfig which is valid for at least five more
+// minutes. Otherwise, a new kubeconfig with the given validity is requested from Gardener.
+func (r *GardenerShootControlPlaneReconciler) reconcileKubeconfigSecret(cpc ControlPlaneContext, c client.Client, secret *v1.Secret, validitySeconds int64, requestKubeconfig func() ([]byte, error)) error {
 	err := c.Get(cpc.ctx, client.ObjectKeyFromObject(secret), secret)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -359,32 +797,59 @@ func (r *GardenerShootControlPlaneReconciler) reconcileShootAccess(cpc ControlPl
 		}
 	}
 
+	labelsChanged := ensureSecretLabels(secret, cpc.cluster)
 	valid, err := isKubeConfigValid(secret.Data)
 	if err != nil {
 		return fmt.Errorf("could not get validity from secret data: %w", err)
 	}
 	if valid {
+		if labelsChanged {
+			return c.Update(cpc.ctx, secret)
+		}
 		// The kubeconfig is still valid, no need to update it.
 		return nil
 	}
 
-	adminKubeconfigRequest := &gardenerauthenticationv1alpha1.AdminKubeconfigRequest{
-		Spec: gardenerauthenticationv1alpha1.AdminKubeconfigRequestSpec{
-			ExpirationSeconds: ptr.To(int64(KubeConfigValiditySeconds)),
-		},
-	}
-	if err := r.GardenerClient.SubResource("adminkubeconfig").Create(cpc.ctx, cpc.shoot, adminKubeconfigRequest); err != nil {
+	kubeconfig, err := requestKubeconfig()
+	if err != nil {
 		return err
 	}
 
 	secret.Data = map[string][]byte{
-		"value":    adminKubeconfigRequest.Status.Kubeconfig,
-		"validity": []byte(strconv.FormatInt(time.Now().Add(KubeConfigValiditySeconds*time.Second).Unix(), 10)),
+		"value":    kubeconfig,
+		"validity": []byte(strconv.FormatInt(time.Now().Add(time.Duration(validitySeconds)*time.Second).Unix(), 10)),
 	}
 
 	return c.Update(cpc.ctx, secret)
 }
 
+// reconcileShootCA publishes the CA bundle of the Shoot, which Gardener maintains in the `<shoot>.ca-cluster` ConfigMap
+// in the project namespace, as CAPI CA Secret.
+func (r *GardenerShootControlPlaneReconciler) reconcileShootCA(cpc ControlPlaneContext, c client.Client) error {
+	configMap := &v1.ConfigMap{}
+	if err := r.GardenerClient.Get(cpc.ctx, client.ObjectKey{
+		Name:      gardener.ComputeShootProjectResourceName(cpc.shoot.Name, gardener.ShootProjectConfigMapSuffixCACluster),
+		Namespace: cpc.shoot.Namespace,
+	}, configMap); err != nil {
+		return fmt.Errorf("could not get CA ConfigMap of shoot: %w", err)
+	}
+
+	caBundle, ok := configMap.Data[shootCADataKey]
+	if !ok || len(caBundle) == 0 {
+		return fmt.Errorf("CA ConfigMap %s does not contain %q", client.ObjectKeyFromObject(configMap), shootCADataKey)
+	}
+
+	secret := newEmptyShootCASecret(cpc.cluster)
+	_, err := controllerutil.CreateOrUpdate(cpc.ctx, c, secret, func() error {
+		ensureSecretLabels(secret, cpc.cluster)
+		secret.Data = map[string][]byte{
+			clusterCADataKey: []byte(caBundle),
+		}
+		return nil
+	})
+	return err
+}
+
 func isKubeConfigValid(data map[string][]byte) (bool, error) {
 	validity, ok := data["validity"]
 	if !ok {
@@ -403,14 +868,45 @@ func newEmptyShootAccessSecret(cluster *clusterv1beta2.Cluster) *v1.Secret {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-kubeconfig", cluster.Name),
 			Namespace: cluster.Namespace,
-			Labels: map[string]string{
-				"cluster.x-k8s.io/cluster-name": cluster.Name,
-			},
+			Labels:    secretLabels(cluster),
 		},
 		Type: clusterv1beta2.ClusterSecretType,
 	}
 }
 
+// secretLabels returns the labels of the Secrets generated for the given Cluster. The move hierarchy label makes
+// `clusterctl move` pick up the Secrets.
+func secretLabels(cluster *clusterv1beta2.Cluster) map[string]string {
+	return map[string]string{
+		clusterv1beta2.ClusterNameLabel:           cluster.Name,
+		clusterctlv1.ClusterctlMoveHierarchyLabel: "",
+	}
+}
+
+// ensureSecretLabels adds the labels of secretLabels to the given Secret. It returns true if the labels changed.
+func ensureSecretLabels(secret *v1.Secret, cluster *clusterv1beta2.Cluster) bool {
+	changed := false
+	for key, value := range secretLabels(cluster) {
+		if current, ok := secret.Labels[key]; !ok || current != value {
+			metav1.SetMetaDataLabel(&secret.ObjectMeta, key, value)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func newEmptyShootViewerAccessSecret(cluster *clusterv1beta2.Cluster) *v1.Secret {
+	secret := newEmptyShootAccessSecret(cluster)
+	secret.Name = fmt.Sprintf("%s-viewer-kubeconfig", cluster.Name)
+	return secret
+}
+
+func newEmptyShootCASecret(cluster *clusterv1beta2.Cluster) *v1.Secret {
+	secret := newEmptyShootAccessSecret(cluster)
+	secret.Name = fmt.Sprintf("%s-ca", cluster.Name)
+	return secret
+}
+
 func (r *GardenerShootControlPlaneReconciler) syncControlPlaneSpecs(cpc ControlPlaneContext, c client.Client) error {
 	log := runtimelog.FromContext(cpc.ctx).WithValues("operation", "syncSpecs")
 
@@ -419,6 +915,8 @@ func (r *GardenerShootControlPlaneReconciler) syncControlPlaneSpecs(cpc ControlP
 
 	if r.PrioritizeShoot {
 		providerutil.SyncGSCPSpecFromShoot(originalShoot, cpc.shootControlPlane)
+		// The Shoot references the replicas, whereas the GardenerShootControlPlane references the replicated objects.
+		providerutil.RestoreReplicatedReferences(cpc.shootControlPlane, originalShootControlPlane, originalShoot.Name)
 
 		// Check if GardenerShootControlPlane spec has changed before patching
 		if !providerutil.IsControlPlaneSpecEqual(originalShootControlPlane, cpc.shootControlPlane) {
@@ -435,6 +933,7 @@ func (r *GardenerShootControlPlaneReconciler) syncControlPlaneSpecs(cpc ControlP
 		}
 	} else {
 		providerutil.SyncShootSpecFromGSCP(cpc.shoot, originalShootControlPlane)
+		providerutil.ReplaceReplicatedReferences(cpc.shoot, cpc.replicatedResources)
 
 		// Check if Shoot spec has changed before patching
 		if !providerutil.IsShootSpecEqual(originalShoot, cpc.shoot) {
@@ -465,6 +964,28 @@ func (r *GardenerShootControlPlaneReconciler) updateStatus(cpc ControlPlaneConte
 		}
 		cpc.shootControlPlane.Status.ShootStatus = cpc.shoot.Status
 	}
+
+	// Fields and conditions of the Cluster API v1beta2 contract.
+	status := &cpc.shootControlPlane.Status
+	if status.Initialized {
+		status.Initialization.ControlPlaneInitialized = ptr.To(true)
+	}
+	status.ExternalManagedControlPlane = ptr.To(true)
+	availableCondition := metav1.Condition{
+		Type:               clusterv1beta2.AvailableCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1beta2.NotAvailableReason,
+		Message:            "Shoot is not healthy",
+		ObservedGeneration: cpc.shootControlPlane.Generation,
+	}
+	if status.Ready {
+		availableCondition.Status = metav1.ConditionTrue
+		availableCondition.Reason = clusterv1beta2.AvailableReason
+		availableCondition.Message = ""
+	}
+	meta.SetStatusCondition(&status.Conditions, availableCondition)
+	providerutil.SetPausedCondition(&status.Conditions, cpc.shootControlPlane.Generation, false)
+
 	if apiequality.Semantic.DeepEqual(cpc.shootControlPlane.Status, formerShootStatus) {
 		return nil
 	}
@@ -483,37 +1004,23 @@ func controlPlaneReady(shootStatus gardenercorev1beta1.ShootStatus) bool {
 	return false
 }
 
-func injectReferenceLabels(
-	shoot *gardenercorev1beta1.Shoot,
-	shootControlPlane *controlplanev1alpha1.GardenerShootControlPlane,
-	infraCluster *infrastructurev1alpha1.GardenerShootCluster,
-	workerPools []infrastructurev1alpha1.GardenerWorkerPool,
-	isKCP bool,
-	kcpClusterName string,
-) {
-	labels := map[string]string{
-		controlplanev1alpha1.GSCPReferenceNameKey:      shootControlPlane.Name,
-		controlplanev1alpha1.GSCPReferenceNamespaceKey: shootControlPlane.Namespace,
-
-		infrastructurev1alpha1.GSCReferenceNameKey:      infraCluster.Name,
-		infrastructurev1alpha1.GSCReferenceNamespaceKey: infraCluster.Namespace,
-	}
-	if isKCP {
-		labels[controlplanev1alpha1.GSCPReferenceClusterNameKey] = kcpClusterName
-		labels[infrastructurev1alpha1.GSCReferecenceClusterNameKey] = kcpClusterName
-		labels[infrastructurev1alpha1.GSWReferenceClusterNameKey] = kcpClusterName
-	}
-
-	for _, workerPool := range workerPools {
-		labels[infrastructurev1alpha1.GSWReferenceNamePrefix+workerPool.Name] = infrastructurev1alpha1.GSWTrue
-		labels[infrastructurev1alpha1.GSWReferenceNamespaceKey] = workerPool.Namespace
-	}
+// legacyReferenceLabelPrefixes are the prefixes of the labels which referenced the CAPI objects of a Shoot before the
+// ShootOwnerAnnotation was introduced.
+var legacyReferenceLabelPrefixes = []string{
+	"controlplane.cluster.x-k8s.io/gscp_",
+	"controlplane.cluster.x-k8s.io/gsc_",
+	"infrastructure.cluster.x-k8s.io/gsc_",
+	"infrastructure.cluster.x-k8s.io/gsw_",
+}
 
-	if shoot.Labels == nil {
-		shoot.Labels = labels
-	} else {
-		for k, v := range labels {
-			shoot.Labels[k] = v
+// removeLegacyReferenceLabels removes the reference labels written by earlier versions from the given Shoot.
+func removeLegacyReferenceLabels(shoot *gardenercorev1beta1.Shoot) {
+	for key := range shoot.Labels {
+		for _, prefix := range legacyReferenceLabelPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				delete(shoot.Labels, key)
+				break
+			}
 		}
 	}
 }
@@ -530,51 +1037,85 @@ func (r *GardenerShootControlPlaneReconciler) SetupWithManager(mgr mcmanager.Man
 					targetCluster.GetCache(),
 					&gardenercorev1beta1.Shoot{},
 					handler.TypedEnqueueRequestsFromMapFunc[client.Object, mcreconcile.Request](r.MapShootToControlPlaneObject),
+					r.Sharder.Predicate(providerutil.ShootOwnerShardKey),
 				),
 			)
 	} else {
 		controller.
 			Named(name).
-			For(&controlplanev1alpha1.GardenerShootControlPlane{})
+			For(&controlplanev1alpha2.GardenerShootControlPlane{}).
+			// Reconcile after the pause window, e.g. at the end of `clusterctl move`.
+			Watches(
+				&clusterv1beta2.Cluster{},
+				mchandler.TypedEnqueueRequestsFromMapFunc[client.Object, mcreconcile.Request](r.MapClusterToControlPlaneObject),
+				mcbuilder.WithPredicates(predicates.ClusterUnpaused(mgr.GetLocalManager().GetScheme(), mgr.GetLogger())),
+			).
+			// Keep the replicas in the project namespace in sync. Only Secrets and ConfigMaps which carry the replicate
+			// label, or lose it, are of interest.
+			Watches(&v1.Secret{}, mapReferencedResourceToControlPlanes, mcbuilder.WithPredicates(replicatedResourcePredicate)).
+			Watches(&v1.ConfigMap{}, mapReferencedResourceToControlPlanes, mcbuilder.WithPredicates(replicatedResourcePredicate))
+	}
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source(name))
 	}
 	return controller.Complete(r)
 }
 
-// MapShootToControlPlaneObject maps a Shoot object to a GardenerShootControlPlane object.
-func (r *GardenerShootControlPlaneReconciler) MapShootToControlPlaneObject(ctx context.Context, obj client.Object) []mcreconcile.Request {
-	var (
-		log          = runtimelog.FromContext(ctx).WithValues("shoot", client.ObjectKeyFromObject(obj))
-		clusterName  string
-		controlPlane *controlplanev1alpha1.GardenerShootControlPlane
-	)
-	shoot, ok := obj.(*gardenercorev1beta1.Shoot)
+// MapClusterToControlPlaneObject maps a Cluster object to the GardenerShootControlPlane it references.
+func (r *GardenerShootControlPlaneReconciler) MapClusterToControlPlaneObject(_ context.Context, obj client.Object) []mcreconcile.Request {
+	cluster, ok := obj.(*clusterv1beta2.Cluster)
 	if !ok {
-		log.Error(fmt.Errorf("could not assert object to Shoot"), "")
 		return nil
 	}
-
-	namespace, ok := shoot.GetLabels()[controlplanev1alpha1.GSCPReferenceNamespaceKey]
-	if !ok {
-		log.Info("Could not find gscp namespace on label")
+	ref := cluster.Spec.ControlPlaneRef
+	if ref.APIGroup != controlplanev1alpha2.GroupVersion.Group || ref.Kind != providerutil.KindGardenerShootControlPlane {
 		return nil
 	}
+	return []mcreconcile.Request{{Request: reconcile.Request{NamespacedName: client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}}}}
+}
 
-	name, ok := shoot.GetLabels()[controlplanev1alpha1.GSCPReferenceNameKey]
-	if !ok {
-		log.Info("Could not find gscp name on label")
-	}
-	if r.IsKCP {
-		clusterName, ok = shoot.GetLabels()[controlplanev1alpha1.GSCPReferenceClusterNameKey]
-		if !ok {
-			log.Info("Could not find gscp cluster on label")
-		}
-	}
+// MapShootToControlPlaneObject maps a Shoot object to the GardenerShootControlPlane of its owner.
+func (r *GardenerShootControlPlaneReconciler) MapShootToControlPlaneObject(ctx context.Context, obj client.Object) []mcreconcile.Request {
+	return providerutil.MapShootToOwnedObjects(ctx, r.Manager, obj, &controlplanev1alpha2.GardenerShootControlPlaneList{})
+}
 
-	controlPlane = &controlplanev1alpha1.GardenerShootControlPlane{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-	}
-	return []mcreconcile.Request{{Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(controlPlane)}, ClusterName: multicluster.ClusterName(clusterName)}}
+// replicatedResourcePredicate admits events of Secrets and ConfigMaps which carry the replicate label, and updates
+// which add or remove it.
+var replicatedResourcePredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return providerutil.IsReplicated(e.Object)
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return providerutil.IsReplicated(e.ObjectOld) || providerutil.IsReplicated(e.ObjectNew)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return providerutil.IsReplicated(e.Object)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return providerutil.IsReplicated(e.Object)
+	},
+}
+
+// mapReferencedResourceToControlPlanes maps a replicated Secret or ConfigMap to the GardenerShootControlPlanes in its
+// namespace which reference it. For updates, both the old and the new object are mapped, so that removing the replicate
+// label is picked up as well.
+func mapReferencedResourceToControlPlanes(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		if !providerutil.IsReplicated(obj) {
+			return nil
+		}
+		controlPlaneList := &controlplanev1alpha2.GardenerShootControlPlaneList{}
+		if err := cl.GetClient().List(ctx, controlPlaneList, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+		reference := providerutil.ResourceReference{Kind: providerutil.ResourceKind(obj), Name: obj.GetName()}
+		var requests []mcreconcile.Request
+		for i := range controlPlaneList.Items {
+			controlPlane := &controlPlaneList.Items[i]
+			if slices.Contains(providerutil.ReferencedResources(controlPlane), reference) {
+				requests = append(requests, mcreconcile.Request{ClusterName: clusterName, Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(controlPlane)}})
+			}
+		}
+		return requests
+	})
 }
@@ -6,19 +6,36 @@ package controller
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/test/matchers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 var _ = Describe("GardenerShootControlPlane Controller", func() {
@@ -31,7 +48,7 @@ var _ = Describe("GardenerShootControlPlane Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		gardenershootcontrolplane := &controlplanev1alpha1.GardenerShootControlPlane{}
+		gardenershootcontrolplane := &controlplanev1alpha2.GardenerShootControlPlane{}
 
 		BeforeEach(func() {
 			By("Ensuring the custom resource for the Kind GardenerShootControlPlane")
@@ -42,7 +59,7 @@ var _ = Describe("GardenerShootControlPlane Controller", func() {
 			}).WithTimeout(10 * time.Second).Should(Succeed())
 			if err != nil && errors.IsNotFound(err) {
 				By("Creating the custom resource for the Kind GardenerShootControlPlane")
-				resource := &controlplanev1alpha1.GardenerShootControlPlane{
+				resource := &controlplanev1alpha2.GardenerShootControlPlane{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
@@ -56,7 +73,7 @@ var _ = Describe("GardenerShootControlPlane Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &controlplanev1alpha1.GardenerShootControlPlane{}
+			resource := &controlplanev1alpha2.GardenerShootControlPlane{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -82,3 +99,444 @@ var _ = Describe("GardenerShootControlPlane Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Delete for move", func() {
+	It("should remove the finalizer of a paused GardenerShootControlPlane which is deleted for move", func() {
+		ctx := context.Background()
+		shootControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "moved",
+				Namespace: "default",
+				Annotations: map[string]string{
+					clusterv1beta2.PausedAnnotation:      "",
+					clusterctlv1.DeleteForMoveAnnotation: "",
+				},
+				Finalizers: []string{clusterv1beta2.ClusterFinalizer},
+			},
+		}
+		Expect(k8sClient.Create(ctx, shootControlPlane)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, shootControlPlane)).To(Succeed())
+		Eventually(ctx, func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(shootControlPlane), shootControlPlane)).To(Succeed())
+			g.Expect(shootControlPlane.DeletionTimestamp).NotTo(BeNil())
+		}).WithTimeout(10 * time.Second).Should(Succeed())
+
+		controllerReconciler := &GardenerShootControlPlaneReconciler{Manager: mgr}
+		_, err := controllerReconciler.Reconcile(ctx, mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(shootControlPlane)},
+			ClusterName: mcmanager.LocalCluster,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(ctx, func() error {
+			return k8sClient.Get(ctx, client.ObjectKeyFromObject(shootControlPlane), &controlplanev1alpha2.GardenerShootControlPlane{})
+		}).WithTimeout(10 * time.Second).Should(matchers.BeNotFoundError())
+	})
+})
+
+var _ = Describe("Shoot CA Secret", func() {
+	var (
+		ctx          context.Context
+		gardenClient client.Client
+		c            client.Client
+		reconciler   *GardenerShootControlPlaneReconciler
+		cpc          ControlPlaneContext
+		configMap    *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+		c = fakeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		reconciler = &GardenerShootControlPlaneReconciler{GardenerClient: gardenClient}
+		cpc = ControlPlaneContext{
+			ctx:     ctx,
+			cluster: &clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}},
+			shoot:   &gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-dev"}},
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar.ca-cluster", Namespace: "garden-dev"},
+			Data:       map[string]string{"ca.crt": "ca-bundle"},
+		}
+	})
+
+	It("should publish the CA bundle of the Shoot", func() {
+		Expect(gardenClient.Create(ctx, configMap)).To(Succeed())
+
+		Expect(reconciler.reconcileShootCA(cpc, c)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "foo-ca", Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Type).To(Equal(clusterv1beta2.ClusterSecretType))
+		Expect(secret.Labels).To(Equal(map[string]string{
+			"cluster.x-k8s.io/cluster-name":              "foo",
+			"clusterctl.cluster.x-k8s.io/move-hierarchy": "",
+		}))
+		Expect(secret.Data).To(Equal(map[string][]byte{"tls.crt": []byte("ca-bundle")}))
+	})
+
+	It("should update the CA Secret when the CA bundle is rotated", func() {
+		Expect(gardenClient.Create(ctx, configMap)).To(Succeed())
+		Expect(reconciler.reconcileShootCA(cpc, c)).To(Succeed())
+
+		configMap.Data["ca.crt"] = "ca-bundle-old\nca-bundle-new"
+		Expect(gardenClient.Update(ctx, configMap)).To(Succeed())
+		Expect(reconciler.reconcileShootCA(cpc, c)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "foo-ca", Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("tls.crt", []byte("ca-bundle-old\nca-bundle-new")))
+	})
+
+	It("should fail if the CA ConfigMap does not exist yet", func() {
+		Expect(reconciler.reconcileShootCA(cpc, c)).To(MatchError(ContainSubstring("could not get CA ConfigMap of shoot")))
+	})
+
+	It("should fail if the CA ConfigMap does not contain the CA bundle", func() {
+		delete(configMap.Data, "ca.crt")
+		Expect(gardenClient.Create(ctx, configMap)).To(Succeed())
+
+		Expect(reconciler.reconcileShootCA(cpc, c)).To(MatchError(ContainSubstring(`does not contain "ca.crt"`)))
+	})
+})
+
+var _ = Describe("Shoot owner", func() {
+	var (
+		ctx          context.Context
+		gardenClient client.Client
+		reconciler   *GardenerShootControlPlaneReconciler
+		cpc          ControlPlaneContext
+		shoot        *gardenercorev1beta1.Shoot
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		shoot = &gardenercorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "garden-dev",
+				Annotations: map[string]string{
+					"controlplane.cluster.x-k8s.io/owner": "old/foo",
+				},
+				Labels: map[string]string{
+					"controlplane.cluster.x-k8s.io/gscp_namespace":    "old",
+					"controlplane.cluster.x-k8s.io/gscp_name":         "foo",
+					"infrastructure.cluster.x-k8s.io/gsc_namespace":   "old",
+					"infrastructure.cluster.x-k8s.io/gsc_name":        "foo",
+					"infrastructure.cluster.x-k8s.io/gsw_namespace":   "old",
+					"infrastructure.cluster.x-k8s.io/gsw_name-worker": "true",
+					"unrelated": "label",
+				},
+			},
+			Spec: gardenercorev1beta1.ShootSpec{Region: "eu-west-1"},
+		}
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(shoot).Build()
+		reconciler = &GardenerShootControlPlaneReconciler{GardenerClient: gardenClient}
+
+		cpc = ControlPlaneContext{
+			ctx:     ctx,
+			cluster: &clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "new"}},
+			shootControlPlane: &controlplanev1alpha2.GardenerShootControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-cp", Namespace: "new"},
+			},
+			shoot: shoot.DeepCopy(),
+		}
+	})
+
+	It("should update the owner and remove the legacy reference labels after the CAPI objects were moved", func() {
+		Expect(reconciler.reconcileShootOwner(cpc)).To(Succeed())
+
+		updatedShoot := &gardenercorev1beta1.Shoot{}
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), updatedShoot)).To(Succeed())
+		Expect(updatedShoot.Annotations).To(Equal(map[string]string{
+			"controlplane.cluster.x-k8s.io/owner": "new/foo",
+		}))
+		Expect(updatedShoot.Labels).To(Equal(map[string]string{
+			"controlplane.cluster.x-k8s.io/owner-hash": providerutil.ShootOwner{ObjectKey: client.ObjectKey{Namespace: "new", Name: "foo"}}.Hash(),
+			"unrelated": "label",
+		}))
+		Expect(updatedShoot.Spec).To(Equal(shoot.Spec))
+	})
+
+	It("should include the logical cluster in the owner", func() {
+		cpc.clusterName = "root-org"
+
+		Expect(reconciler.reconcileShootOwner(cpc)).To(Succeed())
+
+		updatedShoot := &gardenercorev1beta1.Shoot{}
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), updatedShoot)).To(Succeed())
+		Expect(updatedShoot.Annotations).To(HaveKeyWithValue("controlplane.cluster.x-k8s.io/owner", "root-org/new/foo"))
+	})
+
+	It("should label the Shoot with the shard of its owner when sharding is enabled", func() {
+		for _, shards := range []int{4, 3} {
+			sharder, err := sharding.New(fakeclient.NewClientBuilder().Build(), sharding.Options{Shards: shards, Namespace: "default", Name: "capga", Identity: "a"})
+			Expect(err).NotTo(HaveOccurred())
+			reconciler.Sharder = sharder
+
+			Expect(reconciler.reconcileShootOwner(cpc)).To(Succeed())
+
+			Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), cpc.shoot)).To(Succeed())
+			Expect(cpc.shoot.Labels).To(HaveKeyWithValue("controlplane.cluster.x-k8s.io/shard", strconv.Itoa(sharder.ShardFor("", "new"))))
+		}
+	})
+
+	It("should not patch the Shoot if the owner is up to date", func() {
+		Expect(reconciler.reconcileShootOwner(cpc)).To(Succeed())
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), cpc.shoot)).To(Succeed())
+		resourceVersion := cpc.shoot.ResourceVersion
+
+		Expect(reconciler.reconcileShootOwner(cpc)).To(Succeed())
+
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), cpc.shoot)).To(Succeed())
+		Expect(cpc.shoot.ResourceVersion).To(Equal(resourceVersion))
+	})
+
+	It("should map an unpaused Cluster to its GardenerShootControlPlane", func() {
+		cluster := &clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "new"}}
+		cluster.Spec.ControlPlaneRef = clusterv1beta2.ContractVersionedObjectReference{
+			APIGroup: "controlplane.cluster.x-k8s.io",
+			Kind:     "GardenerShootControlPlane",
+			Name:     "foo-cp",
+		}
+
+		Expect(reconciler.MapClusterToControlPlaneObject(ctx, cluster)).To(ConsistOf(
+			HaveField("Request.NamespacedName", Equal(types.NamespacedName{Namespace: "new", Name: "foo-cp"})),
+		))
+
+		cluster.Spec.ControlPlaneRef.Kind = "KubeadmControlPlane"
+		Expect(reconciler.MapClusterToControlPlaneObject(ctx, cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SecretBinding migration", func() {
+	var (
+		ctx           context.Context
+		gardenClient  client.Client
+		c             client.Client
+		reconciler    *GardenerShootControlPlaneReconciler
+		cpc           ControlPlaneContext
+		shoot         *gardenercorev1beta1.Shoot
+		secretBinding *gardenercorev1beta1.SecretBinding
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		shoot = &gardenercorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-dev"},
+			Spec:       gardenercorev1beta1.ShootSpec{SecretBindingName: ptr.To("aws")},
+		}
+		secretBinding = &gardenercorev1beta1.SecretBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "garden-dev"},
+			SecretRef:  corev1.SecretReference{Name: "aws-secret", Namespace: "garden-dev"},
+		}
+		shootControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-cp", Namespace: "default"},
+			Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+				ProjectNamespace:  "garden-dev",
+				SecretBindingName: ptr.To("aws"),
+				Provider:          controlplanev1alpha2.ProviderGSCP{Type: "aws"},
+			},
+		}
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(shoot, secretBinding).Build()
+		c = fakeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(shootControlPlane).WithStatusSubresource(shootControlPlane).Build()
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), shoot)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(shootControlPlane), shootControlPlane)).To(Succeed())
+		reconciler = &GardenerShootControlPlaneReconciler{GardenerClient: gardenClient}
+		cpc = ControlPlaneContext{
+			ctx:               ctx,
+			shootControlPlane: shootControlPlane,
+			shoot:             shoot.DeepCopy(),
+		}
+	})
+
+	expectMigrated := func(credentialsBindingName string) {
+		GinkgoHelper()
+		updatedShoot := &gardenercorev1beta1.Shoot{}
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), updatedShoot)).To(Succeed())
+		Expect(updatedShoot.Spec.SecretBindingName).To(BeNil())
+		Expect(updatedShoot.Spec.CredentialsBindingName).To(PointTo(Equal(credentialsBindingName)))
+
+		updatedShootControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(cpc.shootControlPlane), updatedShootControlPlane)).To(Succeed())
+		Expect(updatedShootControlPlane.Spec.SecretBindingName).To(BeNil())
+		Expect(updatedShootControlPlane.Spec.CredentialsBindingName).To(PointTo(Equal(credentialsBindingName)))
+		Expect(meta.FindStatusCondition(updatedShootControlPlane.Status.Conditions, controlplanev1alpha2.CredentialsBindingMigratedCondition)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status": Equal(metav1.ConditionTrue),
+			"Reason": Equal(controlplanev1alpha2.CredentialsBindingMigratedReason),
+		})))
+	}
+
+	It("should create a CredentialsBinding and switch the Shoot and the GardenerShootControlPlane to it", func() {
+		Expect(reconciler.migrateSecretBinding(cpc, c, true)).To(Succeed())
+
+		expectMigrated("aws")
+		credentialsBinding := &securityv1alpha1.CredentialsBinding{}
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "aws", Namespace: "garden-dev"}, credentialsBinding)).To(Succeed())
+		Expect(credentialsBinding.Provider.Type).To(Equal("aws"))
+		Expect(credentialsBinding.CredentialsRef).To(Equal(corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "aws-secret", Namespace: "garden-dev"}))
+	})
+
+	It("should reuse an existing CredentialsBinding referencing the same Secret", func() {
+		existing := providerutil.CredentialsBindingFromSecretBinding(secretBinding, "aws")
+		existing.Name = "aws-credentials"
+		Expect(gardenClient.Create(ctx, existing)).To(Succeed())
+
+		Expect(reconciler.migrateSecretBinding(cpc, c, true)).To(Succeed())
+
+		expectMigrated("aws-credentials")
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "aws", Namespace: "garden-dev"}, &securityv1alpha1.CredentialsBinding{})).To(matchers.BeNotFoundError())
+	})
+
+	It("should only switch the GardenerShootControlPlane if the Shoot was already switched", func() {
+		shoot.Spec.SecretBindingName = nil
+		shoot.Spec.CredentialsBindingName = ptr.To("aws-credentials")
+		Expect(gardenClient.Update(ctx, shoot)).To(Succeed())
+		cpc.shoot = shoot.DeepCopy()
+
+		Expect(reconciler.migrateSecretBinding(cpc, c, true)).To(Succeed())
+
+		expectMigrated("aws-credentials")
+	})
+
+	It("should only switch the GardenerShootControlPlane if the Shoot does not exist yet", func() {
+		Expect(reconciler.migrateSecretBinding(cpc, c, false)).To(Succeed())
+
+		Expect(cpc.shootControlPlane.Spec.SecretBindingName).To(BeNil())
+		Expect(cpc.shootControlPlane.Spec.CredentialsBindingName).To(PointTo(Equal("aws")))
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "aws", Namespace: "garden-dev"}, &securityv1alpha1.CredentialsBinding{})).To(Succeed())
+	})
+
+	It("should report a failed migration and keep the SecretBinding", func() {
+		conflicting := providerutil.CredentialsBindingFromSecretBinding(secretBinding, "aws")
+		conflicting.CredentialsRef.Name = "other-secret"
+		Expect(gardenClient.Create(ctx, conflicting)).To(Succeed())
+
+		Expect(reconciler.migrateSecretBinding(cpc, c, true)).To(Succeed())
+
+		updatedShoot := &gardenercorev1beta1.Shoot{}
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), updatedShoot)).To(Succeed())
+		Expect(updatedShoot.Spec.SecretBindingName).To(PointTo(Equal("aws")))
+
+		updatedShootControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(cpc.shootControlPlane), updatedShootControlPlane)).To(Succeed())
+		Expect(updatedShootControlPlane.Spec.SecretBindingName).To(PointTo(Equal("aws")))
+		Expect(meta.FindStatusCondition(updatedShootControlPlane.Status.Conditions, controlplanev1alpha2.CredentialsBindingMigratedCondition)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(metav1.ConditionFalse),
+			"Reason":  Equal(controlplanev1alpha2.CredentialsBindingMigrationFailedReason),
+			"Message": ContainSubstring("CredentialsBinding aws already exists"),
+		})))
+	})
+
+	It("should not do anything for GardenerShootControlPlanes without SecretBinding", func() {
+		cpc.shootControlPlane.Spec.SecretBindingName = nil
+
+		Expect(reconciler.migrateSecretBinding(cpc, c, true)).To(Succeed())
+
+		Expect(cpc.shootControlPlane.Status.Conditions).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Replication", func() {
+	var (
+		ctx          context.Context
+		gardenClient client.Client
+		c            client.Client
+		reconciler   *GardenerShootControlPlaneReconciler
+		cpc          ControlPlaneContext
+		shoot        *gardenercorev1beta1.Shoot
+		source       *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		shoot = &gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-dev"}}
+		source = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "extension-secret",
+				Namespace: "default",
+				Labels:    map[string]string{controlplanev1alpha2.ReplicateLabel: "true"},
+			},
+			Data: map[string][]byte{"token": []byte("secret")},
+		}
+		shootControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-cp", Namespace: "default"},
+			Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+				ProjectNamespace: "garden-dev",
+				Resources: []gardenercorev1beta1.NamedResourceReference{{
+					Name:        "credentials",
+					ResourceRef: autoscalingv1.CrossVersionObjectReference{APIVersion: "v1", Kind: "Secret", Name: "extension-secret"},
+				}},
+			},
+		}
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+		c = fakeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(shootControlPlane, source).Build()
+		reconciler = &GardenerShootControlPlaneReconciler{GardenerClient: gardenClient}
+		cpc = ControlPlaneContext{
+			ctx:               ctx,
+			cluster:           &clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}},
+			shootControlPlane: shootControlPlane,
+			shoot:             shoot,
+		}
+	})
+
+	It("should replicate the labeled Secret into the project namespace and keep it in sync", func() {
+		replicated, err := reconciler.reconcileReplicas(cpc, c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicated.UnsortedList()).To(ConsistOf(providerutil.ResourceReference{Kind: "Secret", Name: "extension-secret"}))
+
+		replica := &corev1.Secret{}
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "foo-extension-secret", Namespace: "garden-dev"}, replica)).To(Succeed())
+		Expect(replica.Data).To(Equal(source.Data))
+		Expect(replica.Labels).To(HaveKeyWithValue(controlplanev1alpha2.ReplicaShootLabel, "foo"))
+		Expect(replica.Annotations).To(HaveKeyWithValue(controlplanev1alpha2.ReplicaSourceAnnotation, "extension-secret"))
+
+		source.Data = map[string][]byte{"token": []byte("rotated")}
+		Expect(c.Update(ctx, source)).To(Succeed())
+		_, err = reconciler.reconcileReplicas(cpc, c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(replica), replica)).To(Succeed())
+		Expect(replica.Data).To(Equal(source.Data))
+	})
+
+	It("should not replicate Secrets without the replicate label", func() {
+		source.Labels = nil
+		Expect(c.Update(ctx, source)).To(Succeed())
+
+		replicated, err := reconciler.reconcileReplicas(cpc, c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicated).To(BeEmpty())
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "foo-extension-secret", Namespace: "garden-dev"}, &corev1.Secret{})).To(matchers.BeNotFoundError())
+	})
+
+	It("should not overwrite objects in the project namespace which are not replicas", func() {
+		Expect(gardenClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo-extension-secret", Namespace: "garden-dev"}})).To(Succeed())
+
+		_, err := reconciler.reconcileReplicas(cpc, c)
+		Expect(err).To(MatchError(ContainSubstring("is not a replica")))
+	})
+
+	It("should delete the replicas which are no longer referenced", func() {
+		_, err := reconciler.reconcileReplicas(cpc, c)
+		Expect(err).NotTo(HaveOccurred())
+		unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "garden-dev"}}
+		Expect(gardenClient.Create(ctx, unrelated)).To(Succeed())
+
+		Expect(reconciler.deleteReplicas(cpc, shoot, nil)).To(Succeed())
+
+		Expect(gardenClient.Get(ctx, client.ObjectKey{Name: "foo-extension-secret", Namespace: "garden-dev"}, &corev1.Secret{})).To(matchers.BeNotFoundError())
+		Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(unrelated), &corev1.Secret{})).To(Succeed())
+	})
+
+	It("should only watch Secrets and ConfigMaps which carry or lose the replicate label", func() {
+		unlabeled := source.DeepCopy()
+		unlabeled.Labels = nil
+
+		Expect(replicatedResourcePredicate.Create(event.CreateEvent{Object: source})).To(BeTrue())
+		Expect(replicatedResourcePredicate.Create(event.CreateEvent{Object: unlabeled})).To(BeFalse())
+		Expect(replicatedResourcePredicate.Update(event.UpdateEvent{ObjectOld: source, ObjectNew: unlabeled})).To(BeTrue())
+		Expect(replicatedResourcePredicate.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: source})).To(BeTrue())
+		Expect(replicatedResourcePredicate.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: unlabeled})).To(BeFalse())
+		Expect(replicatedResourcePredicate.Delete(event.DeleteEvent{Object: unlabeled})).To(BeFalse())
+	})
+})
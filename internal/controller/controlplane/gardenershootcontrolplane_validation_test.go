@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+var _ = Describe("GardenerShootControlPlane CRD validation", func() {
+	var controlPlane *controlplanev1alpha2.GardenerShootControlPlane
+
+	BeforeEach(func() {
+		controlPlane = &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "validation-",
+				Namespace:    "default",
+			},
+			Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+				Provider: controlplanev1alpha2.ProviderGSCP{Type: "aws"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, controlPlane))).To(Succeed())
+	})
+
+	It("should forbid setting both secretBindingName and credentialsBindingName", func() {
+		controlPlane.Spec.SecretBindingName = ptr.To("secret-binding")
+		controlPlane.Spec.CredentialsBindingName = ptr.To("credentials-binding")
+
+		err := k8sClient.Create(ctx, controlPlane)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("secretBindingName and credentialsBindingName are mutually exclusive"))
+	})
+
+	It("should forbid changing the provider type", func() {
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.Provider.Type = "gcp"
+		err := k8sClient.Update(ctx, controlPlane)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("type is immutable"))
+	})
+
+	It("should forbid adding a secretBindingName", func() {
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.SecretBindingName = ptr.To("secret-binding")
+		err := k8sClient.Update(ctx, controlPlane)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("secretBindingName cannot be added"))
+	})
+
+	It("should allow migrating from secretBindingName to credentialsBindingName", func() {
+		controlPlane.Spec.SecretBindingName = ptr.To("secret-binding")
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.SecretBindingName = nil
+		controlPlane.Spec.CredentialsBindingName = ptr.To("credentials-binding")
+		Expect(k8sClient.Update(ctx, controlPlane)).To(Succeed())
+	})
+
+	It("should forbid removing the secretBindingName without migrating", func() {
+		controlPlane.Spec.SecretBindingName = ptr.To("secret-binding")
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.SecretBindingName = nil
+		err := k8sClient.Update(ctx, controlPlane)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("secretBindingName can only be removed when migrating to credentialsBindingName"))
+	})
+
+	It("should allow setting the schedulerName once but forbid changing or removing it", func() {
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.SchedulerName = ptr.To("default-scheduler")
+		Expect(k8sClient.Update(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.SchedulerName = ptr.To("custom-scheduler")
+		Expect(k8sClient.Update(ctx, controlPlane)).To(MatchError(ContainSubstring("schedulerName is immutable")))
+
+		controlPlane.Spec.SchedulerName = nil
+		Expect(k8sClient.Update(ctx, controlPlane)).To(MatchError(ContainSubstring("schedulerName cannot be removed")))
+	})
+
+	It("should forbid adding an exposureClassName", func() {
+		Expect(k8sClient.Create(ctx, controlPlane)).To(Succeed())
+
+		controlPlane.Spec.ExposureClassName = ptr.To("internet")
+		Expect(k8sClient.Update(ctx, controlPlane)).To(MatchError(ContainSubstring("exposureClassName is immutable")))
+	})
+})
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apisv1alpha2 "github.com/kcp-dev/sdk/apis/apis/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+)
+
+// WorkspaceProjectReconciler creates a GardenerProject in every kcp workspace which binds the APIExport of the
+// provider. GardenerShootControlPlanes in the workspace default their ProjectNamespace to its namespace.
+type WorkspaceProjectReconciler struct {
+	Manager mcmanager.Manager
+	// APIExportName is the name of the APIExport of the provider.
+	APIExportName string
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
+}
+
+// +kubebuilder:rbac:groups=apis.kcp.io,resources=apibindings,verbs=get;list;watch
+
+// Reconcile ensures that the GardenerProject of the workspace of an APIBinding exists.
+func (r *WorkspaceProjectReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx).WithValues("apibinding", req.Name, "cluster", req.ClusterName)
+
+	if !r.Sharder.Handles("workspaceproject", req) {
+		return ctrl.Result{}, nil
+	}
+
+	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	c := cl.GetClient()
+
+	binding := &apisv1alpha2.APIBinding{}
+	if err := c.Get(ctx, req.NamespacedName, binding); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Sharder.Forget("workspaceproject", req)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !binding.DeletionTimestamp.IsZero() || !r.bindsAPIExport(binding) {
+		return ctrl.Result{}, nil
+	}
+
+	gardenerProject := &controlplanev1alpha2.GardenerProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: controlplanev1alpha2.WorkspaceProjectName,
+		},
+	}
+	if err := c.Create(ctx, gardenerProject); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	log.Info("Created GardenerProject for workspace", "gardenerproject", gardenerProject.Name)
+	return ctrl.Result{}, nil
+}
+
+func (r *WorkspaceProjectReconciler) bindsAPIExport(obj client.Object) bool {
+	binding, ok := obj.(*apisv1alpha2.APIBinding)
+	return ok && binding.Spec.Reference.Export != nil && binding.Spec.Reference.Export.Name == r.APIExportName
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspaceProjectReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	controller := mcbuilder.ControllerManagedBy(mgr).
+		Named("workspaceproject").
+		For(&apisv1alpha2.APIBinding{}, mcbuilder.WithPredicates(predicate.NewPredicateFuncs(r.bindsAPIExport)))
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source("workspaceproject"))
+	}
+	return controller.Complete(r)
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/clustercache"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+// triggerDeletionByMCMAnnotation is the annotation on nodes which makes the machine-controller-manager delete the node
+// and its machine, and decrease the replicas of the machine deployment accordingly.
+const triggerDeletionByMCMAnnotation = "node.machine.sapcloud.io/trigger-deletion-by-mcm"
+
+// GardenerMachineReconciler reconciles a GardenerMachine object. GardenerMachines are created and deleted by the
+// GardenerWorkerPool controller, this reconciler takes care of removing the node when a GardenerMachine is deleted,
+// e.g. because Cluster API deletes the corresponding Machine.
+type GardenerMachineReconciler struct {
+	Manager      mcmanager.Manager
+	ClusterCache *clustercache.ClusterCache
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenermachines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenermachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenermachines/finalizers,verbs=update
+
+// Reconcile adds the finalizer to GardenerMachines and removes the node of deleted GardenerMachines.
+func (r *GardenerMachineReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx).WithValues("gardenermachine", req.NamespacedName, "cluster", req.ClusterName)
+
+	if !r.Sharder.Handles("gardenermachine", req) {
+		return ctrl.Result{}, nil
+	}
+
+	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	c := cl.GetClient()
+
+	machine := &infrastructurev1alpha2.GardenerMachine{}
+	if err := c.Get(ctx, req.NamespacedName, machine); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("GardenerMachine not found or already deleted")
+			r.Sharder.Forget("gardenermachine", req)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get GardenerMachine")
+		return ctrl.Result{}, err
+	}
+
+	cluster, err := util.GetClusterFromMetadata(ctx, c, machine.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	if !machine.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, c, req, machine, cluster)
+	}
+
+	if cluster == nil {
+		log.Info("Cluster not found")
+		return ctrl.Result{}, nil
+	}
+	if annotations.IsPaused(cluster, machine) {
+		log.Info("GardenerMachine or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(machine.DeepCopy())
+	if controllerutil.AddFinalizer(machine, infrastructurev1alpha2.GardenerMachineFinalizer) {
+		if err := c.Patch(ctx, machine, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *GardenerMachineReconciler) reconcileDelete(ctx context.Context, c client.Client, req mcreconcile.Request, machine *infrastructurev1alpha2.GardenerMachine, cluster *clusterv1beta2.Cluster) (ctrl.Result, error) {
+	log := runtimelog.FromContext(ctx).WithValues("operation", "delete")
+
+	if !controllerutil.ContainsFinalizer(machine, infrastructurev1alpha2.GardenerMachineFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	removeNode, err := r.shouldRemoveNode(ctx, c, machine, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if removeNode {
+		if annotations.IsPaused(cluster, machine) {
+			log.Info("GardenerMachine or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+
+		nodeRemoved, err := r.removeNode(ctx, c, req, machine, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !nodeRemoved {
+			// Wait until the machine-controller-manager drained and removed the node.
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	patch := client.MergeFrom(machine.DeepCopy())
+	controllerutil.RemoveFinalizer(machine, infrastructurev1alpha2.GardenerMachineFinalizer)
+	if err := c.Patch(ctx, machine, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Successfully reconciled deletion of GardenerMachine")
+	return ctrl.Result{}, nil
+}
+
+// shouldRemoveNode returns false if the whole worker pool or Cluster is deleted, as Gardener removes the nodes then,
+// or if the GardenerMachine is only deleted because it was moved to another management cluster.
+func (r *GardenerMachineReconciler) shouldRemoveNode(ctx context.Context, c client.Client, machine *infrastructurev1alpha2.GardenerMachine, cluster *clusterv1beta2.Cluster) (bool, error) {
+	if _, ok := machine.Annotations[clusterctlv1.DeleteForMoveAnnotation]; ok {
+		return false, nil
+	}
+	if cluster == nil || !cluster.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	for _, ref := range machine.OwnerReferences {
+		if ref.Kind != providerutil.KindGardenerWorkerPool || ref.APIVersion != infrastructurev1alpha2.SchemeGroupVersion.String() {
+			continue
+		}
+		workerPool := &infrastructurev1alpha2.GardenerWorkerPool{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: ref.Name}, workerPool); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return workerPool.DeletionTimestamp.IsZero(), nil
+	}
+	return false, nil
+}
+
+// removeNode annotates the node of the given GardenerMachine with triggerDeletionByMCMAnnotation. The
+// machine-controller-manager then drains the node, deletes the backing machine and scales down the machine deployment
+// of the worker pool, so that the machine is not recreated. Returns true once the node is gone.
+func (r *GardenerMachineReconciler) removeNode(ctx context.Context, c client.Client, req mcreconcile.Request, machine *infrastructurev1alpha2.GardenerMachine, cluster *clusterv1beta2.Cluster) (bool, error) {
+	log := runtimelog.FromContext(ctx).WithValues("node", machine.Spec.NodeName)
+
+	shootClient, err := r.ClusterCache.GetClient(ctx, c, clustercache.Key{ClusterName: req.ClusterName, ObjectKey: client.ObjectKeyFromObject(cluster)})
+	if err != nil {
+		if errors.Is(err, clustercache.ErrClusterNotConnected) {
+			log.Info("Shoot Access Secret not found, cannot remove node")
+			return true, nil
+		}
+		return false, err
+	}
+
+	node := &corev1.Node{}
+	if err := shootClient.Get(ctx, client.ObjectKey{Name: machine.Spec.NodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if node.Annotations[triggerDeletionByMCMAnnotation] == "true" {
+		return false, nil
+	}
+
+	log.Info("Triggering deletion of node by machine-controller-manager")
+	patch := client.MergeFrom(node.DeepCopy())
+	metav1.SetMetaDataAnnotation(&node.ObjectMeta, triggerDeletionByMCMAnnotation, "true")
+	if err := shootClient.Patch(ctx, node, patch); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to trigger deletion of node: %w", err)
+	}
+	return false, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GardenerMachineReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	controller := mcbuilder.ControllerManagedBy(mgr).
+		Named("gardenermachine").
+		For(&infrastructurev1alpha2.GardenerMachine{})
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source("gardenermachine"))
+	}
+	return controller.Complete(r)
+}
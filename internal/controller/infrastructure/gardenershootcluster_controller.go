@@ -7,30 +7,34 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gardener/gardener/pkg/api/core/helper"
 	"github.com/gardener/gardener/pkg/apis/core"
 	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerRuntimeCluster "sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
-	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
 	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
@@ -38,19 +42,27 @@ import (
 type GardenerShootClusterReconciler struct {
 	Manager        mcmanager.Manager
 	GardenerClient client.Client
-	IsKCP          bool
+	// GardenerAPIReader reads from the garden without the cache. It is optional.
+	GardenerAPIReader client.Reader
 
 	PrioritizeShoot bool
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenershootclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenershootclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenershootclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile reconciles and syncs the GardenerShootCluster resource with the corresponding Shoot resource.
 func (r *GardenerShootClusterReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("gardenershootcluster", req.NamespacedName, "cluster", req.ClusterName)
 
+	if !r.Sharder.Handles("gardenershootcluster", req) {
+		return ctrl.Result{}, nil
+	}
+
 	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -58,10 +70,11 @@ func (r *GardenerShootClusterReconciler) Reconcile(ctx context.Context, req mcre
 	c := cl.GetClient()
 
 	log.Info("Reconciling GardenerShootCluster")
-	infraCluster := &infrastructurev1alpha1.GardenerShootCluster{}
+	infraCluster := &infrastructurev1alpha2.GardenerShootCluster{}
 	if err := c.Get(ctx, req.NamespacedName, infraCluster); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("GardenerShootCluster not found or already deleted")
+			r.Sharder.Forget("gardenershootcluster", req)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get GardenerShootCluster")
@@ -80,6 +93,12 @@ func (r *GardenerShootClusterReconciler) Reconcile(ctx context.Context, req mcre
 
 	if annotations.IsPaused(cluster, infraCluster) {
 		log.Info("GardenerShootCluster or linked Cluster is marked as paused. Won't reconcile")
+		if r.PrioritizeShoot {
+			patch := client.MergeFrom(infraCluster.DeepCopy())
+			if providerutil.SetPausedCondition(&infraCluster.Status.Conditions, infraCluster.Generation, true) {
+				return ctrl.Result{}, c.Status().Patch(ctx, infraCluster, patch)
+			}
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -91,7 +110,7 @@ func (r *GardenerShootClusterReconciler) Reconcile(ctx context.Context, req mcre
 	return r.reconcile(ctx, c, infraCluster, cluster)
 }
 
-func (r *GardenerShootClusterReconciler) reconcileDelete(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha1.GardenerShootCluster) (ctrl.Result, error) {
+func (r *GardenerShootClusterReconciler) reconcileDelete(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha2.GardenerShootCluster) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "delete")
 
 	patch := client.MergeFrom(infraCluster.DeepCopy())
@@ -106,7 +125,7 @@ func (r *GardenerShootClusterReconciler) reconcileDelete(ctx context.Context, c
 	return ctrl.Result{}, nil
 }
 
-func (r *GardenerShootClusterReconciler) reconcile(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha1.GardenerShootCluster, cluster *clusterv1beta2.Cluster) (ctrl.Result, error) {
+func (r *GardenerShootClusterReconciler) reconcile(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha2.GardenerShootCluster, cluster *clusterv1beta2.Cluster) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "reconcile")
 
 	log.Info("Adding finalizer to GardenerShootCluster")
@@ -123,45 +142,51 @@ func (r *GardenerShootClusterReconciler) reconcile(ctx context.Context, c client
 		return ctrl.Result{}, err
 	}
 
+	var result ctrl.Result
 	if r.PrioritizeShoot {
-		if err := r.updateStatus(ctx, c, infraCluster, cluster); err != nil {
+		requeueAfter, err := r.updateStatus(ctx, c, infraCluster, cluster)
+		if err != nil {
 			log.Error(err, "Failed to update GardenerShootCluster status")
 			return ctrl.Result{}, err
 		}
+		// The maintenance status depends on the time, it has to be updated once it becomes outdated.
+		result.RequeueAfter = requeueAfter
 	}
 
 	log.Info("GardenerShootCluster reconciled successfully")
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
-func (r *GardenerShootClusterReconciler) updateStatus(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha1.GardenerShootCluster, cluster *clusterv1beta2.Cluster) error {
+// updateStatus updates the status of the GardenerShootCluster and returns the duration after which its maintenance
+// status becomes outdated.
+func (r *GardenerShootClusterReconciler) updateStatus(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha2.GardenerShootCluster, cluster *clusterv1beta2.Cluster) (time.Duration, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "updateStatus")
 
-	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, c, cluster)
+	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, r.GardenerAPIReader, c, cluster)
 	if err != nil {
 		log.Error(err, "Failed to get Shoot from Cluster")
-		return err
+		return 0, err
 	}
 	if shoot == nil {
 		log.Info("Shoot not found, do nothing")
-		return nil
+		return 0, nil
 	}
 
 	if shoot.Spec.SeedName == nil {
 		log.Info("Shoot does not have a SeedName yet, do nothing")
-		return nil
+		return 0, nil
 	}
 
 	seed := &gardenercorev1beta1.Seed{}
 	if err := r.GardenerClient.Get(ctx, types.NamespacedName{Name: *shoot.Spec.SeedName}, seed); err != nil {
 		log.Error(err, "Failed to get Seed")
-		return err
+		return 0, err
 	}
 
 	coreSeed := core.Seed{}
 	if err := gardenercorev1beta1.Convert_v1beta1_Seed_To_core_Seed(seed, &coreSeed, nil); err != nil {
 		log.Error(err, "Failed to convert Seed from v1beta1 to core")
-		return err
+		return 0, err
 	}
 
 	patch := client.MergeFrom(infraCluster.DeepCopy())
@@ -181,19 +206,69 @@ func (r *GardenerShootClusterReconciler) updateStatus(ctx context.Context, c cli
 		infraCluster.Status.Ready = true
 	}
 
+	// Fields and conditions of the Cluster API v1beta2 contract.
+	readyCondition := metav1.Condition{
+		Type:               clusterv1beta2.ReadyCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1beta2.NotReadyReason,
+		Message:            fmt.Sprintf("Seed %s is not ready", seed.Name),
+		ObservedGeneration: infraCluster.Generation,
+	}
+	if infraCluster.Status.Ready {
+		infraCluster.Status.Initialization.Provisioned = ptr.To(true)
+		readyCondition.Status = metav1.ConditionTrue
+		readyCondition.Reason = clusterv1beta2.ReadyReason
+		readyCondition.Message = ""
+	}
+	meta.SetStatusCondition(&infraCluster.Status.Conditions, readyCondition)
+	providerutil.SetPausedCondition(&infraCluster.Status.Conditions, infraCluster.Generation, false)
+
+	forcedUpdates := r.updateMaintenanceStatus(ctx, infraCluster, shoot)
+
 	if err := c.Status().Patch(ctx, infraCluster, patch); err != nil {
 		log.Error(err, "Failed to patch GardenerShootCluster status")
-		return err
+		return 0, err
+	}
+
+	// The forced updates are only announced once they are recorded in the status, so that they are announced again if
+	// the status could not be patched.
+	for _, update := range forcedUpdates {
+		record.Warn(infraCluster, "ForcedUpdatePending", providerutil.ForcedUpdateMessage(update, infraCluster.Status.Maintenance))
 	}
 
 	log.Info("GardenerShootCluster status updated successfully")
-	return nil
+	return providerutil.NextMaintenanceStatusChange(infraCluster.Status.Maintenance, time.Now()), nil
 }
 
-func (r *GardenerShootClusterReconciler) syncSpecs(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha1.GardenerShootCluster, cluster *clusterv1beta2.Cluster) error {
+// updateMaintenanceStatus computes the next maintenance time window and the pending automatic updates of the Shoot,
+// and returns the forced updates which are to be announced with events so that they can be planned for. The maintenance
+// status is left as is if the CloudProfile of the Shoot cannot be read, so that the rest of the status is still updated.
+func (r *GardenerShootClusterReconciler) updateMaintenanceStatus(ctx context.Context, infraCluster *infrastructurev1alpha2.GardenerShootCluster, shoot *gardenercorev1beta1.Shoot) []infrastructurev1alpha2.PendingUpdate {
+	log := runtimelog.FromContext(ctx).WithValues("operation", "updateMaintenanceStatus")
+
+	cloudProfile, err := gardenerutils.GetCloudProfile(ctx, r.GardenerClient, shoot)
+	if err != nil {
+		log.Error(err, "Failed to get CloudProfile of Shoot, skipping maintenance status")
+		return nil
+	}
+
+	maintenance := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+		PendingUpdates: providerutil.PendingUpdates(shoot, cloudProfile),
+	}
+	if start, end, ok := providerutil.NextMaintenanceWindow(shoot, time.Now()); ok {
+		maintenance.NextWindowStart = ptr.To(metav1.NewTime(start))
+		maintenance.NextWindowEnd = ptr.To(metav1.NewTime(end))
+	}
+
+	forcedUpdates := providerutil.ForcedUpdatesToAnnounce(infraCluster.Status.Maintenance, maintenance)
+	infraCluster.Status.Maintenance = maintenance
+	return forcedUpdates
+}
+
+func (r *GardenerShootClusterReconciler) syncSpecs(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha2.GardenerShootCluster, cluster *clusterv1beta2.Cluster) error {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "syncSpecs")
 
-	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, c, cluster)
+	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, r.GardenerAPIReader, c, cluster)
 	if err != nil {
 		log.Error(err, "Failed to get Shoot from Cluster")
 		return err
@@ -256,51 +331,21 @@ func (r *GardenerShootClusterReconciler) SetupWithManager(mgr mcmanager.Manager,
 					targetCluster.GetCache(),
 					&gardenercorev1beta1.Shoot{},
 					handler.TypedEnqueueRequestsFromMapFunc[client.Object, mcreconcile.Request](r.MapShootToGardenerShootClusterObject),
+					r.Sharder.Predicate(providerutil.ShootOwnerShardKey),
 				),
 			)
 	} else {
 		controller.
 			Named(name).
-			For(&infrastructurev1alpha1.GardenerShootCluster{})
+			For(&infrastructurev1alpha2.GardenerShootCluster{})
+	}
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source(name))
 	}
 	return controller.Complete(r)
 }
 
-// MapShootToGardenerShootClusterObject maps a Shoot object to a GardenerShootCluster object for reconciliation.
+// MapShootToGardenerShootClusterObject maps a Shoot object to the GardenerShootCluster of its owner.
 func (r *GardenerShootClusterReconciler) MapShootToGardenerShootClusterObject(ctx context.Context, obj client.Object) []mcreconcile.Request {
-	var (
-		log          = runtimelog.FromContext(ctx).WithValues("shoot", client.ObjectKeyFromObject(obj))
-		clusterName  string
-		infraCluster *infrastructurev1alpha1.GardenerShootCluster
-	)
-	shoot, ok := obj.(*gardenercorev1beta1.Shoot)
-	if !ok {
-		log.Error(fmt.Errorf("could not assert object to Shoot"), "")
-		return nil
-	}
-
-	namespace, ok := shoot.GetLabels()[infrastructurev1alpha1.GSCReferenceNamespaceKey]
-	if !ok {
-		log.Info("Could not find gsc namespace on label")
-		return nil
-	}
-
-	name, ok := shoot.GetLabels()[infrastructurev1alpha1.GSCReferenceNameKey]
-	if !ok {
-		log.Info("Could not find gsc name on label")
-	}
-	if r.IsKCP {
-		clusterName, ok = shoot.GetLabels()[infrastructurev1alpha1.GSCReferecenceClusterNameKey]
-		if !ok {
-			log.Info("Could not find gsc cluster on label")
-		}
-	}
-
-	infraCluster = &infrastructurev1alpha1.GardenerShootCluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-	}
-	return []mcreconcile.Request{{Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(infraCluster)}, ClusterName: multicluster.ClusterName(clusterName)}}
+	return providerutil.MapShootToOwnedObjects(ctx, r.Manager, obj, &infrastructurev1alpha2.GardenerShootClusterList{})
 }
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// eventRecorder receives the events of the controllers. The recorder of Cluster API can only be initialized once.
+var eventRecorder = clientgorecord.NewFakeRecorder(10)
+
+var _ = Describe("Maintenance status of a GardenerShootCluster", func() {
+	var (
+		ctx            context.Context
+		testScheme     *runtime.Scheme
+		reconciler     *GardenerShootClusterReconciler
+		cluster        *clusterv1beta2.Cluster
+		controlPlane   *controlplanev1alpha2.GardenerShootControlPlane
+		infraCluster   *infrastructurev1alpha2.GardenerShootCluster
+		statusPatchErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		record.InitFromRecorder(eventRecorder)
+		for len(eventRecorder.Events) > 0 {
+			<-eventRecorder.Events
+		}
+		statusPatchErr = nil
+
+		testScheme = runtime.NewScheme()
+		Expect(clusterv1beta2.AddToScheme(testScheme)).To(Succeed())
+		Expect(controlplanev1alpha2.AddToScheme(testScheme)).To(Succeed())
+		Expect(infrastructurev1alpha2.AddToScheme(testScheme)).To(Succeed())
+
+		cluster = &clusterv1beta2.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: clusterv1beta2.ClusterSpec{ControlPlaneRef: clusterv1beta2.ContractVersionedObjectReference{
+				APIGroup: controlplanev1alpha2.GroupVersion.Group,
+				Kind:     "GardenerShootControlPlane",
+				Name:     "foo",
+			}},
+		}
+		controlPlane = &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec:       controlplanev1alpha2.GardenerShootControlPlaneSpec{ProjectNamespace: "garden-foo"},
+		}
+		infraCluster = &infrastructurev1alpha2.GardenerShootCluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+		expiration := metav1.NewTime(time.Now().AddDate(0, 1, 0))
+		shoot := &gardenercorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-foo"},
+			Spec: gardenercorev1beta1.ShootSpec{
+				CloudProfile: &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"},
+				SeedName:     ptr.To("seed"),
+				Kubernetes:   gardenercorev1beta1.Kubernetes{Version: "1.32.5"},
+				Maintenance: &gardenercorev1beta1.Maintenance{
+					AutoUpdate: &gardenercorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: false},
+					TimeWindow: &gardenercorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+				},
+			},
+		}
+		cloudProfile := &gardenercorev1beta1.CloudProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+			Spec: gardenercorev1beta1.CloudProfileSpec{
+				Kubernetes: gardenercorev1beta1.KubernetesSettings{
+					Versions: []gardenercorev1beta1.ExpirableVersion{
+						{Version: "1.32.5", ExpirationDate: &expiration},
+						{Version: "1.33.2"},
+					},
+				},
+			},
+		}
+		seed := &gardenercorev1beta1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "seed"}}
+
+		reconciler = &GardenerShootClusterReconciler{
+			GardenerClient: fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(shoot, cloudProfile, seed).Build(),
+		}
+	})
+
+	newClient := func() client.Client {
+		return fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithObjects(cluster, controlPlane, infraCluster).
+			WithStatusSubresource(&infrastructurev1alpha2.GardenerShootCluster{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if statusPatchErr != nil {
+						return statusPatchErr
+					}
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+	}
+
+	It("should announce a pending forced update once its status is patched", func() {
+		c := newClient()
+
+		_, err := reconciler.updateStatus(ctx, c, infraCluster, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infraCluster.Status.Maintenance).NotTo(BeNil())
+		Expect(infraCluster.Status.Maintenance.PendingUpdates).To(HaveLen(1))
+		Expect(eventRecorder.Events).To(Receive(HavePrefix("Warning ForcedUpdatePending The Kubernetes version 1.32.5 expires on")))
+
+		_, err = reconciler.updateStatus(ctx, c, infraCluster, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(eventRecorder.Events).NotTo(Receive())
+	})
+
+	It("should not announce a pending forced update if its status could not be patched", func() {
+		c := newClient()
+		statusPatchErr = fmt.Errorf("fake error")
+
+		_, err := reconciler.updateStatus(ctx, c, infraCluster, cluster)
+		Expect(err).To(MatchError("fake error"))
+		Expect(eventRecorder.Events).NotTo(Receive())
+	})
+})
@@ -6,23 +6,28 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"slices"
 
 	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerRuntimeCluster "sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -32,22 +37,30 @@ import (
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	"github.com/gardener/cluster-api-provider-gardener/internal/clustercache"
+	"github.com/gardener/cluster-api-provider-gardener/internal/sharding"
 	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 // GardenerWorkerPoolReconciler reconciles a GardenerWorkerPool object
 type GardenerWorkerPoolReconciler struct {
-	Manager         mcmanager.Manager
-	GardenerClient  client.Client
-	IsKCP           bool
-	Scheme          *runtime.Scheme
-	PrioritizeShoot bool
+	Manager        mcmanager.Manager
+	GardenerClient client.Client
+	// GardenerAPIReader reads from the garden without the cache. It is optional.
+	GardenerAPIReader client.Reader
+	PrioritizeShoot   bool
+	// ClusterCache provides access to the workload clusters. It is only required if PrioritizeShoot is set.
+	ClusterCache *clustercache.ClusterCache
+	// Sharder restricts the reconciliation to the shards of this replica. It is optional.
+	Sharder *sharding.Sharder
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenerworkerpools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenerworkerpools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenerworkerpools/finalizers,verbs=update
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenermachines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gardenermachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;update;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools/status,verbs=get;update
 
@@ -64,16 +77,21 @@ func (r *GardenerWorkerPoolReconciler) Reconcile(ctx context.Context, req mcreco
 	log := runtimelog.FromContext(ctx).WithValues("gardenerworkerpool", req.NamespacedName, "cluster", req.ClusterName)
 	log.Info("Reconciling GardenerWorkerPool")
 
+	if !r.Sharder.Handles("gardenerworkerpool", req) {
+		return ctrl.Result{}, nil
+	}
+
 	cl, err := r.Manager.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
 	}
 	c := cl.GetClient()
 
-	workerPool := &infrastructurev1alpha1.GardenerWorkerPool{}
+	workerPool := &infrastructurev1alpha2.GardenerWorkerPool{}
 	if err := c.Get(ctx, req.NamespacedName, workerPool); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("GardenerWorkerPool not found or already deleted")
+			r.Sharder.Forget("gardenerworkerpool", req)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get GardenerWorkerPool")
@@ -110,13 +128,13 @@ func (r *GardenerWorkerPoolReconciler) Reconcile(ctx context.Context, req mcreco
 		return r.reconcileDelete()
 	}
 
-	return r.reconcile(ctx, c, workerPool, machinePool, cluster)
+	return r.reconcile(ctx, c, req.ClusterName, workerPool, machinePool, cluster)
 }
 
-func (r *GardenerWorkerPoolReconciler) syncSpecs(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha1.GardenerWorkerPool, cluster *clusterv1beta2.Cluster) error {
+func (r *GardenerWorkerPoolReconciler) syncSpecs(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool, cluster *clusterv1beta2.Cluster, bounds *providerutil.AutoscalerBounds, version string) error {
 	log := runtimelog.FromContext(ctx).WithValues("gardenerworkerpool", client.ObjectKeyFromObject(workerPool), "operation", "syncSpecs")
 
-	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, c, cluster)
+	shoot, err := providerutil.ShootFromCluster(ctx, r.GardenerClient, r.GardenerAPIReader, c, cluster)
 	if err != nil {
 		log.Error(err, "Failed to get Shoot from Cluster")
 		return err
@@ -133,6 +151,8 @@ func (r *GardenerWorkerPoolReconciler) syncSpecs(ctx context.Context, c client.C
 	// Sync the specs between Shoot and GardenerWorkerPool
 	if r.PrioritizeShoot {
 		providerutil.SyncWorkerPoolFromShootSpec(originalShoot, workerPool)
+		// The MachinePool might not have been synced to the Shoot yet.
+		applyMachinePool(&workerPool.Spec, bounds, version)
 
 		// Check if GardenerWorkerPool spec has changed before patching
 		if !providerutil.IsWorkerPoolSpecEqual(originalWorkerPool, workerPool) {
@@ -173,109 +193,282 @@ func (r *GardenerWorkerPoolReconciler) reconcileDelete() (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
-// createClientFromKubeconfig creates a client.Client from a kubeconfig string.
-func createClientFromKubeconfig(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error) {
-	// Load the kubeconfig from the provided string
-	restConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
-	}
-
-	// Convert the kubeconfig to a rest.Config
-	config, err := restConfig.ClientConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create rest.Config: %w", err)
-	}
-
-	// Create a new client.Client using the rest.Config
-	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
-	}
-
-	return k8sClient, nil
-}
-
-func (r *GardenerWorkerPoolReconciler) updateStatus(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha1.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, cluster *clusterv1beta2.Cluster) error {
+func (r *GardenerWorkerPoolReconciler) updateStatus(ctx context.Context, c client.Client, clusterName multicluster.ClusterName, workerPool *infrastructurev1alpha2.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, cluster *clusterv1beta2.Cluster, bounds *providerutil.AutoscalerBounds) error {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "updateStatus")
 
-	// Get the secret for the shoot cluster to get the nodes
-	secret := &corev1.Secret{
-		ObjectMeta: v1.ObjectMeta{
-			Namespace: cluster.Namespace,
-			Name:      fmt.Sprintf("%s-kubeconfig", cluster.Name),
-		},
-	}
-	log.Info("Retrieving Shoot Access Secret", "secret", client.ObjectKeyFromObject(secret))
-	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
-		if apierrors.IsNotFound(err) {
+	shootClient, err := r.ClusterCache.GetClient(ctx, c, clustercache.Key{ClusterName: clusterName, ObjectKey: client.ObjectKeyFromObject(cluster)})
+	if err != nil {
+		if errors.Is(err, clustercache.ErrClusterNotConnected) {
 			log.Info("Shoot Access Secret not found or already deleted")
 			return nil
 		}
-		log.Error(err, "Failed to get Shoot Access Secret")
+		log.Error(err, "Failed to get client for Shoot")
 		return err
 	}
 
-	kubeconfig, ok := secret.Data["value"]
-	if !ok {
-		err := fmt.Errorf("could not find kubeconfig in secret")
-		log.Error(err, "Failed to get kubeconfig from secret")
+	nodes := &corev1.NodeList{}
+	if err := shootClient.List(ctx, nodes, client.MatchingLabels{v1beta1constants.LabelWorkerPool: workerPool.Name}); err != nil {
+		log.Error(err, "Failed to list nodes")
 		return err
 	}
+	providerIDList := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if node.Spec.ProviderID != "" {
+			providerIDList = append(providerIDList, node.Spec.ProviderID)
+		}
+	}
+	slices.Sort(providerIDList)
+
+	if !slices.Equal(workerPool.Spec.ProviderIDList, providerIDList) {
+		log.Info("Updating GardenerWorkerPool provider IDs", "providerIDs", providerIDList)
+		patch := client.MergeFrom(workerPool.DeepCopy())
+		workerPool.Spec.ProviderIDList = providerIDList
+		if err := c.Patch(ctx, workerPool, patch); err != nil {
+			log.Error(err, "Failed to update GardenerWorkerPool provider IDs")
+			return err
+		}
+	}
 
-	shootClient, err := createClientFromKubeconfig(kubeconfig, r.Scheme)
-	if err != nil {
-		log.Error(err, "Failed to create client from kubeconfig")
-		return err
+	status := infrastructurev1alpha2.GardenerWorkerPoolStatus{
+		Ready:                     len(providerIDList) >= int(workerPool.Spec.Minimum),
+		InfrastructureMachineKind: providerutil.KindGardenerMachine,
+		KubernetesVersion:         workerPool.Status.KubernetesVersion,
+		Initialization:            *workerPool.Status.Initialization.DeepCopy(),
+		Conditions:                workerPool.Status.Conditions,
+	}
+	if status.Ready {
+		status.Initialization.Provisioned = ptr.To(true)
+	}
+	if version, ok := providerutil.NodesKubernetesVersion(nodes.Items); ok {
+		status.KubernetesVersion = version
+	}
+	if !apiequality.Semantic.DeepEqual(workerPool.Status, status) {
+		patch := client.MergeFrom(workerPool.DeepCopy())
+		workerPool.Status = status
+		if err := c.Status().Patch(ctx, workerPool, patch); err != nil {
+			log.Error(err, "Failed to update GardenerWorkerPool status")
+			return err
+		}
 	}
 
-	// Reset it here everytime so we don't write the same ids over and over
-	workerPool.Spec.ProviderIDList = []string{}
-	nodes := &corev1.NodeList{}
-	if err := shootClient.List(ctx, nodes); err != nil {
-		log.Error(err, "Failed to list nodes")
+	if err := r.reconcileMachines(ctx, c, workerPool, machinePool, cluster, nodes.Items); err != nil {
+		log.Error(err, "Failed to reconcile GardenerMachines")
 		return err
 	}
-	for _, node := range nodes.Items {
-		// TODO remove debug log
-		log.Info("Found node", "name", node.Name, "labels", node.Labels)
-		if node.Labels[v1beta1constants.LabelWorkerPool] == workerPool.Name {
-			// TODO remove debug log
-			log.Info("Adding node to providerIDList", "name", node.Name, "providerID", node.Spec.ProviderID)
-			workerPool.Spec.ProviderIDList = append(workerPool.Spec.ProviderIDList, node.Spec.ProviderID)
+
+	if replicas := int32(len(providerIDList)); !ptr.Equal(machinePool.Status.Replicas, &replicas) { // #nosec G115
+		patch := client.MergeFrom(machinePool.DeepCopy())
+		machinePool.Status.Replicas = &replicas
+		if err := c.Status().Patch(ctx, machinePool, patch); err != nil {
+			log.Error(err, "Failed to update MachinePool replicas")
+			return err
 		}
 	}
 
-	if err := c.Update(ctx, workerPool); err != nil {
-		log.Error(err, "Failed to update GardenerWorkerPool provider IDs")
-		return err
+	return r.reconcileReplicasManagedBy(ctx, c, machinePool, bounds)
+}
+
+// reconcileReplicasManagedBy marks the replicas of the MachinePool as managed by the cluster-autoscaler if the worker
+// pool is autoscaled. The size of an autoscaled worker pool is chosen by the cluster-autoscaler of the Shoot. It is only
+// reported as the status replicas of the MachinePool, so that its spec replicas are left alone. The mark is removed
+// again once the worker pool is no longer autoscaled, unless it was set by someone else.
+func (r *GardenerWorkerPoolReconciler) reconcileReplicasManagedBy(ctx context.Context, c client.Client, machinePool *clusterv1beta2.MachinePool, bounds *providerutil.AutoscalerBounds) error {
+	log := runtimelog.FromContext(ctx).WithValues("operation", "reconcileReplicasManagedBy")
+
+	_, setByController := machinePool.Annotations[infrastructurev1alpha2.ReplicasManagedByAutoscalerAnnotation]
+	patch := client.MergeFrom(machinePool.DeepCopy())
+	switch {
+	case bounds != nil && !annotations.ReplicasManagedByExternalAutoscaler(machinePool):
+		log.Info("Marking MachinePool replicas as managed by the cluster-autoscaler")
+		if machinePool.Annotations == nil {
+			machinePool.Annotations = map[string]string{}
+		}
+		machinePool.Annotations[clusterv1beta2.ReplicasManagedByAnnotation] = "true"
+		machinePool.Annotations[infrastructurev1alpha2.ReplicasManagedByAutoscalerAnnotation] = "true"
+	case bounds == nil && setByController:
+		log.Info("Unmarking MachinePool replicas as managed by the cluster-autoscaler")
+		delete(machinePool.Annotations, clusterv1beta2.ReplicasManagedByAnnotation)
+		delete(machinePool.Annotations, infrastructurev1alpha2.ReplicasManagedByAutoscalerAnnotation)
+	default:
+		return nil
 	}
 
-	workerPool.Status.Ready = len(workerPool.Spec.ProviderIDList) >= int(workerPool.Spec.Minimum)
-	// Update the status
-	if err := c.Status().Update(ctx, workerPool); err != nil {
-		log.Error(err, "Failed to update GardenerWorkerPool readiness status")
+	if err := c.Patch(ctx, machinePool, patch); err != nil {
+		log.Error(err, "Failed to update the replicas-managed-by annotation of the MachinePool")
 		return err
 	}
+	return nil
+}
 
-	machinePool.Status.Replicas = ptr.To(int32(len(workerPool.Spec.ProviderIDList))) // #nosec G115
-	if err := c.Status().Update(ctx, machinePool); err != nil {
-		log.Error(err, "Failed to update MachinePool replicas")
-		return err
+// setKubernetesVersionValidCondition reports the result of the validation of the Kubernetes version of the MachinePool
+// in the KubernetesVersionValid condition of the GardenerWorkerPool.
+func (r *GardenerWorkerPoolReconciler) setKubernetesVersionValidCondition(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool, versionErrs field.ErrorList) error {
+	condition := v1.Condition{
+		Type:               infrastructurev1alpha2.KubernetesVersionValidCondition,
+		Status:             v1.ConditionTrue,
+		Reason:             infrastructurev1alpha2.KubernetesVersionValidReason,
+		ObservedGeneration: workerPool.Generation,
+	}
+	if len(versionErrs) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = infrastructurev1alpha2.KubernetesVersionInvalidReason
+		condition.Message = versionErrs.ToAggregate().Error()
+	}
+
+	patch := client.MergeFrom(workerPool.DeepCopy())
+	if !meta.SetStatusCondition(&workerPool.Status.Conditions, condition) {
+		return nil
+	}
+	return c.Status().Patch(ctx, workerPool, patch)
+}
+
+// applyMachinePool applies the autoscaler bounds and the Kubernetes version of the MachinePool, which take precedence
+// over the ones of the GardenerWorkerPool, and returns whether the spec changed.
+func applyMachinePool(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, bounds *providerutil.AutoscalerBounds, version string) bool {
+	changed := bounds.Apply(spec)
+	return providerutil.ApplyWorkerPoolKubernetesVersion(spec, version) || changed
+}
+
+// reconcileMachines implements the MachinePool Machines contract of Cluster API: it ensures that there is exactly one
+// GardenerMachine for every node of the worker pool, and that its status reflects the node.
+func (r *GardenerWorkerPoolReconciler) reconcileMachines(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, cluster *clusterv1beta2.Cluster, nodes []corev1.Node) error {
+	log := runtimelog.FromContext(ctx).WithValues("operation", "reconcileMachines")
+
+	machineLabels := map[string]string{
+		clusterv1beta2.ClusterNameLabel:     cluster.Name,
+		clusterv1beta2.MachinePoolNameLabel: format.MustFormatValue(machinePool.Name),
+	}
+	machineList := &infrastructurev1alpha2.GardenerMachineList{}
+	if err := c.List(ctx, machineList, client.InNamespace(workerPool.Namespace), client.MatchingLabels(machineLabels)); err != nil {
+		return fmt.Errorf("failed to list GardenerMachines: %w", err)
+	}
+	machines := make(map[string]*infrastructurev1alpha2.GardenerMachine, len(machineList.Items))
+	for i := range machineList.Items {
+		machines[machineList.Items[i].Spec.NodeName] = &machineList.Items[i]
+	}
+
+	for _, node := range nodes {
+		machine, ok := machines[node.Name]
+		delete(machines, node.Name)
+		if !ok {
+			machine = &infrastructurev1alpha2.GardenerMachine{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      gardenerMachineName(workerPool, node.Name),
+					Namespace: workerPool.Namespace,
+					Labels:    machineLabels,
+				},
+				Spec: infrastructurev1alpha2.GardenerMachineSpec{
+					NodeName: node.Name,
+				},
+			}
+			if node.Spec.ProviderID != "" {
+				machine.Spec.ProviderID = ptr.To(node.Spec.ProviderID)
+			}
+			if err := controllerutil.SetOwnerReference(workerPool, machine, c.Scheme()); err != nil {
+				return err
+			}
+			log.Info("Creating GardenerMachine for node", "gardenerMachine", client.ObjectKeyFromObject(machine), "node", node.Name)
+			if err := c.Create(ctx, machine); err != nil {
+				return fmt.Errorf("failed to create GardenerMachine for node %s: %w", node.Name, err)
+			}
+		} else if machine.Spec.ProviderID == nil && node.Spec.ProviderID != "" {
+			patch := client.MergeFrom(machine.DeepCopy())
+			machine.Spec.ProviderID = ptr.To(node.Spec.ProviderID)
+			if err := c.Patch(ctx, machine, patch); err != nil {
+				return fmt.Errorf("failed to update provider ID of GardenerMachine %s: %w", client.ObjectKeyFromObject(machine), err)
+			}
+		}
+
+		status := infrastructurev1alpha2.GardenerMachineStatus{
+			Ready:       true,
+			NodeReady:   providerutil.IsNodeReady(&node),
+			Zone:        node.Labels[corev1.LabelTopologyZone],
+			MachineType: node.Labels[corev1.LabelInstanceTypeStable],
+			Initialization: infrastructurev1alpha2.GardenerMachineInitializationStatus{
+				Provisioned: ptr.To(true),
+			},
+		}
+		if !apiequality.Semantic.DeepEqual(machine.Status, status) {
+			patch := client.MergeFrom(machine.DeepCopy())
+			machine.Status = status
+			if err := c.Status().Patch(ctx, machine, patch); err != nil {
+				return fmt.Errorf("failed to update status of GardenerMachine %s: %w", client.ObjectKeyFromObject(machine), err)
+			}
+		}
+	}
+
+	// The remaining GardenerMachines have no node anymore.
+	for _, machine := range machines {
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		log.Info("Deleting GardenerMachine of removed node", "gardenerMachine", client.ObjectKeyFromObject(machine), "node", machine.Spec.NodeName)
+		if err := c.Delete(ctx, machine); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete GardenerMachine %s: %w", client.ObjectKeyFromObject(machine), err)
+		}
 	}
 
 	return nil
 }
 
-func (r *GardenerWorkerPoolReconciler) reconcile(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha1.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, cluster *clusterv1beta2.Cluster) (ctrl.Result, error) {
+// gardenerMachineName returns the name of the GardenerMachine for the given node. Node names are only unique within a
+// Shoot, hence the name is derived from the name of the worker pool and a hash of the node name.
+func gardenerMachineName(workerPool *infrastructurev1alpha2.GardenerWorkerPool, nodeName string) string {
+	return fmt.Sprintf("%s-%s", workerPool.Name, utils.ComputeSHA256Hex([]byte(nodeName))[:10])
+}
+
+func (r *GardenerWorkerPoolReconciler) reconcile(ctx context.Context, c client.Client, clusterName multicluster.ClusterName, workerPool *infrastructurev1alpha2.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, cluster *clusterv1beta2.Cluster) (ctrl.Result, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "reconcile")
-	if err := r.syncSpecs(ctx, c, workerPool, cluster); err != nil {
+
+	// The cluster name label is used to find the GardenerWorkerPools of a Shoot, see providerutil.ClusterNameField.
+	if workerPool.Labels[clusterv1beta2.ClusterNameLabel] != cluster.Name {
+		patch := client.MergeFrom(workerPool.DeepCopy())
+		v1.SetMetaDataLabel(&workerPool.ObjectMeta, clusterv1beta2.ClusterNameLabel, cluster.Name)
+		if err := c.Patch(ctx, workerPool, patch); err != nil {
+			log.Error(err, "Failed to patch cluster name label of GardenerWorkerPool")
+			return ctrl.Result{}, err
+		}
+	}
+
+	specPath := field.NewPath("spec")
+	bounds, errs := providerutil.AutoscalerBoundsFromMachinePool(machinePool, &workerPool.Spec, specPath)
+	version := providerutil.MachinePoolKubernetesVersion(machinePool)
+	var versionErrs field.ErrorList
+	if version != "" {
+		controlPlane, err := providerutil.ControlPlaneForCluster(ctx, c, cluster)
+		if err != nil {
+			log.Error(err, "Failed to get GardenerShootControlPlane")
+			return ctrl.Result{}, err
+		}
+		if controlPlane != nil {
+			versionErrs = providerutil.ValidateWorkerPoolKubernetesVersion(version, controlPlane.Spec.Kubernetes.Version, specPath.Child("kubernetes", "version"))
+		}
+	}
+	// MachinePools are not validated by the webhooks of the provider, so an invalid version is reported as a condition.
+	if err := r.setKubernetesVersionValidCondition(ctx, c, workerPool, versionErrs); err != nil {
+		log.Error(err, "Failed to update KubernetesVersionValid condition of GardenerWorkerPool")
+		return ctrl.Result{}, err
+	}
+	if errs = append(errs, versionErrs...); len(errs) > 0 {
+		err := errs.ToAggregate()
+		log.Error(err, "Invalid MachinePool for GardenerWorkerPool")
+		return ctrl.Result{}, err
+	}
+	if originalWorkerPool := workerPool.DeepCopy(); applyMachinePool(&workerPool.Spec, bounds, version) {
+		log.Info("Applying MachinePool to GardenerWorkerPool", "minimum", workerPool.Spec.Minimum, "maximum", workerPool.Spec.Maximum, "kubernetesVersion", version)
+		if err := c.Patch(ctx, workerPool, client.MergeFrom(originalWorkerPool)); err != nil {
+			log.Error(err, "Failed to apply MachinePool to GardenerWorkerPool")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.syncSpecs(ctx, c, workerPool, cluster, bounds, version); err != nil {
 		log.Error(err, "Failed to sync GardenerWorkerPool spec")
 		return ctrl.Result{}, err
 	}
 
 	if r.PrioritizeShoot {
-		if err := r.updateStatus(ctx, c, workerPool, machinePool, cluster); err != nil {
+		if err := r.updateStatus(ctx, c, clusterName, workerPool, machinePool, cluster, bounds); err != nil {
 			log.Error(err, "Failed to update GardenerWorkerPool status")
 			return ctrl.Result{}, err
 		}
@@ -296,64 +489,41 @@ func (r *GardenerWorkerPoolReconciler) SetupWithManager(mgr mcmanager.Manager, t
 					targetCluster.GetCache(),
 					&gardenercorev1beta1.Shoot{},
 					handler.TypedEnqueueRequestsFromMapFunc[client.Object, mcreconcile.Request](r.MapShootToGardenerWorkerPoolObject),
+					r.Sharder.Predicate(providerutil.ShootOwnerShardKey),
 				),
 			)
+		if r.ClusterCache != nil {
+			controller.WatchesRawSource(r.ClusterCache.WorkerPoolSource())
+		}
 	} else {
 		controller.
 			Named(name).
-			For(&infrastructurev1alpha1.GardenerWorkerPool{})
+			For(&infrastructurev1alpha2.GardenerWorkerPool{}).
+			Watches(&clusterv1beta2.MachinePool{}, mapMachinePoolToWorkerPool)
+	}
+	if r.Sharder != nil {
+		controller.WatchesRawSource(r.Sharder.Source(name))
 	}
 	return controller.Complete(r)
 }
 
-// MapShootToGardenerWorkerPoolObject maps a Shoot object to a list of GardenerWorkerPool reconcile requests.
+// MapShootToGardenerWorkerPoolObject maps a Shoot object to all GardenerWorkerPools of its owner.
 func (r *GardenerWorkerPoolReconciler) MapShootToGardenerWorkerPoolObject(ctx context.Context, obj client.Object) []mcreconcile.Request {
-	var (
-		log         = runtimelog.FromContext(ctx).WithValues("shoot", client.ObjectKeyFromObject(obj))
-		clusterName string
-	)
-	shoot, ok := obj.(*gardenercorev1beta1.Shoot)
-	if !ok {
-		log.Error(fmt.Errorf("could not assert object to Shoot"), "")
-		return nil
-	}
-
-	namespace, ok := shoot.GetLabels()[infrastructurev1alpha1.GSWReferenceNamespaceKey]
-	if !ok {
-		log.Info("Could not find gsw namespace on label")
-		return nil
-	}
+	return providerutil.MapShootToOwnedObjects(ctx, r.Manager, obj, &infrastructurev1alpha2.GardenerWorkerPoolList{})
+}
 
-	if r.IsKCP {
-		clusterName, ok = shoot.GetLabels()[infrastructurev1alpha1.GSWReferenceClusterNameKey]
+// mapMachinePoolToWorkerPool maps a MachinePool to the GardenerWorkerPool it references, so that changes of its
+// autoscaler annotations and of its Kubernetes version are applied.
+func mapMachinePoolToWorkerPool(clusterName multicluster.ClusterName, _ controllerRuntimeCluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []mcreconcile.Request {
+		machinePool, ok := obj.(*clusterv1beta2.MachinePool)
 		if !ok {
-			log.Info("Could not find gsw cluster on label")
-		}
-	}
-
-	requests := []mcreconcile.Request{}
-	for key, value := range shoot.GetLabels() {
-		if !strings.HasPrefix(key, infrastructurev1alpha1.GSWReferenceNamePrefix) {
-			continue
-		}
-		if value != infrastructurev1alpha1.GSWTrue {
-			continue
+			return nil
 		}
-
-		name := strings.TrimPrefix(key, infrastructurev1alpha1.GSWReferenceNamePrefix)
-		if name == "" {
-			log.Info("Could not extract gsw name from label")
-			continue
+		ref := machinePool.Spec.Template.Spec.InfrastructureRef
+		if ref.GroupKind() != infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind() {
+			return nil
 		}
-
-		requests = append(requests, mcreconcile.Request{Request: reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Name:      name,
-				Namespace: namespace,
-			},
-		},
-			ClusterName: multicluster.ClusterName(clusterName),
-		})
-	}
-	return requests
+		return []mcreconcile.Request{{ClusterName: clusterName, Request: reconcile.Request{NamespacedName: client.ObjectKey{Namespace: machinePool.Namespace, Name: ref.Name}}}}
+	})
 }
@@ -10,15 +10,24 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
 var _ = Describe("GardenerWorkerPool Controller", func() {
@@ -31,7 +40,7 @@ var _ = Describe("GardenerWorkerPool Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		gardenerworkerpool := &infrastructurev1alpha1.GardenerWorkerPool{}
+		gardenerworkerpool := &infrastructurev1alpha2.GardenerWorkerPool{}
 
 		BeforeEach(func() {
 			By("Ensuring the custom resource for the Kind GardenerWorkerPool")
@@ -42,7 +51,7 @@ var _ = Describe("GardenerWorkerPool Controller", func() {
 			}).WithTimeout(10 * time.Second).Should(Succeed())
 			if err != nil && errors.IsNotFound(err) {
 				By("Creating the custom resource for the Kind GardenerWorkerPool")
-				resource := &infrastructurev1alpha1.GardenerWorkerPool{
+				resource := &infrastructurev1alpha2.GardenerWorkerPool{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
@@ -56,7 +65,7 @@ var _ = Describe("GardenerWorkerPool Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &infrastructurev1alpha1.GardenerWorkerPool{}
+			resource := &infrastructurev1alpha2.GardenerWorkerPool{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -68,8 +77,6 @@ var _ = Describe("GardenerWorkerPool Controller", func() {
 			controllerReconciler := &GardenerWorkerPoolReconciler{
 				Manager:        mgr,
 				GardenerClient: k8sClient,
-				IsKCP:          false,
-				Scheme:         k8sClient.Scheme(),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, mcreconcile.Request{
@@ -84,3 +91,195 @@ var _ = Describe("GardenerWorkerPool Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("GardenerMachines of a GardenerWorkerPool", func() {
+	var (
+		ctx         context.Context
+		c           client.Client
+		reconciler  *GardenerWorkerPoolReconciler
+		workerPool  *infrastructurev1alpha2.GardenerWorkerPool
+		machinePool *clusterv1beta2.MachinePool
+		cluster     *clusterv1beta2.Cluster
+		node        corev1.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(infrastructurev1alpha2.AddToScheme(testScheme)).To(Succeed())
+		c = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&infrastructurev1alpha2.GardenerMachine{}).
+			Build()
+		reconciler = &GardenerWorkerPoolReconciler{}
+
+		workerPool = &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"}}
+		machinePool = &clusterv1beta2.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "machine-pool", Namespace: "default"}}
+		cluster = &clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		node = corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					corev1.LabelTopologyZone:       "eu-west-1a",
+					corev1.LabelInstanceTypeStable: "m5.large",
+				},
+			},
+			Spec: corev1.NodeSpec{ProviderID: "aws:///eu-west-1a/i-1"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	})
+
+	listMachines := func() []infrastructurev1alpha2.GardenerMachine {
+		machineList := &infrastructurev1alpha2.GardenerMachineList{}
+		Expect(c.List(ctx, machineList, client.InNamespace("default"))).To(Succeed())
+		return machineList.Items
+	}
+
+	It("should create a GardenerMachine for every node", func() {
+		Expect(reconciler.reconcileMachines(ctx, c, workerPool, machinePool, cluster, []corev1.Node{node})).To(Succeed())
+
+		machines := listMachines()
+		Expect(machines).To(HaveLen(1))
+		machine := machines[0]
+		Expect(machine.Name).To(Equal(gardenerMachineName(workerPool, "node-1")))
+		Expect(machine.Labels).To(Equal(map[string]string{
+			"cluster.x-k8s.io/cluster-name": "cluster",
+			"cluster.x-k8s.io/pool-name":    "machine-pool",
+		}))
+		Expect(machine.OwnerReferences).To(ConsistOf(HaveField("UID", Equal(types.UID("pool-uid")))))
+		Expect(machine.Spec.NodeName).To(Equal("node-1"))
+		Expect(machine.Spec.ProviderID).To(Equal(ptr.To("aws:///eu-west-1a/i-1")))
+		Expect(machine.Status).To(Equal(infrastructurev1alpha2.GardenerMachineStatus{
+			Ready:       true,
+			NodeReady:   true,
+			Zone:        "eu-west-1a",
+			MachineType: "m5.large",
+			Initialization: infrastructurev1alpha2.GardenerMachineInitializationStatus{
+				Provisioned: ptr.To(true),
+			},
+		}))
+	})
+
+	It("should update the status of an existing GardenerMachine", func() {
+		Expect(reconciler.reconcileMachines(ctx, c, workerPool, machinePool, cluster, []corev1.Node{node})).To(Succeed())
+
+		node.Status.Conditions[0].Status = corev1.ConditionFalse
+		Expect(reconciler.reconcileMachines(ctx, c, workerPool, machinePool, cluster, []corev1.Node{node})).To(Succeed())
+
+		machines := listMachines()
+		Expect(machines).To(HaveLen(1))
+		Expect(machines[0].Status.NodeReady).To(BeFalse())
+	})
+
+	It("should delete the GardenerMachine of a removed node", func() {
+		Expect(reconciler.reconcileMachines(ctx, c, workerPool, machinePool, cluster, []corev1.Node{node})).To(Succeed())
+
+		Expect(reconciler.reconcileMachines(ctx, c, workerPool, machinePool, cluster, nil)).To(Succeed())
+
+		Expect(listMachines()).To(BeEmpty())
+	})
+
+	It("should derive distinct names for nodes of different worker pools", func() {
+		otherWorkerPool := &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "other-pool"}}
+
+		Expect(gardenerMachineName(workerPool, "node-1")).To(HavePrefix("pool-"))
+		Expect(gardenerMachineName(workerPool, "node-1")).NotTo(Equal(gardenerMachineName(workerPool, "node-2")))
+		Expect(gardenerMachineName(otherWorkerPool, "node-1")).To(HavePrefix("other-pool-"))
+	})
+})
+
+var _ = Describe("KubernetesVersionValid condition of a GardenerWorkerPool", func() {
+	var (
+		ctx        context.Context
+		c          client.Client
+		reconciler *GardenerWorkerPoolReconciler
+		workerPool *infrastructurev1alpha2.GardenerWorkerPool
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(infrastructurev1alpha2.AddToScheme(testScheme)).To(Succeed())
+		workerPool = &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Generation: 2}}
+		c = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithObjects(workerPool).
+			WithStatusSubresource(&infrastructurev1alpha2.GardenerWorkerPool{}).
+			Build()
+		reconciler = &GardenerWorkerPoolReconciler{}
+	})
+
+	getCondition := func() *metav1.Condition {
+		current := &infrastructurev1alpha2.GardenerWorkerPool{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(workerPool), current)).To(Succeed())
+		return meta.FindStatusCondition(current.Status.Conditions, infrastructurev1alpha2.KubernetesVersionValidCondition)
+	}
+
+	It("should report an invalid version of the MachinePool and clear it once fixed", func() {
+		versionErrs := field.ErrorList{field.Forbidden(field.NewPath("spec", "kubernetes", "version"), "must not be newer than the control plane version 1.31.1")}
+		Expect(reconciler.setKubernetesVersionValidCondition(ctx, c, workerPool, versionErrs)).To(Succeed())
+
+		condition := getCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(infrastructurev1alpha2.KubernetesVersionInvalidReason))
+		Expect(condition.Message).To(ContainSubstring("must not be newer than the control plane version 1.31.1"))
+		Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+		Expect(reconciler.setKubernetesVersionValidCondition(ctx, c, workerPool, nil)).To(Succeed())
+
+		condition = getCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(infrastructurev1alpha2.KubernetesVersionValidReason))
+	})
+})
+
+var _ = Describe("replicas-managed-by annotation of a MachinePool", func() {
+	var (
+		ctx         context.Context
+		c           client.Client
+		reconciler  *GardenerWorkerPoolReconciler
+		machinePool *clusterv1beta2.MachinePool
+		bounds      *providerutil.AutoscalerBounds
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(clusterv1beta2.AddToScheme(testScheme)).To(Succeed())
+		machinePool = &clusterv1beta2.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+		c = fakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(machinePool).Build()
+		reconciler = &GardenerWorkerPoolReconciler{}
+		bounds = &providerutil.AutoscalerBounds{Minimum: 1, Maximum: 3}
+	})
+
+	getAnnotations := func() map[string]string {
+		current := &clusterv1beta2.MachinePool{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(machinePool), current)).To(Succeed())
+		return current.Annotations
+	}
+
+	It("should set the annotation while the worker pool is autoscaled and remove it afterwards", func() {
+		Expect(reconciler.reconcileReplicasManagedBy(ctx, c, machinePool, bounds)).To(Succeed())
+		Expect(getAnnotations()).To(HaveKey(clusterv1beta2.ReplicasManagedByAnnotation))
+
+		Expect(reconciler.reconcileReplicasManagedBy(ctx, c, machinePool, nil)).To(Succeed())
+		Expect(getAnnotations()).NotTo(HaveKey(clusterv1beta2.ReplicasManagedByAnnotation))
+		Expect(getAnnotations()).NotTo(HaveKey(infrastructurev1alpha2.ReplicasManagedByAutoscalerAnnotation))
+	})
+
+	It("should keep an annotation which was not set by the controller", func() {
+		machinePool.Annotations = map[string]string{clusterv1beta2.ReplicasManagedByAnnotation: "external-autoscaler"}
+		Expect(c.Update(ctx, machinePool)).To(Succeed())
+
+		Expect(reconciler.reconcileReplicasManagedBy(ctx, c, machinePool, bounds)).To(Succeed())
+		Expect(getAnnotations()).NotTo(HaveKey(infrastructurev1alpha2.ReplicasManagedByAutoscalerAnnotation))
+
+		Expect(reconciler.reconcileReplicasManagedBy(ctx, c, machinePool, nil)).To(Succeed())
+		Expect(getAnnotations()).To(HaveKeyWithValue(clusterv1beta2.ReplicasManagedByAnnotation, "external-autoscaler"))
+	})
+})
@@ -22,7 +22,7 @@ import (
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	mcsingle "sigs.k8s.io/multicluster-runtime/providers/single"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 	"github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
@@ -50,7 +50,7 @@ var _ = BeforeSuite(func() {
 	ctx, cancel = context.WithCancel(context.Background())
 
 	var err error
-	err = infrastructurev1alpha1.AddToScheme(scheme.Scheme)
+	err = infrastructurev1alpha2.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// collectTimeout is the time to wait for the garden cache when collecting the metrics.
+const collectTimeout = 5 * time.Second
+
+var gardenCacheShootsDesc = prometheus.NewDesc(
+	"cluster_api_provider_gardener_garden_cache_shoots",
+	"Number of Shoots in the garden cache by project namespace.",
+	[]string{"namespace"},
+	nil,
+)
+
+// GardenCacheCollector reports the number of Shoots held in the garden cache.
+type GardenCacheCollector struct {
+	// Reader reads from the garden cache.
+	Reader client.Reader
+}
+
+// RegisterGardenCacheMetrics registers the metrics of the garden cache with the controller-runtime metrics registry.
+func RegisterGardenCacheMetrics(reader client.Reader) error {
+	return metrics.Registry.Register(&GardenCacheCollector{Reader: reader})
+}
+
+// Describe implements prometheus.Collector.
+func (c *GardenCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gardenCacheShootsDesc
+}
+
+// Collect implements prometheus.Collector. Nothing is reported while the cache is not started.
+func (c *GardenCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	shoots := &gardenercorev1beta1.ShootList{}
+	if err := c.Reader.List(ctx, shoots, client.UnsafeDisableDeepCopy); err != nil {
+		return
+	}
+
+	shootsByNamespace := map[string]int{}
+	for _, shoot := range shoots.Items {
+		shootsByNamespace[shoot.Namespace]++
+	}
+	for namespace, count := range shootsByNamespace {
+		ch <- prometheus.MustNewConstMetric(gardenCacheShootsDesc, prometheus.GaugeValue, float64(count), namespace)
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics_test
+
+import (
+	"strings"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/gardener/cluster-api-provider-gardener/internal/metrics"
+)
+
+var _ = Describe("GardenCacheCollector", func() {
+	It("should report the number of Shoots by project namespace", func() {
+		c := fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(
+			&gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-dev"}},
+			&gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-dev"}},
+			&gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-prod"}},
+		).Build()
+
+		Expect(testutil.CollectAndCompare(&GardenCacheCollector{Reader: c}, strings.NewReader(`
+# HELP cluster_api_provider_gardener_garden_cache_shoots Number of Shoots in the garden cache by project namespace.
+# TYPE cluster_api_provider_gardener_garden_cache_shoots gauge
+cluster_api_provider_gardener_garden_cache_shoots{namespace="garden-dev"} 2
+cluster_api_provider_gardener_garden_cache_shoots{namespace="garden-prod"} 1
+`))).To(Succeed())
+	})
+})
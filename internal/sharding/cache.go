@@ -0,0 +1,553 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// syncPollInterval is the interval in which the informers of the shards are checked for being synced.
+const syncPollInterval = 100 * time.Millisecond
+
+// SetShardLabel sets the given label of the object to the shard of the given namespace and logical cluster, so that the
+// object is cached by the replica holding the shard, see NewCache. A nil Sharder does not label objects.
+func (s *Sharder) SetShardLabel(obj metav1.Object, label string, clusterName multicluster.ClusterName, namespace string) {
+	if s == nil {
+		return
+	}
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[label] = strconv.Itoa(s.ShardFor(clusterName, namespace))
+	obj.SetLabels(objLabels)
+}
+
+// NewCache returns a function which creates caches that only contain the objects of the given type in the shards held
+// by the replica. The objects are selected by the given label, which contains their shard, see SetShardLabel. Every held
+// shard is cached separately, the cache of a shard is started when the shard is acquired and stopped when it is
+// released. Objects of other types are cached as usual.
+//
+// Objects whose shard label is missing or outdated are not cached by any replica, they have to be read with an API
+// reader until their label is updated.
+func (s *Sharder) NewCache(obj client.Object, label string) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		c, err := newShardedCache(obj, label, opts, func(opts cache.Options) (cache.Cache, error) {
+			return cache.New(config, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if c.Cache, err = cache.New(config, opts); err != nil {
+			return nil, err
+		}
+		s.addCache(c)
+		return c, nil
+	}
+}
+
+// addCache starts the shards held by the replica in the given cache, the cache is notified about shards which are
+// acquired or released later.
+func (s *Sharder) addCache(c *shardedCache) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.caches = append(s.caches, c)
+	for shard := range s.owned {
+		c.startShard(shard)
+	}
+}
+
+// shardedCache caches the objects of a single type in a separate cache for every held shard. All other objects are
+// cached by the embedded cache.
+type shardedCache struct {
+	cache.Cache
+
+	obj   client.Object
+	gvk   schema.GroupVersionKind
+	label string
+	// opts and byObject are the options of the sharded type before they were defaulted by cache.New.
+	opts     cache.Options
+	byObject cache.ByObject
+	newCache func(cache.Options) (cache.Cache, error)
+	informer *shardedInformer
+
+	lock   sync.Mutex
+	ctx    context.Context
+	shards map[int]*shardCache
+}
+
+var _ cache.Cache = &shardedCache{}
+
+// shardCache is the cache of a held shard. The cache is nil until the sharded cache is started.
+type shardCache struct {
+	cache  cache.Cache
+	cancel context.CancelFunc
+}
+
+func newShardedCache(obj client.Object, label string, opts cache.Options, newCache func(cache.Options) (cache.Cache, error)) (*shardedCache, error) {
+	gvk, err := apiutil.GVKForObject(obj, opts.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := labels.NewRequirement(label, selection.Equals, []string{"0"}); err != nil {
+		return nil, fmt.Errorf("invalid shard label: %w", err)
+	}
+
+	// cache.New defaults the options in place, copy them so that the shard label can be added to the selectors later.
+	var byObject cache.ByObject
+	for o, b := range opts.ByObject {
+		if objGVK, err := apiutil.GVKForObject(o, opts.Scheme); err == nil && objGVK == gvk {
+			byObject = b
+			byObject.Namespaces = maps.Clone(b.Namespaces)
+		}
+	}
+	opts.DefaultNamespaces = maps.Clone(opts.DefaultNamespaces)
+
+	return &shardedCache{
+		obj:      obj,
+		gvk:      gvk,
+		label:    label,
+		opts:     opts,
+		byObject: byObject,
+		newCache: newCache,
+		informer: &shardedInformer{informers: map[int]cache.Informer{}, stopped: make(chan struct{})},
+		shards:   map[int]*shardCache{},
+	}, nil
+}
+
+// Get implements client.Reader. Objects of the sharded type are looked up in the caches of all held shards.
+func (c *shardedCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if !c.isSharded(obj) {
+		return c.Cache.Get(ctx, key, obj, opts...)
+	}
+	caches, err := c.shardCaches(ctx)
+	if err != nil {
+		return err
+	}
+	for _, shardCache := range caches {
+		if err := shardCache.Get(ctx, key, obj, opts...); !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return apierrors.NewNotFound(schema.GroupResource{Group: c.gvk.Group, Resource: c.gvk.Kind}, key.Name)
+}
+
+// List implements client.Reader. Objects of the sharded type are collected from the caches of all held shards.
+func (c *shardedCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if !c.isSharded(list) {
+		return c.Cache.List(ctx, list, opts...)
+	}
+	caches, err := c.shardCaches(ctx)
+	if err != nil {
+		return err
+	}
+	var items []runtime.Object
+	for _, shardCache := range caches {
+		shardList, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return fmt.Errorf("unexpected list type %T", list)
+		}
+		if err := shardCache.List(ctx, shardList, opts...); err != nil {
+			return err
+		}
+		shardItems, err := meta.ExtractList(shardList)
+		if err != nil {
+			return err
+		}
+		items = append(items, shardItems...)
+	}
+	return meta.SetList(list, items)
+}
+
+// GetInformer implements cache.Informers. The informer of the sharded type delivers the events of all held shards.
+func (c *shardedCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	if !c.isSharded(obj) {
+		return c.Cache.GetInformer(ctx, obj, opts...)
+	}
+	return c.informer, nil
+}
+
+// GetInformerForKind implements cache.Informers.
+func (c *shardedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	if gvk != c.gvk {
+		return c.Cache.GetInformerForKind(ctx, gvk, opts...)
+	}
+	return c.informer, nil
+}
+
+// RemoveInformer implements cache.Informers.
+func (c *shardedCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	if !c.isSharded(obj) {
+		return c.Cache.RemoveInformer(ctx, obj)
+	}
+	return errors.New("the informer of a sharded type cannot be removed")
+}
+
+// IndexField implements client.FieldIndexer.
+func (c *shardedCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	if !c.isSharded(obj) {
+		return c.Cache.IndexField(ctx, obj, field, extractValue)
+	}
+	return errors.New("fields of a sharded type cannot be indexed")
+}
+
+// Start implements cache.Informers. The caches of the shards acquired before are started, too.
+func (c *shardedCache) Start(ctx context.Context) error {
+	c.lock.Lock()
+	c.ctx = ctx
+	for shard, shardCache := range c.shards {
+		c.runShardLocked(shard, shardCache)
+	}
+	c.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		close(c.informer.stopped)
+	}()
+	return c.Cache.Start(ctx)
+}
+
+// WaitForCacheSync implements cache.Informers.
+func (c *shardedCache) WaitForCacheSync(ctx context.Context) bool {
+	if !c.Cache.WaitForCacheSync(ctx) {
+		return false
+	}
+	_, err := c.shardCaches(ctx)
+	return err == nil
+}
+
+// isSharded returns true if the given object or list is of the sharded type.
+func (c *shardedCache) isSharded(obj runtime.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, c.opts.Scheme)
+	if err != nil {
+		return false
+	}
+	if meta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	return gvk == c.gvk
+}
+
+// shardCaches returns the caches of the held shards once they are synced.
+func (c *shardedCache) shardCaches(ctx context.Context) ([]cache.Cache, error) {
+	c.lock.Lock()
+	caches := make([]cache.Cache, 0, len(c.shards))
+	for _, shardCache := range c.shards {
+		if shardCache.cache != nil {
+			caches = append(caches, shardCache.cache)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, shardCache := range caches {
+		if !shardCache.WaitForCacheSync(ctx) {
+			return nil, fmt.Errorf("cache of %s shard did not sync", c.gvk.Kind)
+		}
+	}
+	return caches, nil
+}
+
+// startShard caches the objects of the given shard. The cache is started with the sharded cache, if that is not
+// started yet.
+func (c *shardedCache) startShard(shard int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.shards[shard]; ok {
+		return
+	}
+	shardCache := &shardCache{}
+	c.shards[shard] = shardCache
+	if c.ctx != nil {
+		c.runShardLocked(shard, shardCache)
+	}
+}
+
+// stopShard stops caching the objects of the given shard.
+func (c *shardedCache) stopShard(shard int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	shardCache, ok := c.shards[shard]
+	if !ok {
+		return
+	}
+	delete(c.shards, shard)
+	c.informer.remove(shard)
+	if shardCache.cancel != nil {
+		shardCache.cancel()
+	}
+}
+
+// runShardLocked creates and starts the cache of the given shard. The event handlers of the sharded informer are added
+// before the cache is started, so that they receive the objects of the shard. The caller must hold the lock.
+func (c *shardedCache) runShardLocked(shard int, shardCache *shardCache) {
+	log := runtimelog.FromContext(c.ctx).WithValues("kind", c.gvk.Kind, "shard", shard)
+
+	opts, err := c.shardOptions(shard)
+	if err != nil {
+		log.Error(err, "Failed to create cache of shard")
+		return
+	}
+	newCache, err := c.newCache(opts)
+	if err != nil {
+		log.Error(err, "Failed to create cache of shard")
+		return
+	}
+	informer, err := newCache.GetInformer(c.ctx, c.obj)
+	if err != nil {
+		log.Error(err, "Failed to create informer of shard")
+		return
+	}
+	if err := c.informer.add(shard, informer); err != nil {
+		log.Error(err, "Failed to add event handlers to informer of shard")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	shardCache.cache, shardCache.cancel = newCache, cancel
+	go func() {
+		if err := newCache.Start(ctx); err != nil {
+			log.Error(err, "Failed to start cache of shard")
+		}
+	}()
+}
+
+// shardOptions returns the options of the cache of the given shard, which only selects the objects of the sharded type
+// carrying the shard label.
+func (c *shardedCache) shardOptions(shard int) (cache.Options, error) {
+	requirement, err := labels.NewRequirement(c.label, selection.Equals, []string{strconv.Itoa(shard)})
+	if err != nil {
+		return cache.Options{}, err
+	}
+	withShard := func(selector labels.Selector) labels.Selector {
+		if selector == nil {
+			selector = labels.Everything()
+		}
+		return selector.DeepCopySelector().Add(*requirement)
+	}
+
+	byObject := c.byObject
+	byObject.Label = withShard(byObject.Label)
+	byObject.Namespaces = maps.Clone(byObject.Namespaces)
+	for namespace, config := range byObject.Namespaces {
+		if config.LabelSelector != nil {
+			config.LabelSelector = withShard(config.LabelSelector)
+			byObject.Namespaces[namespace] = config
+		}
+	}
+
+	opts := c.opts
+	opts.DefaultNamespaces = maps.Clone(opts.DefaultNamespaces)
+	opts.ByObject = map[client.Object]cache.ByObject{c.obj: byObject}
+	return opts, nil
+}
+
+// shardedInformer adds its event handlers to the informers of all held shards, including shards acquired later.
+type shardedInformer struct {
+	// stopped is closed when the sharded cache is stopped.
+	stopped chan struct{}
+
+	lock          sync.Mutex
+	informers     map[int]cache.Informer
+	registrations []*shardedRegistration
+}
+
+var _ cache.Informer = &shardedInformer{}
+
+// AddEventHandler implements cache.Informer.
+func (i *shardedInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.AddEventHandlerWithOptions(handler, toolscache.HandlerOptions{})
+}
+
+// AddEventHandlerWithResyncPeriod implements cache.Informer.
+func (i *shardedInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.AddEventHandlerWithOptions(handler, toolscache.HandlerOptions{ResyncPeriod: &resyncPeriod})
+}
+
+// AddEventHandlerWithOptions implements cache.Informer.
+func (i *shardedInformer) AddEventHandlerWithOptions(handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	registration := &shardedRegistration{
+		informer:      i,
+		handler:       handler,
+		options:       options,
+		registrations: map[int]toolscache.ResourceEventHandlerRegistration{},
+	}
+	for shard, informer := range i.informers {
+		shardRegistration, err := informer.AddEventHandlerWithOptions(handler, options)
+		if err != nil {
+			return nil, err
+		}
+		registration.registrations[shard] = shardRegistration
+	}
+	i.registrations = append(i.registrations, registration)
+	return registration, nil
+}
+
+// RemoveEventHandler implements cache.Informer.
+func (i *shardedInformer) RemoveEventHandler(handle toolscache.ResourceEventHandlerRegistration) error {
+	registration, ok := handle.(*shardedRegistration)
+	if !ok {
+		return fmt.Errorf("unexpected registration type %T", handle)
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	var errs []error
+	for shard, shardRegistration := range registration.registrations {
+		errs = append(errs, i.informers[shard].RemoveEventHandler(shardRegistration))
+	}
+	registration.registrations = map[int]toolscache.ResourceEventHandlerRegistration{}
+	i.registrations = slices.DeleteFunc(i.registrations, func(r *shardedRegistration) bool { return r == registration })
+	return errors.Join(errs...)
+}
+
+// AddIndexers implements cache.Informer.
+func (i *shardedInformer) AddIndexers(_ toolscache.Indexers) error {
+	return errors.New("the informer of a sharded type cannot be indexed")
+}
+
+// HasSynced implements cache.Informer. It returns true once the informers of all held shards are synced.
+func (i *shardedInformer) HasSynced() bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSyncedChecker implements cache.Informer.
+func (i *shardedInformer) HasSyncedChecker() toolscache.DoneChecker {
+	return newSyncedChecker("sharded informer", i.HasSynced, i.stopped)
+}
+
+// IsStopped implements cache.Informer.
+func (i *shardedInformer) IsStopped() bool {
+	select {
+	case <-i.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// add adds the event handlers to the informer of the given shard.
+func (i *shardedInformer) add(shard int, informer cache.Informer) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.informers[shard] = informer
+	for _, registration := range i.registrations {
+		shardRegistration, err := informer.AddEventHandlerWithOptions(registration.handler, registration.options)
+		if err != nil {
+			return err
+		}
+		registration.registrations[shard] = shardRegistration
+	}
+	return nil
+}
+
+// remove removes the event handlers from the informer of the given shard.
+func (i *shardedInformer) remove(shard int) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	informer, ok := i.informers[shard]
+	if !ok {
+		return
+	}
+	delete(i.informers, shard)
+	for _, registration := range i.registrations {
+		if shardRegistration, ok := registration.registrations[shard]; ok {
+			_ = informer.RemoveEventHandler(shardRegistration)
+			delete(registration.registrations, shard)
+		}
+	}
+}
+
+// shardedRegistration is the registration of an event handler of a shardedInformer.
+type shardedRegistration struct {
+	informer *shardedInformer
+	handler  toolscache.ResourceEventHandler
+	options  toolscache.HandlerOptions
+	// registrations are the registrations of the handler at the informers of the shards.
+	registrations map[int]toolscache.ResourceEventHandlerRegistration
+}
+
+// HasSynced implements toolscache.ResourceEventHandlerRegistration. It returns true once the handler received the
+// initial events of all held shards.
+func (r *shardedRegistration) HasSynced() bool {
+	r.informer.lock.Lock()
+	defer r.informer.lock.Unlock()
+	for _, registration := range r.registrations {
+		if !registration.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSyncedChecker implements toolscache.ResourceEventHandlerRegistration.
+func (r *shardedRegistration) HasSyncedChecker() toolscache.DoneChecker {
+	return newSyncedChecker("sharded event handler", r.HasSynced, r.informer.stopped)
+}
+
+// syncedChecker implements toolscache.DoneChecker by polling a HasSynced function until it returns true or stop is
+// closed.
+type syncedChecker struct {
+	name      string
+	hasSynced func() bool
+	stop      <-chan struct{}
+
+	once sync.Once
+	done chan struct{}
+}
+
+func newSyncedChecker(name string, hasSynced func() bool, stop <-chan struct{}) *syncedChecker {
+	return &syncedChecker{name: name, hasSynced: hasSynced, stop: stop, done: make(chan struct{})}
+}
+
+// Name implements toolscache.DoneChecker.
+func (c *syncedChecker) Name() string {
+	return c.name
+}
+
+// Done implements toolscache.DoneChecker.
+func (c *syncedChecker) Done() <-chan struct{} {
+	c.once.Do(func() {
+		go func() {
+			if err := wait.PollUntilContextCancel(wait.ContextForChannel(c.stop), syncPollInterval, true, func(context.Context) (bool, error) {
+				return c.hasSynced(), nil
+			}); err == nil {
+				close(c.done)
+			}
+		}()
+	})
+	return c.done
+}
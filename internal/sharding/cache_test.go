@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Sharded cache", func() {
+	const (
+		shards     = 8
+		namespaces = 16
+		shardLabel = "shard"
+		ownerLabel = "owner"
+	)
+
+	var (
+		ctx          context.Context
+		leaseClient  client.Client
+		objectClient client.Client
+		fakeClock    *testclock.FakeClock
+		shardCaches  []*fakeShardCache
+	)
+
+	newSharder := func(identity string) *Sharder {
+		s, err := New(leaseClient, Options{Shards: shards, Namespace: "default", Name: "capga", Identity: identity})
+		Expect(err).NotTo(HaveOccurred())
+		s.clock = fakeClock
+		s.ctx = ctx
+		return s
+	}
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		leaseClient = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		fakeClock = testclock.NewFakeClock(time.Now())
+		shardCaches = nil
+
+		labeler := newSharder("labeler")
+		builder := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme)
+		for i := range namespaces {
+			namespace := fmt.Sprintf("ns-%d", i)
+			managed := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: namespace, Labels: map[string]string{ownerLabel: "foo"}}}
+			labeler.SetShardLabel(managed, shardLabel, "", namespace)
+			unmanaged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: namespace}}
+			labeler.SetShardLabel(unmanaged, shardLabel, "", namespace)
+			builder.WithObjects(managed, unmanaged)
+		}
+		objectClient = builder.Build()
+	})
+
+	newCache := func(s *Sharder) *shardedCache {
+		ownerSelector := labels.NewSelector()
+		requirement, err := labels.NewRequirement(ownerLabel, selection.Exists, nil)
+		Expect(err).NotTo(HaveOccurred())
+		opts := cache.Options{
+			Scheme: clientgoscheme.Scheme,
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {Label: ownerSelector.Add(*requirement)},
+			},
+		}
+
+		c, err := newShardedCache(&corev1.ConfigMap{}, shardLabel, opts, func(opts cache.Options) (cache.Cache, error) {
+			shardCache := newFakeShardCache(objectClient, opts)
+			shardCaches = append(shardCaches, shardCache)
+			return shardCache, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		c.Cache = &informertest.FakeInformers{Scheme: clientgoscheme.Scheme}
+		s.addCache(c)
+		Expect(c.Start(ctx)).To(Succeed())
+		return c
+	}
+
+	// syncAll runs two rounds of syncs, as shards are only acquired after the previous holder released them.
+	syncAll := func(sharders ...*Sharder) {
+		for range 2 {
+			for _, s := range sharders {
+				Expect(s.sync(ctx)).To(Succeed())
+			}
+		}
+	}
+
+	It("should only cache the objects of the held shards", func() {
+		a, b := newSharder("a"), newSharder("b")
+		cacheA := newCache(a)
+		syncAll(a, b)
+
+		configMaps := &corev1.ConfigMapList{}
+		Expect(cacheA.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).NotTo(BeEmpty())
+		Expect(len(configMaps.Items)).To(BeNumerically("<", namespaces))
+		for _, configMap := range configMaps.Items {
+			Expect(configMap.Name).To(Equal("managed"))
+			Expect(a.Owns("", configMap.Namespace)).To(BeTrue())
+		}
+
+		for i := range namespaces {
+			namespace := fmt.Sprintf("ns-%d", i)
+			err := cacheA.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "managed"}, &corev1.ConfigMap{})
+			if a.Owns("", namespace) {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+		}
+
+		By("taking over the shards of a replica which left")
+		Expect(b.release(ctx)).To(Succeed())
+		syncAll(a)
+		Expect(cacheA.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(namespaces))
+
+		By("dropping the objects of released shards")
+		syncAll(a, b)
+		Expect(cacheA.List(ctx, configMaps)).To(Succeed())
+		Expect(len(configMaps.Items)).To(BeNumerically("<", namespaces))
+		for _, configMap := range configMaps.Items {
+			Expect(a.Owns("", configMap.Namespace)).To(BeTrue())
+		}
+	})
+
+	It("should add event handlers to the informers of shards acquired later", func() {
+		a := newSharder("a")
+		cacheA := newCache(a)
+
+		informer, err := cacheA.GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		var added []string
+		_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				added = append(added, obj.(*corev1.ConfigMap).Namespace)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		syncAll(a)
+		Expect(shardCaches).To(HaveLen(shards))
+		Expect(informer.HasSynced()).To(BeTrue())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(objectClient.Get(ctx, client.ObjectKey{Namespace: "ns-0", Name: "managed"}, configMap)).To(Succeed())
+		for _, shardCache := range shardCaches {
+			if !shardCache.selector.Matches(labels.Set(configMap.Labels)) {
+				continue
+			}
+			shardInformer, err := shardCache.FakeInformerFor(ctx, &corev1.ConfigMap{})
+			Expect(err).NotTo(HaveOccurred())
+			shardInformer.Add(configMap)
+		}
+		Expect(added).To(ConsistOf("ns-0"))
+	})
+
+	It("should not label objects without sharding", func() {
+		var s *Sharder
+		configMap := &corev1.ConfigMap{}
+		s.SetShardLabel(configMap, shardLabel, "", "default")
+		Expect(configMap.Labels).To(BeEmpty())
+	})
+})
+
+// fakeShardCache serves the objects of a fake client which match the label selector of the sharded type.
+type fakeShardCache struct {
+	informertest.FakeInformers
+	client   client.Client
+	selector labels.Selector
+}
+
+func newFakeShardCache(c client.Client, opts cache.Options) *fakeShardCache {
+	shardCache := &fakeShardCache{FakeInformers: informertest.FakeInformers{Scheme: opts.Scheme}, client: c}
+	for _, byObject := range opts.ByObject {
+		shardCache.selector = byObject.Label
+	}
+	return shardCache
+}
+
+func (c *fakeShardCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if !c.selector.Matches(labels.Set(obj.GetLabels())) {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	return nil
+}
+
+func (c *fakeShardCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.client.List(ctx, list, append(opts, client.MatchingLabelsSelector{Selector: c.selector})...)
+}
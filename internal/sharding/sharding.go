@@ -0,0 +1,429 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sharding distributes the reconciliation of CAPI objects across multiple replicas of the provider.
+//
+// Objects are assigned to a fixed number of shards by the hash of their namespace and logical cluster. Every replica
+// maintains a member Lease, shards are assigned round-robin to the live members and claimed with a shard Lease. A
+// replica only reconciles objects of the shards it holds. Shards are handed over when members join or leave.
+//
+// Objects of types cached with NewCache carry a shard label, the caches of a replica only contain such objects in its
+// shards. They are rebuilt when shards are handed over. The caches of all other types are not sharded, events of their
+// objects in foreign shards are dropped by Predicate.
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultLeaseDuration is the duration after which the Leases of a replica which stopped renewing them expire.
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewInterval is the interval in which a replica renews its Leases and rebalances the shards.
+	DefaultRenewInterval = 5 * time.Second
+
+	// eventBufferSize is the size of the buffer for requests of acquired shards which are not yet consumed.
+	eventBufferSize = 1024
+	// inClusterNamespacePath is the path of the namespace of the service account when running in a Pod.
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Options configure a Sharder.
+type Options struct {
+	// Shards is the total number of shards. It must be the same for all replicas.
+	Shards int
+	// Namespace is the namespace of the Leases.
+	Namespace string
+	// Name is the prefix of the Lease names.
+	Name string
+	// Identity identifies the replica, it must be unique.
+	Identity string
+	// LeaseDuration defaults to DefaultLeaseDuration.
+	LeaseDuration time.Duration
+	// RenewInterval defaults to DefaultRenewInterval.
+	RenewInterval time.Duration
+}
+
+// Sharder claims shards for a replica and decides which requests the replica reconciles. A nil Sharder owns all
+// shards, so reconcilers do not need to distinguish whether sharding is enabled.
+type Sharder struct {
+	client client.Client
+	clock  clock.WithTicker
+	opts   Options
+
+	lock sync.Mutex
+	ctx  context.Context
+	// owned maps the held shards to the time their Lease was last renewed.
+	owned map[int]time.Time
+	// known contains all requests seen by the controllers of a kind, to enqueue them when their shard is acquired.
+	known   map[string]map[mcreconcile.Request]struct{}
+	sources map[string][]chan event.TypedGenericEvent[mcreconcile.Request]
+	// caches are notified when shards are acquired or released, see NewCache.
+	caches []*shardedCache
+}
+
+// New creates a new Sharder which manages its Leases with the given client. It must be added to the manager to be
+// started.
+func New(c client.Client, opts Options) (*Sharder, error) {
+	if opts.Shards <= 0 {
+		return nil, errors.New("the number of shards must be positive")
+	}
+	if opts.Namespace == "" || opts.Name == "" || opts.Identity == "" {
+		return nil, errors.New("namespace, name and identity are required")
+	}
+	if opts.LeaseDuration == 0 {
+		opts.LeaseDuration = DefaultLeaseDuration
+	}
+	if opts.RenewInterval == 0 {
+		opts.RenewInterval = DefaultRenewInterval
+	}
+	if opts.RenewInterval >= opts.LeaseDuration {
+		return nil, errors.New("the renew interval must be shorter than the lease duration")
+	}
+
+	return &Sharder{
+		client:  c,
+		clock:   clock.RealClock{},
+		opts:    opts,
+		owned:   map[int]time.Time{},
+		known:   map[string]map[mcreconcile.Request]struct{}{},
+		sources: map[string][]chan event.TypedGenericEvent[mcreconcile.Request]{},
+	}, nil
+}
+
+// InClusterNamespace returns the namespace of the Pod the provider is running in.
+func InClusterNamespace() (string, error) {
+	namespace, err := os.ReadFile(inClusterNamespacePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the namespace of the Pod: %w", err)
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
+
+// Start implements manager.Runnable. It renews the Leases and rebalances the shards until the context is cancelled,
+// the Leases are released afterwards so that other replicas can take over immediately.
+func (s *Sharder) Start(ctx context.Context) error {
+	log := runtimelog.FromContext(ctx).WithName("sharder")
+
+	s.lock.Lock()
+	s.ctx = ctx
+	s.lock.Unlock()
+
+	ticker := s.clock.NewTicker(s.opts.RenewInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.sync(ctx); err != nil {
+			log.Error(err, "Failed to sync shards")
+		}
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), s.opts.RenewInterval)
+			defer cancel()
+			return s.release(releaseCtx)
+		case <-ticker.C():
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Sharding replaces leader election.
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+// ShardFor returns the shard of the objects in the given namespace and logical cluster.
+func (s *Sharder) ShardFor(clusterName multicluster.ClusterName, namespace string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(string(clusterName) + "/" + namespace))
+	return int(h.Sum32() % uint32(s.opts.Shards))
+}
+
+// Owns returns true if the replica holds the shard of the given namespace and logical cluster.
+func (s *Sharder) Owns(clusterName multicluster.ClusterName, namespace string) bool {
+	if s == nil {
+		return true
+	}
+	shard := s.ShardFor(clusterName, namespace)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ownsLocked(shard)
+}
+
+// ownsLocked returns true if the Lease of the shard was renewed recently enough that no other replica can have taken
+// it over. The caller must hold the lock.
+func (s *Sharder) ownsLocked(shard int) bool {
+	renewTime, ok := s.owned[shard]
+	return ok && s.clock.Since(renewTime) < s.opts.LeaseDuration-s.opts.RenewInterval
+}
+
+// Handles returns true if the request should be reconciled by this replica. The request is remembered under the given
+// name, so that it is enqueued in the Sources with the same name when the replica acquires its shard. Controllers
+// reconciling the same kind share the name, so requests seen by one of them are enqueued for all of them.
+func (s *Sharder) Handles(name string, req mcreconcile.Request) bool {
+	if s == nil {
+		return true
+	}
+	shard := s.ShardFor(req.ClusterName, req.Namespace)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.known[name] == nil {
+		s.known[name] = map[mcreconcile.Request]struct{}{}
+	}
+	s.known[name][req] = struct{}{}
+	return s.ownsLocked(shard)
+}
+
+// Forget removes a request which does not need to be reconciled anymore, e.g. because the object was deleted.
+func (s *Sharder) Forget(name string, req mcreconcile.Request) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.known[name], req)
+}
+
+// Source returns a source which emits the requests remembered under the given name whenever the replica acquires
+// their shard. Every controller must use its own Source.
+func (s *Sharder) Source(name string) source.TypedSource[mcreconcile.Request] {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	events := make(chan event.TypedGenericEvent[mcreconcile.Request], eventBufferSize)
+	s.sources[name] = append(s.sources[name], events)
+
+	return source.TypedChannel[mcreconcile.Request, mcreconcile.Request](
+		events,
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, req mcreconcile.Request) []mcreconcile.Request {
+			return []mcreconcile.Request{req}
+		}),
+	)
+}
+
+// Predicate returns a predicate which only admits objects for which the given function returns a logical cluster
+// and namespace in a shard of the replica. Objects in foreign shards are still cached unless their type is cached with
+// NewCache, and even then while their shard label is outdated.
+func (s *Sharder) Predicate(keyFunc func(client.Object) (multicluster.ClusterName, string, bool)) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		clusterName, namespace, ok := keyFunc(obj)
+		return ok && s.Owns(clusterName, namespace)
+	})
+}
+
+// sync renews the member Lease, releases the shards which are assigned to other members and acquires the shards
+// which are assigned to this replica.
+func (s *Sharder) sync(ctx context.Context) error {
+	if err := s.renewLease(ctx, s.memberLeaseName(s.opts.Identity), false); err != nil {
+		return fmt.Errorf("failed to renew member lease: %w", err)
+	}
+
+	members, err := s.liveMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for shard := range s.opts.Shards {
+		if members[shard%len(members)] == s.opts.Identity {
+			errs = append(errs, s.acquire(ctx, shard))
+		} else {
+			errs = append(errs, s.releaseShard(ctx, shard))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// liveMembers returns the sorted identities of all members whose Lease did not expire. The replica itself is always
+// a member.
+func (s *Sharder) liveMembers(ctx context.Context) ([]string, error) {
+	leases := &coordinationv1.LeaseList{}
+	if err := s.client.List(ctx, leases, client.InNamespace(s.opts.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list leases: %w", err)
+	}
+
+	members := []string{s.opts.Identity}
+	prefix := s.memberLeaseName("")
+	for _, lease := range leases.Items {
+		if !strings.HasPrefix(lease.Name, prefix) || s.expired(&lease) {
+			continue
+		}
+		if identity := ptr.Deref(lease.Spec.HolderIdentity, ""); identity != "" && !slices.Contains(members, identity) {
+			members = append(members, identity)
+		}
+	}
+	slices.Sort(members)
+	return members, nil
+}
+
+// acquire renews the Lease of the shard if it is free, expired or already held by the replica. Newly acquired shards
+// are cached and their requests are enqueued.
+func (s *Sharder) acquire(ctx context.Context, shard int) error {
+	renewTime := s.clock.Now()
+	if err := s.renewLease(ctx, s.shardLeaseName(shard), true); err != nil {
+		if errors.Is(err, errLeaseHeld) {
+			return nil
+		}
+		return fmt.Errorf("failed to acquire shard %d: %w", shard, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	acquired := !s.ownsLocked(shard)
+	s.owned[shard] = renewTime
+	if acquired {
+		runtimelog.FromContext(ctx).Info("Acquired shard", "shard", shard)
+		for _, c := range s.caches {
+			c.startShard(shard)
+		}
+		s.enqueueLocked(shard)
+	}
+	return nil
+}
+
+// releaseShard stops caching the shard and gives up its Lease, if it is held by the replica.
+func (s *Sharder) releaseShard(ctx context.Context, shard int) error {
+	s.lock.Lock()
+	_, held := s.owned[shard]
+	delete(s.owned, shard)
+	if held {
+		for _, c := range s.caches {
+			c.stopShard(shard)
+		}
+	}
+	s.lock.Unlock()
+	if !held {
+		return nil
+	}
+
+	runtimelog.FromContext(ctx).Info("Releasing shard", "shard", shard)
+	return s.deleteLease(ctx, s.shardLeaseName(shard))
+}
+
+// release gives up all Leases of the replica.
+func (s *Sharder) release(ctx context.Context) error {
+	var errs []error
+	for shard := range s.opts.Shards {
+		errs = append(errs, s.releaseShard(ctx, shard))
+	}
+	errs = append(errs, s.deleteLease(ctx, s.memberLeaseName(s.opts.Identity)))
+	return errors.Join(errs...)
+}
+
+var errLeaseHeld = errors.New("lease is held by another member")
+
+// renewLease creates or renews the Lease with the given name for the replica. If takeOver is set, Leases of other
+// members are only taken over once they expired, otherwise errLeaseHeld is returned.
+func (s *Sharder) renewLease(ctx context.Context, name string, takeOver bool) error {
+	now := metav1.NewMicroTime(s.clock.Now())
+	lease := &coordinationv1.Lease{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.opts.Namespace, Name: name}, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.opts.Namespace, Name: name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(s.opts.Identity),
+				LeaseDurationSeconds: ptr.To(int32(s.opts.LeaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		return s.client.Create(ctx, lease)
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder != s.opts.Identity {
+		if takeOver && holder != "" && !s.expired(lease) {
+			return errLeaseHeld
+		}
+		lease.Spec.HolderIdentity = ptr.To(s.opts.Identity)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(s.opts.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	// The update fails with a conflict if another member changed the Lease concurrently.
+	return s.client.Update(ctx, lease)
+}
+
+func (s *Sharder) deleteLease(ctx context.Context, name string) error {
+	lease := &coordinationv1.Lease{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.opts.Namespace, Name: name}, lease); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != s.opts.Identity {
+		return nil
+	}
+	return client.IgnoreNotFound(s.client.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion}))
+}
+
+func (s *Sharder) expired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return s.clock.Since(lease.Spec.RenewTime.Time) > time.Duration(*lease.Spec.LeaseDurationSeconds)*time.Second
+}
+
+// enqueueLocked emits the known requests of the shard to the sources of the controllers. The caller must hold the lock.
+func (s *Sharder) enqueueLocked(shard int) {
+	if s.ctx == nil {
+		return
+	}
+	for name, requests := range s.known {
+		var pending []mcreconcile.Request
+		for req := range requests {
+			if s.ShardFor(req.ClusterName, req.Namespace) == shard {
+				pending = append(pending, req)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		for _, events := range s.sources[name] {
+			// Do not block renewing the Leases while the controllers are busy.
+			go func(ctx context.Context, events chan<- event.TypedGenericEvent[mcreconcile.Request]) {
+				for _, req := range pending {
+					select {
+					case events <- event.TypedGenericEvent[mcreconcile.Request]{Object: req}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(s.ctx, events)
+		}
+	}
+}
+
+func (s *Sharder) memberLeaseName(identity string) string {
+	return s.opts.Name + "-member-" + identity
+}
+
+func (s *Sharder) shardLeaseName(shard int) string {
+	return s.opts.Name + "-shard-" + strconv.Itoa(shard)
+}
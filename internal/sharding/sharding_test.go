@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Sharder", func() {
+	const shards = 8
+
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		fakeClock  *testclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		fakeClock = testclock.NewFakeClock(time.Now())
+	})
+
+	newSharder := func(identity string) *Sharder {
+		s, err := New(fakeClient, Options{Shards: shards, Namespace: "default", Name: "capga", Identity: identity})
+		Expect(err).NotTo(HaveOccurred())
+		s.clock = fakeClock
+		s.ctx = ctx
+		return s
+	}
+
+	ownedShards := func(s *Sharder) []int {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		var owned []int
+		for shard := range shards {
+			if s.ownsLocked(shard) {
+				owned = append(owned, shard)
+			}
+		}
+		return owned
+	}
+
+	// syncAll runs two rounds of syncs, as shards are only acquired after the previous holder released them.
+	syncAll := func(sharders ...*Sharder) {
+		for range 2 {
+			for _, s := range sharders {
+				Expect(s.sync(ctx)).To(Succeed())
+			}
+		}
+	}
+
+	It("should reject invalid options", func() {
+		_, err := New(fakeClient, Options{Namespace: "default", Name: "capga", Identity: "a"})
+		Expect(err).To(HaveOccurred())
+		_, err = New(fakeClient, Options{Shards: shards, Name: "capga", Identity: "a"})
+		Expect(err).To(HaveOccurred())
+		_, err = New(fakeClient, Options{Shards: shards, Namespace: "default", Name: "capga", Identity: "a", RenewInterval: time.Minute})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should own everything if it is nil", func() {
+		var s *Sharder
+		Expect(s.Owns("", "default")).To(BeTrue())
+		Expect(s.Handles("foo", mcreconcile.Request{})).To(BeTrue())
+		s.Forget("foo", mcreconcile.Request{})
+	})
+
+	It("should assign the same shard to the same namespace and logical cluster", func() {
+		s := newSharder("a")
+		Expect(s.ShardFor("2x6vp4r8", "default")).To(Equal(s.ShardFor("2x6vp4r8", "default")))
+		for i := range 100 {
+			Expect(s.ShardFor(multicluster.ClusterName(fmt.Sprintf("cluster-%d", i)), "default")).To(BeNumerically("<", shards))
+		}
+	})
+
+	It("should acquire all shards as the only replica", func() {
+		s := newSharder("a")
+		syncAll(s)
+		Expect(ownedShards(s)).To(HaveLen(shards))
+	})
+
+	It("should split the shards between replicas and rebalance them", func() {
+		a, b := newSharder("a"), newSharder("b")
+		syncAll(a)
+		Expect(ownedShards(a)).To(HaveLen(shards))
+
+		By("Adding a replica")
+		syncAll(b, a, b)
+		Expect(ownedShards(a)).To(Equal([]int{0, 2, 4, 6}))
+		Expect(ownedShards(b)).To(Equal([]int{1, 3, 5, 7}))
+
+		By("Removing a replica")
+		Expect(a.release(ctx)).To(Succeed())
+		syncAll(b)
+		Expect(ownedShards(b)).To(HaveLen(shards))
+	})
+
+	It("should take over the shards of a replica which stopped renewing its leases", func() {
+		a, b := newSharder("a"), newSharder("b")
+		syncAll(a, b, a, b)
+		Expect(ownedShards(b)).To(HaveLen(shards / 2))
+
+		fakeClock.Step(DefaultLeaseDuration + time.Second)
+		Expect(ownedShards(a)).To(BeEmpty())
+		syncAll(b)
+		Expect(ownedShards(b)).To(HaveLen(shards))
+	})
+
+	It("should stop owning shards whose lease was not renewed in time", func() {
+		s := newSharder("a")
+		syncAll(s)
+
+		fakeClock.Step(DefaultLeaseDuration - DefaultRenewInterval)
+		Expect(ownedShards(s)).To(BeEmpty())
+	})
+
+	It("should enqueue known requests when acquiring their shard", func() {
+		s := newSharder("a")
+		src := s.Source("foo")
+		Expect(src).NotTo(BeNil())
+		req := mcreconcile.Request{Request: reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "bar"}}}
+		forgotten := mcreconcile.Request{Request: reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "baz"}}}
+
+		Expect(s.Handles("foo", req)).To(BeFalse())
+		Expect(s.Handles("foo", forgotten)).To(BeFalse())
+		s.Forget("foo", forgotten)
+		syncAll(s)
+
+		Expect(s.Handles("foo", req)).To(BeTrue())
+		events := s.sources["foo"][0]
+		Eventually(events).Should(Receive(HaveField("Object", req)))
+		Consistently(events).ShouldNot(Receive())
+	})
+})
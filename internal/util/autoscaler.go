@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// AutoscalerBounds are the minimum and maximum size of a MachinePool as chosen by the cluster-autoscaler.
+type AutoscalerBounds struct {
+	Minimum int32
+	Maximum int32
+}
+
+// AutoscalerBoundsFromMachinePool returns the bounds of the given MachinePool, which are expressed by the node group size
+// annotations of the Cluster API cluster-autoscaler provider. Returns nil if the MachinePool has none of the annotations.
+// The bounds are validated against the spec of the GardenerWorkerPool; the returned errors refer to the fields of the
+// spec at fldPath which are controlled by the annotations.
+func AutoscalerBoundsFromMachinePool(machinePool *clusterv1beta2.MachinePool, spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, fldPath *field.Path) (*AutoscalerBounds, field.ErrorList) {
+	if machinePool == nil {
+		return nil, nil
+	}
+	minSize, hasMin := machinePool.Annotations[clusterv1beta2.AutoscalerMinSizeAnnotation]
+	maxSize, hasMax := machinePool.Annotations[clusterv1beta2.AutoscalerMaxSizeAnnotation]
+	if !hasMin && !hasMax {
+		return nil, nil
+	}
+
+	var (
+		allErrs field.ErrorList
+		bounds  = &AutoscalerBounds{}
+	)
+	parse := func(annotation, value string, hasValue bool, otherAnnotation string, out *int32, fldPath *field.Path) {
+		if !hasValue {
+			allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("annotation %s of MachinePool %q is required if %s is set", annotation, machinePool.Name, otherAnnotation)))
+			return
+		}
+		size, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || size < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("annotation %s of MachinePool %q must be a non-negative integer", annotation, machinePool.Name)))
+			return
+		}
+		*out = int32(size) // #nosec G115 -- ParseInt ensures that the size fits into 32 bits
+	}
+	parse(clusterv1beta2.AutoscalerMinSizeAnnotation, minSize, hasMin, clusterv1beta2.AutoscalerMaxSizeAnnotation, &bounds.Minimum, fldPath.Child("minimum"))
+	parse(clusterv1beta2.AutoscalerMaxSizeAnnotation, maxSize, hasMax, clusterv1beta2.AutoscalerMinSizeAnnotation, &bounds.Maximum, fldPath.Child("maximum"))
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+
+	if bounds.Maximum < bounds.Minimum {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maximum"), bounds.Maximum, fmt.Sprintf("annotation %s of MachinePool %q must be greater than or equal to %s", clusterv1beta2.AutoscalerMaxSizeAnnotation, machinePool.Name, clusterv1beta2.AutoscalerMinSizeAnnotation)))
+	}
+	// The cluster-autoscaler does not consider a worker pool whose minimum equals its maximum, hence its options would
+	// silently have no effect.
+	if bounds.Maximum == bounds.Minimum && spec.ClusterAutoscaler != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("clusterAutoscaler"), fmt.Sprintf("must not be set if the worker pool is not autoscaled, i.e. if the annotations %s and %s of MachinePool %q are equal", clusterv1beta2.AutoscalerMinSizeAnnotation, clusterv1beta2.AutoscalerMaxSizeAnnotation, machinePool.Name)))
+	}
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+	return bounds, nil
+}
+
+// Apply sets the minimum and maximum of the given GardenerWorkerPool spec to the bounds and returns whether the spec
+// changed. The spec is not changed if the bounds are nil.
+func (b *AutoscalerBounds) Apply(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec) bool {
+	if b == nil || (spec.Minimum == b.Minimum && spec.Maximum == b.Maximum) {
+		return false
+	}
+	spec.Minimum = b.Minimum
+	spec.Maximum = b.Maximum
+	return true
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("AutoscalerBounds", func() {
+	var (
+		machinePool *clusterv1beta2.MachinePool
+		spec        *infrastructurev1alpha2.GardenerWorkerPoolSpec
+		specPath    = field.NewPath("spec")
+	)
+
+	BeforeEach(func() {
+		machinePool = &clusterv1beta2.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+		spec = &infrastructurev1alpha2.GardenerWorkerPoolSpec{Minimum: 1, Maximum: 3}
+	})
+
+	setBounds := func(minSize, maxSize string) {
+		machinePool.Annotations = map[string]string{
+			clusterv1beta2.AutoscalerMinSizeAnnotation: minSize,
+			clusterv1beta2.AutoscalerMaxSizeAnnotation: maxSize,
+		}
+	}
+
+	matchError := func(errorType field.ErrorType, fieldPath string) types.GomegaMatcher {
+		return PointTo(MatchFields(IgnoreExtras, Fields{
+			"Type":  Equal(errorType),
+			"Field": Equal(fieldPath),
+		}))
+	}
+
+	Describe("#AutoscalerBoundsFromMachinePool", func() {
+		It("should return nil without annotations", func() {
+			bounds, errs := AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)
+			Expect(errs).To(BeEmpty())
+			Expect(bounds).To(BeNil())
+
+			bounds, errs = AutoscalerBoundsFromMachinePool(nil, spec, specPath)
+			Expect(errs).To(BeEmpty())
+			Expect(bounds).To(BeNil())
+		})
+
+		It("should return the bounds of the annotations", func() {
+			setBounds("2", "5")
+			Expect(AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)).To(Equal(&AutoscalerBounds{Minimum: 2, Maximum: 5}))
+		})
+
+		It("should require both annotations", func() {
+			machinePool.Annotations = map[string]string{clusterv1beta2.AutoscalerMinSizeAnnotation: "2"}
+			_, errs := AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)
+			Expect(errs).To(ConsistOf(matchError(field.ErrorTypeRequired, "spec.maximum")))
+		})
+
+		It("should forbid invalid sizes", func() {
+			setBounds("-1", "foo")
+			_, errs := AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)
+			Expect(errs).To(ConsistOf(
+				matchError(field.ErrorTypeInvalid, "spec.minimum"),
+				matchError(field.ErrorTypeInvalid, "spec.maximum"),
+			))
+		})
+
+		It("should forbid a maximum lower than the minimum", func() {
+			setBounds("3", "2")
+			_, errs := AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)
+			Expect(errs).To(ConsistOf(matchError(field.ErrorTypeInvalid, "spec.maximum")))
+		})
+
+		It("should forbid cluster autoscaler options if the worker pool is not autoscaled", func() {
+			setBounds("2", "2")
+			Expect(AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)).To(Equal(&AutoscalerBounds{Minimum: 2, Maximum: 2}))
+
+			spec.ClusterAutoscaler = &gardenercorev1beta1.ClusterAutoscalerOptions{}
+			_, errs := AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)
+			Expect(errs).To(ConsistOf(matchError(field.ErrorTypeForbidden, "spec.clusterAutoscaler")))
+
+			setBounds("2", "4")
+			Expect(AutoscalerBoundsFromMachinePool(machinePool, spec, specPath)).To(Equal(&AutoscalerBounds{Minimum: 2, Maximum: 4}))
+		})
+	})
+
+	Describe("#Apply", func() {
+		It("should set the minimum and maximum", func() {
+			bounds := &AutoscalerBounds{Minimum: 2, Maximum: 5}
+			Expect(bounds.Apply(spec)).To(BeTrue())
+			Expect(spec.Minimum).To(Equal(int32(2)))
+			Expect(spec.Maximum).To(Equal(int32(5)))
+
+			Expect(bounds.Apply(spec)).To(BeFalse())
+		})
+
+		It("should not change the spec without bounds", func() {
+			var bounds *AutoscalerBounds
+			Expect(bounds.Apply(spec)).To(BeFalse())
+			Expect(spec.Minimum).To(Equal(int32(1)))
+			Expect(spec.Maximum).To(Equal(int32(3)))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CAPICoreProviderName is the value of the provider label on the components of the Cluster API core provider.
+const CAPICoreProviderName = "cluster-api"
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=list
+
+// HasCAPICoreControllers checks if the controllers of the Cluster API core provider are deployed in a given cluster,
+// i.e. if there is a Deployment labeled as part of the core provider, as done by `clusterctl init`.
+func HasCAPICoreControllers(ctx context.Context, cfg *rest.Config) (bool, error) {
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	deployments := &metav1.PartialObjectMetadataList{}
+	deployments.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DeploymentList"))
+	if err := c.List(ctx, deployments, client.MatchingLabels{clusterv1beta2.ProviderNameLabel: CAPICoreProviderName}, client.Limit(1)); err != nil {
+		return false, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return len(deployments.Items) > 0, nil
+}
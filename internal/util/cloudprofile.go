@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+// ProjectNamespace returns the namespace of the Gardener project in which the Shoot of the given
+// GardenerShootControlPlane is placed. It falls back to the namespace of the object, like the defaulting webhook does.
+func ProjectNamespace(controlPlane *controlplanev1alpha2.GardenerShootControlPlane) string {
+	if len(controlPlane.Spec.ProjectNamespace) > 0 {
+		return controlPlane.Spec.ProjectNamespace
+	}
+	return controlPlane.Namespace
+}
+
+// CloudProfileForControlPlane returns the CloudProfile referenced by the given GardenerShootControlPlane.
+// If a NamespacedCloudProfile is referenced, a CloudProfile carrying its computed spec is returned.
+// Returns nil if the GardenerShootControlPlane does not reference any CloudProfile.
+func CloudProfileForControlPlane(ctx context.Context, gardenerClient client.Reader, controlPlane *controlplanev1alpha2.GardenerShootControlPlane) (*gardenercorev1beta1.CloudProfile, error) {
+	shoot := &gardenercorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ProjectNamespace(controlPlane),
+		},
+		Spec: gardenercorev1beta1.ShootSpec{
+			CloudProfile: controlPlane.Spec.CloudProfile,
+		},
+	}
+	if gardenerutils.BuildV1beta1CloudProfileReference(shoot) == nil {
+		return nil, nil
+	}
+	return gardenerutils.GetCloudProfile(ctx, gardenerClient, shoot)
+}
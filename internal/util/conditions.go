@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// SetPausedCondition sets the Paused condition, which is required by the Cluster API v1beta2 contract, and returns
+// whether the conditions changed.
+func SetPausedCondition(conditions *[]metav1.Condition, generation int64, paused bool) bool {
+	condition := metav1.Condition{
+		Type:               clusterv1beta2.PausedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1beta2.NotPausedReason,
+		ObservedGeneration: generation,
+	}
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = clusterv1beta2.PausedReason
+	}
+	return meta.SetStatusCondition(conditions, condition)
+}
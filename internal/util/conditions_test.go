@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("#SetPausedCondition", func() {
+	It("should set the Paused condition and report changes", func() {
+		var conditions []metav1.Condition
+
+		Expect(SetPausedCondition(&conditions, 1, false)).To(BeTrue())
+		Expect(conditions).To(ConsistOf(And(
+			HaveField("Type", "Paused"),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", "NotPaused"),
+			HaveField("ObservedGeneration", int64(1)),
+		)))
+		Expect(SetPausedCondition(&conditions, 1, false)).To(BeFalse())
+
+		Expect(SetPausedCondition(&conditions, 2, true)).To(BeTrue())
+		Expect(conditions).To(ConsistOf(And(
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("Reason", "Paused"),
+			HaveField("ObservedGeneration", int64(2)),
+		)))
+	})
+})
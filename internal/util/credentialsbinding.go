@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EquivalentCredentialsBinding returns the CredentialsBinding of the given list which references the same Secret as the
+// given SecretBinding for the given provider type. A CredentialsBinding named like the SecretBinding is preferred.
+// Returns nil if there is no equivalent CredentialsBinding.
+func EquivalentCredentialsBinding(secretBinding *gardenercorev1beta1.SecretBinding, providerType string, credentialsBindings []securityv1alpha1.CredentialsBinding) *securityv1alpha1.CredentialsBinding {
+	var equivalent *securityv1alpha1.CredentialsBinding
+	for i := range credentialsBindings {
+		credentialsBinding := &credentialsBindings[i]
+		if !IsEquivalentCredentialsBinding(secretBinding, providerType, credentialsBinding) {
+			continue
+		}
+		if credentialsBinding.Name == secretBinding.Name {
+			return credentialsBinding
+		}
+		if equivalent == nil {
+			equivalent = credentialsBinding
+		}
+	}
+	return equivalent
+}
+
+// IsEquivalentCredentialsBinding returns true if the given CredentialsBinding references the same Secret as the given
+// SecretBinding for the given provider type.
+func IsEquivalentCredentialsBinding(secretBinding *gardenercorev1beta1.SecretBinding, providerType string, credentialsBinding *securityv1alpha1.CredentialsBinding) bool {
+	ref := credentialsBinding.CredentialsRef
+	return credentialsBinding.Namespace == secretBinding.Namespace &&
+		credentialsBinding.Provider.Type == providerType &&
+		ref.APIVersion == corev1.SchemeGroupVersion.String() &&
+		ref.Kind == "Secret" &&
+		ref.Name == secretBinding.SecretRef.Name &&
+		ref.Namespace == secretNamespace(secretBinding)
+}
+
+// CredentialsBindingFromSecretBinding returns a CredentialsBinding for the given provider type which is named like the
+// given SecretBinding and references the same Secret and Quotas.
+func CredentialsBindingFromSecretBinding(secretBinding *gardenercorev1beta1.SecretBinding, providerType string) *securityv1alpha1.CredentialsBinding {
+	return &securityv1alpha1.CredentialsBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretBinding.Name,
+			Namespace: secretBinding.Namespace,
+		},
+		Provider: securityv1alpha1.CredentialsBindingProvider{Type: providerType},
+		CredentialsRef: corev1.ObjectReference{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+			Name:       secretBinding.SecretRef.Name,
+			Namespace:  secretNamespace(secretBinding),
+		},
+		Quotas: secretBinding.Quotas,
+	}
+}
+
+// secretNamespace returns the namespace of the Secret referenced by the given SecretBinding, which defaults to the
+// namespace of the SecretBinding.
+func secretNamespace(secretBinding *gardenercorev1beta1.SecretBinding) string {
+	if secretBinding.SecretRef.Namespace != "" {
+		return secretBinding.SecretRef.Namespace
+	}
+	return secretBinding.Namespace
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("CredentialsBinding", func() {
+	var secretBinding *gardenercorev1beta1.SecretBinding
+
+	BeforeEach(func() {
+		secretBinding = &gardenercorev1beta1.SecretBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "garden-dev"},
+			SecretRef:  corev1.SecretReference{Name: "aws-secret"},
+			Quotas:     []corev1.ObjectReference{{Name: "trial", Namespace: "garden"}},
+			Provider:   &gardenercorev1beta1.SecretBindingProvider{Type: "aws"},
+		}
+	})
+
+	Describe("#CredentialsBindingFromSecretBinding", func() {
+		It("should reference the same Secret and Quotas", func() {
+			Expect(CredentialsBindingFromSecretBinding(secretBinding, "aws")).To(Equal(&securityv1alpha1.CredentialsBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "garden-dev"},
+				Provider:   securityv1alpha1.CredentialsBindingProvider{Type: "aws"},
+				CredentialsRef: corev1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Name:       "aws-secret",
+					Namespace:  "garden-dev",
+				},
+				Quotas: []corev1.ObjectReference{{Name: "trial", Namespace: "garden"}},
+			}))
+		})
+	})
+
+	Describe("#EquivalentCredentialsBinding", func() {
+		var credentialsBinding func(name, secretName, providerType string) securityv1alpha1.CredentialsBinding
+
+		BeforeEach(func() {
+			credentialsBinding = func(name, secretName, providerType string) securityv1alpha1.CredentialsBinding {
+				binding := CredentialsBindingFromSecretBinding(secretBinding, providerType)
+				binding.Name = name
+				binding.CredentialsRef.Name = secretName
+				return *binding
+			}
+		})
+
+		It("should return nil if no CredentialsBinding references the Secret", func() {
+			Expect(EquivalentCredentialsBinding(secretBinding, "aws", []securityv1alpha1.CredentialsBinding{
+				credentialsBinding("aws", "other-secret", "aws"),
+				credentialsBinding("gcp", "aws-secret", "gcp"),
+			})).To(BeNil())
+		})
+
+		It("should return a CredentialsBinding referencing the same Secret", func() {
+			Expect(EquivalentCredentialsBinding(secretBinding, "aws", []securityv1alpha1.CredentialsBinding{
+				credentialsBinding("other", "other-secret", "aws"),
+				credentialsBinding("migrated", "aws-secret", "aws"),
+			})).To(HaveField("Name", "migrated"))
+		})
+
+		It("should prefer the CredentialsBinding named like the SecretBinding", func() {
+			Expect(EquivalentCredentialsBinding(secretBinding, "aws", []securityv1alpha1.CredentialsBinding{
+				credentialsBinding("migrated", "aws-secret", "aws"),
+				credentialsBinding("aws", "aws-secret", "aws"),
+			})).To(HaveField("Name", "aws"))
+		})
+
+		It("should not return CredentialsBindings referencing WorkloadIdentities", func() {
+			binding := credentialsBinding("aws", "aws-secret", "aws")
+			binding.CredentialsRef.APIVersion = "security.gardener.cloud/v1alpha1"
+			binding.CredentialsRef.Kind = "WorkloadIdentity"
+			Expect(EquivalentCredentialsBinding(secretBinding, "aws", []securityv1alpha1.CredentialsBinding{binding})).To(BeNil())
+		})
+	})
+})
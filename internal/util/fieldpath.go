@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+const (
+	// KindGardenerShootControlPlane is the kind of the GardenerShootControlPlane.
+	KindGardenerShootControlPlane = "GardenerShootControlPlane"
+	// KindGardenerShootCluster is the kind of the GardenerShootCluster.
+	KindGardenerShootCluster = "GardenerShootCluster"
+	// KindGardenerWorkerPool is the kind of the GardenerWorkerPool.
+	KindGardenerWorkerPool = "GardenerWorkerPool"
+	// KindGardenerMachine is the kind of the GardenerMachine.
+	KindGardenerMachine = "GardenerMachine"
+)
+
+// ShootFieldTarget describes the CAPI object and the field in which a field of the Shoot is configured.
+type ShootFieldTarget struct {
+	// Kind is the kind of the CAPI object.
+	Kind string
+	// WorkerPoolName is the name of the GardenerWorkerPool, it is only set if Kind is GardenerWorkerPool.
+	WorkerPoolName string
+	// Path is the field path in the CAPI object.
+	Path string
+}
+
+var (
+	fieldErrorTypes = []field.ErrorType{
+		field.ErrorTypeNotFound,
+		field.ErrorTypeRequired,
+		field.ErrorTypeDuplicate,
+		field.ErrorTypeInvalid,
+		field.ErrorTypeNotSupported,
+		field.ErrorTypeForbidden,
+		field.ErrorTypeTooLong,
+		field.ErrorTypeTooMany,
+		field.ErrorTypeInternal,
+		field.ErrorTypeTypeInvalid,
+		field.ErrorTypeTooShort,
+		field.ErrorTypeTooFew,
+	}
+
+	shootWorkerFieldRegex = regexp.MustCompile(`^spec\.provider\.workers\[(\d+)\](?:\.(.*))?$`)
+)
+
+// ShootFieldPathToTarget returns the CAPI object and field in which the field with the given path of the given Shoot is
+// configured. Returns nil if the field is not configured in any CAPI object, e.g. because it is part of the metadata.
+func ShootFieldPathToTarget(shoot *gardenercorev1beta1.Shoot, shootPath string) *ShootFieldTarget {
+	if match := shootWorkerFieldRegex.FindStringSubmatch(shootPath); match != nil {
+		index, err := strconv.Atoi(match[1])
+		if err != nil || index >= len(shoot.Spec.Provider.Workers) {
+			return nil
+		}
+
+		target := &ShootFieldTarget{
+			Kind:           KindGardenerWorkerPool,
+			WorkerPoolName: shoot.Spec.Provider.Workers[index].Name,
+			Path:           "spec",
+		}
+		if match[2] == "name" {
+			target.Path = "metadata.name"
+		} else if mapping, path := targetForJSONPath(WorkerFieldMappings, match[2]); mapping != nil {
+			target.Path += "." + path
+		}
+		return target
+	}
+
+	if specPath, ok := strings.CutPrefix(shootPath, "spec."); ok {
+		if mapping, path := targetForJSONPath(ShootSpecFieldMappings, specPath); mapping != nil {
+			return &ShootFieldTarget{Kind: mapping.Kind, Path: "spec." + path}
+		}
+	}
+
+	return nil
+}
+
+// TranslateShootStatusError rewrites the causes of a StatusError returned by Gardener for the given Shoot into field
+// errors against the given GardenerShootControlPlane, GardenerShootCluster or GardenerWorkerPool.
+// Causes for fields which are configured in other objects keep the path of the Shoot field.
+// Errors without causes are returned unchanged.
+func TranslateShootStatusError(err error, shoot *gardenercorev1beta1.Shoot, obj client.Object) error {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil || len(statusErr.ErrStatus.Details.Causes) == 0 {
+		return err
+	}
+
+	var groupKind schema.GroupKind
+	switch obj.(type) {
+	case *controlplanev1alpha2.GardenerShootControlPlane:
+		groupKind = controlplanev1alpha2.GroupVersion.WithKind(KindGardenerShootControlPlane).GroupKind()
+	case *infrastructurev1alpha2.GardenerShootCluster:
+		groupKind = infrastructurev1alpha2.SchemeGroupVersion.WithKind(KindGardenerShootCluster).GroupKind()
+	case *infrastructurev1alpha2.GardenerWorkerPool:
+		groupKind = infrastructurev1alpha2.SchemeGroupVersion.WithKind(KindGardenerWorkerPool).GroupKind()
+	default:
+		return err
+	}
+
+	allErrs := field.ErrorList{}
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		errorType := field.ErrorType(cause.Type)
+		detail := strings.TrimPrefix(strings.TrimPrefix(cause.Message, errorType.String()), ": ")
+		if !slices.Contains(fieldErrorTypes, errorType) {
+			errorType, detail = field.ErrorTypeInvalid, cause.Message
+		}
+
+		path := cause.Field
+		target := ShootFieldPathToTarget(shoot, cause.Field)
+		switch {
+		case target != nil && target.Kind == groupKind.Kind && (target.Kind != KindGardenerWorkerPool || target.WorkerPoolName == obj.GetName()):
+			path = target.Path
+		case target != nil && target.Kind == KindGardenerWorkerPool:
+			detail = fmt.Sprintf("%s (field %s of %s %q)", detail, target.Path, target.Kind, target.WorkerPoolName)
+		case target != nil:
+			detail = fmt.Sprintf("%s (field %s of the %s)", detail, target.Path, target.Kind)
+		default:
+			detail = fmt.Sprintf("%s (field of Shoot %s)", detail, client.ObjectKeyFromObject(shoot))
+		}
+
+		allErrs = append(allErrs, &field.Error{
+			Type:     errorType,
+			Field:    path,
+			BadValue: field.OmitValueType{},
+			Detail:   strings.TrimSpace(detail),
+		})
+	}
+
+	return apierrors.NewInvalid(groupKind, obj.GetName(), allErrs)
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Field paths", func() {
+	var shoot *gardenercorev1beta1.Shoot
+
+	BeforeEach(func() {
+		shoot = &gardenercorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-bar"},
+			Spec: gardenercorev1beta1.ShootSpec{
+				Provider: gardenercorev1beta1.Provider{
+					Workers: []gardenercorev1beta1.Worker{{Name: "pool-a"}, {Name: "pool-b"}},
+				},
+			},
+		}
+	})
+
+	Describe("#ShootFieldPathToTarget", func() {
+		It("should map worker fields to the GardenerWorkerPool", func() {
+			Expect(ShootFieldPathToTarget(shoot, "spec.provider.workers[1].machine.type")).To(Equal(&ShootFieldTarget{
+				Kind:           KindGardenerWorkerPool,
+				WorkerPoolName: "pool-b",
+				Path:           "spec.machine.type",
+			}))
+			Expect(ShootFieldPathToTarget(shoot, "spec.provider.workers[0].name")).To(Equal(&ShootFieldTarget{
+				Kind:           KindGardenerWorkerPool,
+				WorkerPoolName: "pool-a",
+				Path:           "metadata.name",
+			}))
+		})
+
+		It("should map control plane and infrastructure cluster fields", func() {
+			Expect(ShootFieldPathToTarget(shoot, "spec.kubernetes.version")).To(Equal(&ShootFieldTarget{Kind: KindGardenerShootControlPlane, Path: "spec.kubernetes.version"}))
+			Expect(ShootFieldPathToTarget(shoot, "spec.provider.type")).To(Equal(&ShootFieldTarget{Kind: KindGardenerShootControlPlane, Path: "spec.provider.type"}))
+			Expect(ShootFieldPathToTarget(shoot, "spec.tolerations[0].key")).To(Equal(&ShootFieldTarget{Kind: KindGardenerShootControlPlane, Path: "spec.tolerations[0].key"}))
+			Expect(ShootFieldPathToTarget(shoot, "spec.region")).To(Equal(&ShootFieldTarget{Kind: KindGardenerShootCluster, Path: "spec.region"}))
+		})
+
+		It("should not map unknown fields or workers", func() {
+			Expect(ShootFieldPathToTarget(shoot, "metadata.name")).To(BeNil())
+			Expect(ShootFieldPathToTarget(shoot, "spec.provider.workers[2].machine.type")).To(BeNil())
+		})
+	})
+
+	Describe("#TranslateShootStatusError", func() {
+		var shootErr error
+
+		BeforeEach(func() {
+			shootErr = apierrors.NewInvalid(schema.GroupKind{Group: "core.gardener.cloud", Kind: "Shoot"}, "foo", field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "provider", "workers").Index(1).Child("machine", "type"), "m5.foo", []string{"m5.large"}),
+				field.Required(field.NewPath("spec", "kubernetes", "version"), ""),
+				field.Forbidden(field.NewPath("metadata", "annotations"), "not allowed"),
+			})
+		})
+
+		It("should rewrite the causes against the GardenerWorkerPool", func() {
+			workerPool := &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "pool-b"}}
+
+			err := TranslateShootStatusError(shootErr, shoot, workerPool)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.(*apierrors.StatusError).ErrStatus.Details.Kind).To(Equal("GardenerWorkerPool"))
+			Expect(err.(*apierrors.StatusError).ErrStatus.Details.Causes).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Field":   Equal("spec.machine.type"),
+					"Message": Equal(`Unsupported value: "m5.foo": supported values: "m5.large"`),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Field":   Equal("spec.kubernetes.version"),
+					"Message": Equal("Required value: (field spec.kubernetes.version of the GardenerShootControlPlane)"),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Field":   Equal("metadata.annotations"),
+					"Message": Equal("Forbidden: not allowed (field of Shoot garden-bar/foo)"),
+				}),
+			))
+		})
+
+		It("should rewrite the causes against the GardenerShootControlPlane", func() {
+			controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+			err := TranslateShootStatusError(shootErr, shoot, controlPlane)
+			Expect(err.(*apierrors.StatusError).ErrStatus.Details.Causes).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Field":   Equal("spec.provider.workers[1].machine.type"),
+					"Message": ContainSubstring(`(field spec.machine.type of GardenerWorkerPool "pool-b")`),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Field":   Equal("spec.kubernetes.version"),
+					"Message": Equal("Required value"),
+				}),
+			))
+		})
+
+		It("should return errors without causes unchanged", func() {
+			notFoundErr := apierrors.NewNotFound(schema.GroupResource{Group: "core.gardener.cloud", Resource: "shoots"}, "foo")
+			Expect(TranslateShootStatusError(notFoundErr, shoot, &infrastructurev1alpha2.GardenerShootCluster{})).To(BeIdenticalTo(notFoundErr))
+			Expect(TranslateShootStatusError(nil, shoot, &infrastructurev1alpha2.GardenerShootCluster{})).To(Succeed())
+		})
+	})
+})
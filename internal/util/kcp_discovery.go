@@ -19,44 +19,13 @@ Modifications Copyright SAP SE or an SAP affiliate company and Gardener contribu
 package util
 
 import (
-	"context"
 	"fmt"
 
 	apisv1alpha1 "github.com/kcp-dev/sdk/apis/apis/v1alpha1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api"
 )
 
-// RestConfigForLogicalClusterHostingAPIExport returns a *rest.Config properly configured
-// to communicate with the endpoint for the APIExport's virtual workspace.
-func RestConfigForLogicalClusterHostingAPIExport(
-	ctx context.Context, cfg *rest.Config, apiExportName string) (*rest.Config, error) {
-	apiExportClient, err := client.New(cfg, client.Options{
-		Scheme: controlplanev1alpha1.Scheme,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error creating APIExport client: %w", err)
-	}
-
-	apiExportEndpointSlice := &apisv1alpha1.APIExportEndpointSlice{}
-	if err := apiExportClient.Get(ctx, types.NamespacedName{Name: apiExportName}, apiExportEndpointSlice); err != nil {
-		return nil, fmt.Errorf("error getting APIExport %q: %w", apiExportName, err)
-	}
-	if len(apiExportEndpointSlice.Status.APIExportEndpoints) < 1 { // nolint:staticcheck
-		return nil, fmt.Errorf("APIExportEndpointSlice %q status.endpoints is empty", apiExportName)
-	}
-
-	// create a new rest.Config with the APIExport's virtual workspace URL
-	exportConfig := rest.CopyConfig(cfg)
-	exportConfig.Host = apiExportEndpointSlice.Status.APIExportEndpoints[0].URL // nolint:staticcheck
-
-	return exportConfig, nil
-}
-
 // HasKcpAPIGroups checks if the KCP API groups are available in a given cluster.
 func HasKcpAPIGroups(cfg *rest.Config) (bool, error) {
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// maxWorkerMinorVersionSkew is the number of minor versions the kubelet of a worker pool may be behind the control plane.
+const maxWorkerMinorVersionSkew = 3
+
+// MachinePoolKubernetesVersion returns the Kubernetes version of the template of the given MachinePool in the format
+// used by Gardener, i.e. without the leading "v". Returns an empty string if the MachinePool does not set a version.
+func MachinePoolKubernetesVersion(machinePool *clusterv1beta2.MachinePool) string {
+	if machinePool == nil {
+		return ""
+	}
+	return strings.TrimPrefix(machinePool.Spec.Template.Spec.Version, "v")
+}
+
+// WorkerPoolKubernetesVersion returns the Kubernetes version of the given GardenerWorkerPool spec. Returns an empty
+// string if the worker pool uses the version of the control plane.
+func WorkerPoolKubernetesVersion(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec) string {
+	if spec.Kubernetes == nil {
+		return ""
+	}
+	return ptr.Deref(spec.Kubernetes.Version, "")
+}
+
+// ApplyWorkerPoolKubernetesVersion sets the Kubernetes version of the given GardenerWorkerPool spec and returns whether
+// the spec changed. The spec is not changed if the version is empty.
+func ApplyWorkerPoolKubernetesVersion(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, version string) bool {
+	if version == "" || WorkerPoolKubernetesVersion(spec) == version {
+		return false
+	}
+	if spec.Kubernetes == nil {
+		spec.Kubernetes = &gardenercorev1beta1.WorkerKubernetes{}
+	}
+	spec.Kubernetes.Version = &version
+	return true
+}
+
+// ValidateWorkerPoolKubernetesVersion validates the Kubernetes version of a worker pool against the version of the
+// control plane, following the version skew policy which is enforced by Gardener: the version of a worker pool must not
+// be newer than the one of the control plane, and at most three minor versions older.
+func ValidateWorkerPoolKubernetesVersion(version, controlPlaneVersion string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if version == "" || controlPlaneVersion == "" {
+		return allErrs
+	}
+
+	workerVersion, err := semver.NewVersion(versionutils.Normalize(version))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, version, err.Error()))
+	}
+	cpVersion, err := semver.NewVersion(versionutils.Normalize(controlPlaneVersion))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, version, fmt.Sprintf("control plane version %q is invalid: %v", controlPlaneVersion, err)))
+	}
+
+	if workerVersion.GreaterThan(cpVersion) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must not be newer than the control plane version %s", controlPlaneVersion)))
+	} else if workerVersion.Major() != cpVersion.Major() || cpVersion.Minor()-workerVersion.Minor() > maxWorkerMinorVersionSkew {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must be at most %d minor versions older than the control plane version %s", maxWorkerMinorVersionSkew, controlPlaneVersion)))
+	}
+	return allErrs
+}
+
+// NodesKubernetesVersion returns the Kubernetes version of the kubelets of the given nodes in the format used by
+// Gardener. Returns false if there are no nodes, or if they do not run the same version, e.g. during a rolling update.
+func NodesKubernetesVersion(nodes []corev1.Node) (string, bool) {
+	var version string
+	for _, node := range nodes {
+		nodeVersion := strings.TrimPrefix(node.Status.NodeInfo.KubeletVersion, "v")
+		if nodeVersion == "" || (version != "" && nodeVersion != version) {
+			return "", false
+		}
+		version = nodeVersion
+	}
+	return version, version != ""
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Kubernetes version", func() {
+	fldPath := field.NewPath("spec", "kubernetes", "version")
+
+	Describe("#MachinePoolKubernetesVersion", func() {
+		It("should strip the leading v", func() {
+			machinePool := &clusterv1beta2.MachinePool{}
+			Expect(MachinePoolKubernetesVersion(machinePool)).To(BeEmpty())
+
+			machinePool.Spec.Template.Spec.Version = "v1.33.2"
+			Expect(MachinePoolKubernetesVersion(machinePool)).To(Equal("1.33.2"))
+
+			Expect(MachinePoolKubernetesVersion(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ApplyWorkerPoolKubernetesVersion", func() {
+		It("should set the version of the worker pool", func() {
+			spec := &infrastructurev1alpha2.GardenerWorkerPoolSpec{}
+			Expect(ApplyWorkerPoolKubernetesVersion(spec, "")).To(BeFalse())
+			Expect(spec.Kubernetes).To(BeNil())
+
+			Expect(ApplyWorkerPoolKubernetesVersion(spec, "1.33.2")).To(BeTrue())
+			Expect(spec.Kubernetes.Version).To(Equal(ptr.To("1.33.2")))
+			Expect(ApplyWorkerPoolKubernetesVersion(spec, "1.33.2")).To(BeFalse())
+		})
+	})
+
+	Describe("#ValidateWorkerPoolKubernetesVersion", func() {
+		It("should allow versions within the skew", func() {
+			Expect(ValidateWorkerPoolKubernetesVersion("1.33.2", "1.33.2", fldPath)).To(BeEmpty())
+			Expect(ValidateWorkerPoolKubernetesVersion("1.30.0", "1.33.2", fldPath)).To(BeEmpty())
+			Expect(ValidateWorkerPoolKubernetesVersion("", "1.33.2", fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid versions newer than the control plane", func() {
+			Expect(ValidateWorkerPoolKubernetesVersion("1.33.3", "1.33.2", fldPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("spec.kubernetes.version"),
+			}))))
+		})
+
+		It("should forbid versions more than three minor versions older than the control plane", func() {
+			Expect(ValidateWorkerPoolKubernetesVersion("1.29.9", "1.33.2", fldPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("spec.kubernetes.version"),
+			}))))
+		})
+
+		It("should forbid invalid versions", func() {
+			Expect(ValidateWorkerPoolKubernetesVersion("foo", "1.33.2", fldPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("spec.kubernetes.version"),
+			}))))
+		})
+	})
+
+	Describe("#NodesKubernetesVersion", func() {
+		node := func(kubeletVersion string) corev1.Node {
+			return corev1.Node{Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubeletVersion}}}
+		}
+
+		It("should return the version if all nodes run it", func() {
+			version, ok := NodesKubernetesVersion([]corev1.Node{node("v1.33.2"), node("v1.33.2")})
+			Expect(ok).To(BeTrue())
+			Expect(version).To(Equal("1.33.2"))
+		})
+
+		It("should not return a version during a rolling update", func() {
+			_, ok := NodesKubernetesVersion([]corev1.Node{node("v1.32.5"), node("v1.33.2")})
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not return a version without nodes", func() {
+			_, ok := NodesKubernetesVersion(nil)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
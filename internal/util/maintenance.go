@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/utils/timewindow"
+	"github.com/gardener/gardener/pkg/controllermanager/controller/shoot/maintenance/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// NextMaintenanceWindow returns the start and the end of the next maintenance time window of the given Shoot, seen
+// from now. If now is within a maintenance time window, the current one is returned. Returns false if the Shoot has no
+// valid maintenance time window.
+func NextMaintenanceWindow(shoot *gardenercorev1beta1.Shoot, now time.Time) (time.Time, time.Time, bool) {
+	if shoot.Spec.Maintenance == nil || shoot.Spec.Maintenance.TimeWindow == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	window, err := timewindow.ParseMaintenanceTimeWindow(shoot.Spec.Maintenance.TimeWindow.Begin, shoot.Spec.Maintenance.TimeWindow.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	// A time window which spans midnight and was begun yesterday may still be ongoing.
+	for day := now.AddDate(0, 0, -1); ; day = day.AddDate(0, 0, 1) {
+		if end := window.AdjustedEnd(day); end.After(now) {
+			return window.AdjustedBegin(day), end, true
+		}
+	}
+}
+
+// PendingUpdates returns the automatic updates Gardener is going to perform in the maintenance time windows of the given
+// Shoot, following the logic of the Shoot maintenance controller of Gardener: versions are updated if automatic
+// updates are enabled, and forcefully if they expire. Updates for which Gardener cannot determine a target version are
+// omitted, since they fail during the maintenance as well.
+func PendingUpdates(shoot *gardenercorev1beta1.Shoot, cloudProfile *gardenercorev1beta1.CloudProfile) []infrastructurev1alpha2.PendingUpdate {
+	var (
+		pendingUpdates []infrastructurev1alpha2.PendingUpdate
+		autoUpdate     = &gardenercorev1beta1.MaintenanceAutoUpdate{}
+	)
+	if shoot.Spec.Maintenance != nil && shoot.Spec.Maintenance.AutoUpdate != nil {
+		autoUpdate = shoot.Spec.Maintenance.AutoUpdate
+	}
+
+	if update := pendingKubernetesVersionUpdate(shoot.Spec.Kubernetes.Version, autoUpdate.KubernetesVersion, cloudProfile); update != nil {
+		pendingUpdates = append(pendingUpdates, *update)
+	}
+
+	controlPlaneVersion, err := semver.NewVersion(shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return pendingUpdates
+	}
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if update := pendingMachineImageVersionUpdate(worker, controlPlaneVersion, ptr.Deref(autoUpdate.MachineImageVersion, true), cloudProfile); update != nil {
+			pendingUpdates = append(pendingUpdates, *update)
+		}
+	}
+	return pendingUpdates
+}
+
+func pendingKubernetesVersionUpdate(version string, autoUpdate bool, cloudProfile *gardenercorev1beta1.CloudProfile) *infrastructurev1alpha2.PendingUpdate {
+	exists, expirableVersion, err := v1beta1helper.KubernetesVersionExistsInCloudProfile(cloudProfile, version)
+	if err != nil {
+		return nil
+	}
+
+	determine := func(isExpired bool) (string, error) {
+		return helper.DetermineVersionForStrategy(
+			cloudProfile.Spec.Kubernetes.Versions,
+			version,
+			v1beta1helper.GetLatestVersionForPatchAutoUpdate,
+			v1beta1helper.GetVersionForForcefulUpdateToConsecutiveMinor,
+			isExpired)
+	}
+	update, err := pendingUpdate(exists, VersionExpirationDate(expirableVersion), autoUpdate, determine)
+	if update == nil || err != nil {
+		return nil
+	}
+	update.Type = infrastructurev1alpha2.PendingUpdateTypeKubernetesVersion
+	update.CurrentVersion = version
+	return update
+}
+
+func pendingMachineImageVersionUpdate(worker gardenercorev1beta1.Worker, controlPlaneVersion *semver.Version, autoUpdate bool, cloudProfile *gardenercorev1beta1.CloudProfile) *infrastructurev1alpha2.PendingUpdate {
+	workerImage := worker.Machine.Image
+	if workerImage == nil || workerImage.Version == nil || worker.Machine.Architecture == nil {
+		return nil
+	}
+	machineType := v1beta1helper.FindMachineTypeByName(cloudProfile.Spec.MachineTypes, worker.Machine.Type)
+	if machineType == nil {
+		return nil
+	}
+	machineImage, err := helper.DetermineMachineImage(cloudProfile, workerImage)
+	if err != nil || machineImage.UpdateStrategy == nil {
+		return nil
+	}
+	kubeletVersion, err := v1beta1helper.CalculateEffectiveKubernetesVersion(controlPlaneVersion, worker.Kubernetes)
+	if err != nil {
+		return nil
+	}
+	filteredMachineImage := helper.FilterMachineImageVersions(&machineImage, worker, kubeletVersion, machineType, cloudProfile.Spec.MachineCapabilities)
+
+	var expirationDate *metav1.Time
+	exists, index := v1beta1helper.ShootMachineImageVersionExists(*filteredMachineImage, *workerImage)
+	if exists {
+		expirationDate = VersionExpirationDate(filteredMachineImage.Versions[index].ExpirableVersion)
+	}
+
+	determine := func(isExpired bool) (string, error) {
+		return helper.DetermineMachineImageVersion(workerImage, filteredMachineImage, isExpired)
+	}
+	update, err := pendingUpdate(exists, expirationDate, autoUpdate, determine)
+	if update == nil || err != nil {
+		return nil
+	}
+	update.Type = infrastructurev1alpha2.PendingUpdateTypeMachineImageVersion
+	update.WorkerPool = worker.Name
+	update.MachineImage = workerImage.Name
+	update.CurrentVersion = *workerImage.Version
+	return update
+}
+
+// pendingUpdate determines the target version of an update with the given determine function. Versions which do not
+// exist in the CloudProfile are treated like expired ones. Versions with an expiration date are forcefully updated,
+// unless an automatic update is pending anyway.
+func pendingUpdate(exists bool, expirationDate *metav1.Time, autoUpdate bool, determine func(isExpired bool) (string, error)) (*infrastructurev1alpha2.PendingUpdate, error) {
+	var (
+		targetVersion string
+		forced        bool
+		err           error
+	)
+	if autoUpdate {
+		if targetVersion, err = determine(false); err != nil {
+			return nil, err
+		}
+	}
+	if targetVersion == "" && (!exists || expirationDate != nil) {
+		if targetVersion, err = determine(true); err != nil {
+			return nil, err
+		}
+		forced = true
+	}
+	if targetVersion == "" {
+		return nil, nil
+	}
+	return &infrastructurev1alpha2.PendingUpdate{
+		TargetVersion:  targetVersion,
+		ExpirationDate: expirationDate,
+		Forced:         forced,
+	}, nil
+}
+
+// ForcedUpdatesToAnnounce returns the forced updates of the given maintenance status which have not been announced
+// yet, judging by the previous maintenance status: forced updates are announced once they become pending, and once more
+// when they are due in the next maintenance time window.
+func ForcedUpdatesToAnnounce(oldStatus, newStatus *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus) []infrastructurev1alpha2.PendingUpdate {
+	if newStatus == nil {
+		return nil
+	}
+	var announce []infrastructurev1alpha2.PendingUpdate
+	for _, update := range newStatus.PendingUpdates {
+		if !update.Forced {
+			continue
+		}
+		oldUpdate := findPendingUpdate(oldStatus, update)
+		if oldUpdate == nil || (isDueInNextWindow(update, newStatus) && !isDueInNextWindow(*oldUpdate, oldStatus)) {
+			announce = append(announce, update)
+		}
+	}
+	return announce
+}
+
+// ForcedUpdateMessage returns a message announcing the given forced update of the given maintenance status, to be
+// emitted as an event.
+func ForcedUpdateMessage(update infrastructurev1alpha2.PendingUpdate, status *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus) string {
+	subject := "Kubernetes version"
+	if update.Type == infrastructurev1alpha2.PendingUpdateTypeMachineImageVersion {
+		subject = fmt.Sprintf("version of machine image %s of worker pool %s", update.MachineImage, update.WorkerPool)
+	}
+	reason := "is not offered by the CloudProfile"
+	if update.ExpirationDate != nil {
+		reason = "expires on " + update.ExpirationDate.UTC().Format(time.RFC3339)
+	}
+	when := "in the first maintenance time window thereafter"
+	if isDueInNextWindow(update, status) {
+		when = "in the next maintenance time window"
+		if status.NextWindowStart != nil {
+			when += " starting on " + status.NextWindowStart.UTC().Format(time.RFC3339)
+		}
+	}
+	return fmt.Sprintf("The %s %s %s and will be forcefully updated to %s %s", subject, update.CurrentVersion, reason, update.TargetVersion, when)
+}
+
+// NextMaintenanceStatusChange returns the duration until the given maintenance status becomes outdated, i.e. until the
+// next maintenance time window starts or ends, or the next pending update expires. Returns zero if none of these is
+// ahead of now.
+func NextMaintenanceStatusChange(status *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus, now time.Time) time.Duration {
+	if status == nil {
+		return 0
+	}
+	times := []*metav1.Time{status.NextWindowStart, status.NextWindowEnd}
+	for _, update := range status.PendingUpdates {
+		times = append(times, update.ExpirationDate)
+	}
+
+	var next time.Duration
+	for _, t := range times {
+		if t == nil || !t.After(now) {
+			continue
+		}
+		if d := t.Sub(now); next == 0 || d < next {
+			next = d
+		}
+	}
+	return next
+}
+
+func findPendingUpdate(status *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus, update infrastructurev1alpha2.PendingUpdate) *infrastructurev1alpha2.PendingUpdate {
+	if status == nil {
+		return nil
+	}
+	for i, pendingUpdate := range status.PendingUpdates {
+		if pendingUpdate.Type == update.Type && pendingUpdate.WorkerPool == update.WorkerPool &&
+			pendingUpdate.CurrentVersion == update.CurrentVersion && pendingUpdate.TargetVersion == update.TargetVersion &&
+			pendingUpdate.Forced == update.Forced {
+			return &status.PendingUpdates[i]
+		}
+	}
+	return nil
+}
+
+// isDueInNextWindow returns whether the given forced update is performed in the next maintenance time window of the
+// given maintenance status. Versions which are not offered by the CloudProfile are updated right away.
+func isDueInNextWindow(update infrastructurev1alpha2.PendingUpdate, status *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus) bool {
+	if update.ExpirationDate == nil {
+		return true
+	}
+	return status != nil && status.NextWindowEnd != nil && update.ExpirationDate.Before(status.NextWindowEnd)
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Maintenance", func() {
+	var (
+		now          = time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+		expiration   = metav1.NewTime(now.AddDate(0, 1, 0))
+		shoot        *gardenercorev1beta1.Shoot
+		cloudProfile *gardenercorev1beta1.CloudProfile
+	)
+
+	BeforeEach(func() {
+		shoot = &gardenercorev1beta1.Shoot{
+			Spec: gardenercorev1beta1.ShootSpec{
+				Kubernetes: gardenercorev1beta1.Kubernetes{Version: "1.32.1"},
+				Maintenance: &gardenercorev1beta1.Maintenance{
+					AutoUpdate: &gardenercorev1beta1.MaintenanceAutoUpdate{
+						KubernetesVersion:   false,
+						MachineImageVersion: ptr.To(false),
+					},
+					TimeWindow: &gardenercorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+				},
+				Provider: gardenercorev1beta1.Provider{
+					Workers: []gardenercorev1beta1.Worker{{
+						Name: "pool",
+						Machine: gardenercorev1beta1.Machine{
+							Type:         "large",
+							Architecture: ptr.To("amd64"),
+							Image:        &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1.0.0")},
+						},
+					}},
+				},
+			},
+		}
+
+		machineImageVersion := func(version string) gardenercorev1beta1.MachineImageVersion {
+			return gardenercorev1beta1.MachineImageVersion{
+				ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: version},
+				Architectures:    []string{"amd64"},
+				CRI:              []gardenercorev1beta1.CRI{{Name: gardenercorev1beta1.CRINameContainerD}},
+			}
+		}
+		cloudProfile = &gardenercorev1beta1.CloudProfile{
+			Spec: gardenercorev1beta1.CloudProfileSpec{
+				Kubernetes: gardenercorev1beta1.KubernetesSettings{
+					Versions: []gardenercorev1beta1.ExpirableVersion{
+						{Version: "1.32.1"},
+						{Version: "1.32.5"},
+						{Version: "1.33.2"},
+					},
+				},
+				MachineTypes: []gardenercorev1beta1.MachineType{{Name: "large", Architecture: ptr.To("amd64")}},
+				MachineImages: []gardenercorev1beta1.MachineImage{{
+					Name:           "gardenlinux",
+					UpdateStrategy: ptr.To(gardenercorev1beta1.UpdateStrategyMajor),
+					Versions:       []gardenercorev1beta1.MachineImageVersion{machineImageVersion("1.0.0"), machineImageVersion("2.0.0")},
+				}},
+			},
+		}
+	})
+
+	Describe("#NextMaintenanceWindow", func() {
+		It("should return the window of today", func() {
+			start, end, ok := NextMaintenanceWindow(shoot, now)
+			Expect(ok).To(BeTrue())
+			Expect(start).To(Equal(time.Date(2026, time.March, 10, 22, 0, 0, 0, time.UTC)))
+			Expect(end).To(Equal(time.Date(2026, time.March, 10, 23, 0, 0, 0, time.UTC)))
+		})
+
+		It("should return the window of tomorrow if the one of today has passed", func() {
+			start, end, ok := NextMaintenanceWindow(shoot, now.Add(11*time.Hour+30*time.Minute))
+			Expect(ok).To(BeTrue())
+			Expect(start).To(Equal(time.Date(2026, time.March, 11, 22, 0, 0, 0, time.UTC)))
+			Expect(end).To(Equal(time.Date(2026, time.March, 11, 23, 0, 0, 0, time.UTC)))
+		})
+
+		It("should return the ongoing window if it spans midnight", func() {
+			shoot.Spec.Maintenance.TimeWindow = &gardenercorev1beta1.MaintenanceTimeWindow{Begin: "230000+0000", End: "020000+0000"}
+			start, end, ok := NextMaintenanceWindow(shoot, time.Date(2026, time.March, 10, 1, 0, 0, 0, time.UTC))
+			Expect(ok).To(BeTrue())
+			Expect(start).To(Equal(time.Date(2026, time.March, 9, 23, 0, 0, 0, time.UTC)))
+			Expect(end).To(Equal(time.Date(2026, time.March, 10, 2, 0, 0, 0, time.UTC)))
+		})
+
+		It("should not return a window if the Shoot has none", func() {
+			shoot.Spec.Maintenance.TimeWindow = nil
+			_, _, ok := NextMaintenanceWindow(shoot, now)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("#PendingUpdates", func() {
+		It("should not return updates if automatic updates are disabled and no version expires", func() {
+			Expect(PendingUpdates(shoot, cloudProfile)).To(BeEmpty())
+		})
+
+		It("should return automatic updates", func() {
+			shoot.Spec.Maintenance.AutoUpdate = &gardenercorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true, MachineImageVersion: ptr.To(true)}
+			Expect(PendingUpdates(shoot, cloudProfile)).To(ConsistOf(
+				infrastructurev1alpha2.PendingUpdate{
+					Type:           infrastructurev1alpha2.PendingUpdateTypeKubernetesVersion,
+					CurrentVersion: "1.32.1",
+					TargetVersion:  "1.32.5",
+				},
+				infrastructurev1alpha2.PendingUpdate{
+					Type:           infrastructurev1alpha2.PendingUpdateTypeMachineImageVersion,
+					WorkerPool:     "pool",
+					MachineImage:   "gardenlinux",
+					CurrentVersion: "1.0.0",
+					TargetVersion:  "2.0.0",
+				},
+			))
+		})
+
+		It("should return forced updates of expiring versions", func() {
+			shoot.Spec.Kubernetes.Version = "1.32.5"
+			cloudProfile.Spec.Kubernetes.Versions[1].ExpirationDate = &expiration
+			Expect(PendingUpdates(shoot, cloudProfile)).To(ConsistOf(infrastructurev1alpha2.PendingUpdate{
+				Type:           infrastructurev1alpha2.PendingUpdateTypeKubernetesVersion,
+				CurrentVersion: "1.32.5",
+				TargetVersion:  "1.33.2",
+				ExpirationDate: &expiration,
+				Forced:         true,
+			}))
+		})
+
+		It("should return forced updates of versions which are not offered by the CloudProfile", func() {
+			shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("0.9.0")
+			Expect(PendingUpdates(shoot, cloudProfile)).To(ConsistOf(infrastructurev1alpha2.PendingUpdate{
+				Type:           infrastructurev1alpha2.PendingUpdateTypeMachineImageVersion,
+				WorkerPool:     "pool",
+				MachineImage:   "gardenlinux",
+				CurrentVersion: "0.9.0",
+				TargetVersion:  "2.0.0",
+				Forced:         true,
+			}))
+		})
+	})
+
+	Describe("#ForcedUpdatesToAnnounce", func() {
+		var (
+			update     infrastructurev1alpha2.PendingUpdate
+			statusWith func(windowEnd time.Time, updates ...infrastructurev1alpha2.PendingUpdate) *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus
+		)
+
+		BeforeEach(func() {
+			update = infrastructurev1alpha2.PendingUpdate{
+				Type:           infrastructurev1alpha2.PendingUpdateTypeKubernetesVersion,
+				CurrentVersion: "1.32.5",
+				TargetVersion:  "1.33.2",
+				ExpirationDate: &expiration,
+				Forced:         true,
+			}
+			statusWith = func(windowEnd time.Time, updates ...infrastructurev1alpha2.PendingUpdate) *infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus {
+				return &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+					NextWindowStart: ptr.To(metav1.NewTime(windowEnd.Add(-time.Hour))),
+					NextWindowEnd:   ptr.To(metav1.NewTime(windowEnd)),
+					PendingUpdates:  updates,
+				}
+			}
+		})
+
+		It("should announce new forced updates", func() {
+			Expect(ForcedUpdatesToAnnounce(nil, statusWith(now, update))).To(ConsistOf(update))
+			Expect(ForcedUpdatesToAnnounce(statusWith(now), statusWith(now, update))).To(ConsistOf(update))
+		})
+
+		It("should not announce automatic updates", func() {
+			update.Forced = false
+			Expect(ForcedUpdatesToAnnounce(nil, statusWith(now, update))).To(BeEmpty())
+		})
+
+		It("should announce forced updates once more when they are due in the next window", func() {
+			Expect(ForcedUpdatesToAnnounce(statusWith(now, update), statusWith(now.Add(24*time.Hour), update))).To(BeEmpty())
+			Expect(ForcedUpdatesToAnnounce(statusWith(now, update), statusWith(expiration.AddDate(0, 0, 1), update))).To(ConsistOf(update))
+			Expect(ForcedUpdatesToAnnounce(statusWith(expiration.AddDate(0, 0, 1), update), statusWith(expiration.AddDate(0, 0, 1), update))).To(BeEmpty())
+		})
+	})
+
+	Describe("#NextMaintenanceStatusChange", func() {
+		It("should return the duration until the next window starts", func() {
+			status := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+				NextWindowStart: ptr.To(metav1.NewTime(now.Add(10 * time.Hour))),
+				NextWindowEnd:   ptr.To(metav1.NewTime(now.Add(11 * time.Hour))),
+				PendingUpdates:  []infrastructurev1alpha2.PendingUpdate{{ExpirationDate: &expiration}},
+			}
+			Expect(NextMaintenanceStatusChange(status, now)).To(Equal(10 * time.Hour))
+		})
+
+		It("should return the duration until the ongoing window ends", func() {
+			status := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+				NextWindowStart: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+				NextWindowEnd:   ptr.To(metav1.NewTime(now.Add(time.Hour))),
+			}
+			Expect(NextMaintenanceStatusChange(status, now)).To(Equal(time.Hour))
+		})
+
+		It("should return the duration until a pending update expires", func() {
+			status := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+				PendingUpdates: []infrastructurev1alpha2.PendingUpdate{{}, {ExpirationDate: &expiration}},
+			}
+			Expect(NextMaintenanceStatusChange(status, now)).To(Equal(expiration.Sub(now)))
+		})
+
+		It("should return zero if nothing is ahead", func() {
+			Expect(NextMaintenanceStatusChange(nil, now)).To(BeZero())
+			Expect(NextMaintenanceStatusChange(&infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{}, now)).To(BeZero())
+		})
+	})
+
+	Describe("#ForcedUpdateMessage", func() {
+		It("should describe when the update is performed", func() {
+			update := infrastructurev1alpha2.PendingUpdate{
+				Type:           infrastructurev1alpha2.PendingUpdateTypeMachineImageVersion,
+				WorkerPool:     "pool",
+				MachineImage:   "gardenlinux",
+				CurrentVersion: "1.0.0",
+				TargetVersion:  "2.0.0",
+				ExpirationDate: &expiration,
+				Forced:         true,
+			}
+			status := &infrastructurev1alpha2.GardenerShootClusterMaintenanceStatus{
+				NextWindowStart: ptr.To(metav1.NewTime(now)),
+				NextWindowEnd:   ptr.To(metav1.NewTime(now.Add(time.Hour))),
+			}
+			Expect(ForcedUpdateMessage(update, status)).To(Equal("The version of machine image gardenlinux of worker pool pool 1.0.0 expires on 2026-04-10T12:00:00Z and will be forcefully updated to 2.0.0 in the first maintenance time window thereafter"))
+
+			update.ExpirationDate = nil
+			Expect(ForcedUpdateMessage(update, status)).To(Equal("The version of machine image gardenlinux of worker pool pool 1.0.0 is not offered by the CloudProfile and will be forcefully updated to 2.0.0 in the next maintenance time window starting on 2026-03-10T12:00:00Z"))
+		})
+	})
+})
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// FieldMapping maps a field of the Shoot spec, or of a worker of the Shoot, to the field with the same Go field path
+// in the spec of a CAPI object.
+type FieldMapping struct {
+	// Path is the Go field path in the Shoot spec or the worker, e.g. `Provider.Type`.
+	Path string
+	// Kind is the kind of the CAPI object whose spec holds the field.
+	Kind string
+	// CreateOnly fields are only written to the Shoot when it is created. They are still synced back from the Shoot.
+	CreateOnly bool
+
+	shootJSONPath  string
+	targetJSONPath string
+}
+
+var (
+	// ShootSpecFieldMappings maps the fields of the Shoot spec to the GardenerShootControlPlane and GardenerShootCluster.
+	ShootSpecFieldMappings = []FieldMapping{
+		{Path: "AccessRestrictions", Kind: KindGardenerShootControlPlane},
+		{Path: "Addons", Kind: KindGardenerShootControlPlane},
+		{Path: "CloudProfile", Kind: KindGardenerShootControlPlane},
+		{Path: "ControlPlane", Kind: KindGardenerShootControlPlane},
+		{Path: "CredentialsBindingName", Kind: KindGardenerShootControlPlane},
+		{Path: "DNS", Kind: KindGardenerShootControlPlane},
+		{Path: "ExposureClassName", Kind: KindGardenerShootControlPlane},
+		{Path: "Extensions", Kind: KindGardenerShootControlPlane},
+		{Path: "Kubernetes", Kind: KindGardenerShootControlPlane},
+		{Path: "Monitoring", Kind: KindGardenerShootControlPlane},
+		{Path: "Networking", Kind: KindGardenerShootControlPlane},
+		{Path: "Provider.ControlPlaneConfig", Kind: KindGardenerShootControlPlane},
+		{Path: "Provider.InfrastructureConfig", Kind: KindGardenerShootControlPlane},
+		{Path: "Provider.Type", Kind: KindGardenerShootControlPlane},
+		{Path: "Provider.WorkersSettings", Kind: KindGardenerShootControlPlane},
+		{Path: "Purpose", Kind: KindGardenerShootControlPlane},
+		{Path: "Resources", Kind: KindGardenerShootControlPlane},
+		{Path: "SchedulerName", Kind: KindGardenerShootControlPlane},
+		{Path: "SecretBindingName", Kind: KindGardenerShootControlPlane},
+		{Path: "SystemComponents", Kind: KindGardenerShootControlPlane},
+		{Path: "Tolerations", Kind: KindGardenerShootControlPlane},
+
+		{Path: "Hibernation", Kind: KindGardenerShootCluster},
+		{Path: "Maintenance", Kind: KindGardenerShootCluster},
+		{Path: "Region", Kind: KindGardenerShootCluster},
+		// Changing the seed of an existing Shoot requires the `shoots/binding` subresource, so updates are not synced.
+		{Path: "SeedName", Kind: KindGardenerShootCluster, CreateOnly: true},
+		{Path: "SeedSelector", Kind: KindGardenerShootCluster},
+	}
+
+	// ShootSpecExcludedFields contains the fields of the Shoot spec which are deliberately not mapped, and the reason.
+	ShootSpecExcludedFields = map[string]string{
+		"CloudProfileName": "deprecated in favour of CloudProfile, which Gardener keeps in sync with it",
+		"Provider.Workers": "workers are mapped to GardenerWorkerPools with WorkerFieldMappings",
+	}
+
+	// WorkerFieldMappings maps the fields of a worker of the Shoot to the GardenerWorkerPool.
+	WorkerFieldMappings = []FieldMapping{
+		{Path: "Annotations", Kind: KindGardenerWorkerPool},
+		{Path: "CABundle", Kind: KindGardenerWorkerPool},
+		{Path: "CRI", Kind: KindGardenerWorkerPool},
+		{Path: "ClusterAutoscaler", Kind: KindGardenerWorkerPool},
+		{Path: "ControlPlane", Kind: KindGardenerWorkerPool},
+		{Path: "DataVolumes", Kind: KindGardenerWorkerPool},
+		{Path: "KubeletDataVolumeName", Kind: KindGardenerWorkerPool},
+		{Path: "Kubernetes", Kind: KindGardenerWorkerPool},
+		{Path: "Labels", Kind: KindGardenerWorkerPool},
+		{Path: "Machine", Kind: KindGardenerWorkerPool},
+		{Path: "MachineControllerManagerSettings", Kind: KindGardenerWorkerPool},
+		{Path: "MaxSurge", Kind: KindGardenerWorkerPool},
+		{Path: "MaxUnavailable", Kind: KindGardenerWorkerPool},
+		{Path: "Maximum", Kind: KindGardenerWorkerPool},
+		{Path: "Minimum", Kind: KindGardenerWorkerPool},
+		{Path: "Priority", Kind: KindGardenerWorkerPool},
+		{Path: "ProviderConfig", Kind: KindGardenerWorkerPool},
+		{Path: "Sysctls", Kind: KindGardenerWorkerPool},
+		{Path: "SystemComponents", Kind: KindGardenerWorkerPool},
+		{Path: "Taints", Kind: KindGardenerWorkerPool},
+		{Path: "UpdateStrategy", Kind: KindGardenerWorkerPool},
+		{Path: "Volume", Kind: KindGardenerWorkerPool},
+		{Path: "Zones", Kind: KindGardenerWorkerPool},
+	}
+
+	// WorkerExcludedFields contains the fields of a worker which are deliberately not mapped, and the reason.
+	WorkerExcludedFields = map[string]string{
+		"Name": "the name of a worker is the name of its GardenerWorkerPool",
+	}
+
+	specTypesByKind = map[string]reflect.Type{
+		KindGardenerShootControlPlane: reflect.TypeFor[controlplanev1alpha2.GardenerShootControlPlaneSpec](),
+		KindGardenerShootCluster:      reflect.TypeFor[infrastructurev1alpha2.GardenerShootClusterSpec](),
+		KindGardenerWorkerPool:        reflect.TypeFor[infrastructurev1alpha2.GardenerWorkerPoolSpec](),
+	}
+)
+
+func init() {
+	initFieldMappings(ShootSpecFieldMappings, reflect.TypeFor[gardenercorev1beta1.ShootSpec]())
+	initFieldMappings(WorkerFieldMappings, reflect.TypeFor[gardenercorev1beta1.Worker]())
+}
+
+// initFieldMappings computes the JSON paths of the given mappings. It panics if a field does not exist in the source or
+// target type, or if the types differ, as the mappings would be unusable.
+func initFieldMappings(mappings []FieldMapping, sourceType reflect.Type) {
+	for i, mapping := range mappings {
+		sourceJSONPath, sourceFieldType, err := jsonPathForFieldPath(sourceType, mapping.Path)
+		if err != nil {
+			panic(err)
+		}
+		targetJSONPath, targetFieldType, err := jsonPathForFieldPath(specTypesByKind[mapping.Kind], mapping.Path)
+		if err != nil {
+			panic(err)
+		}
+		if sourceFieldType != targetFieldType {
+			panic(fmt.Sprintf("field %s has type %s in %s but %s in %s", mapping.Path, sourceFieldType, sourceType.Name(), targetFieldType, mapping.Kind))
+		}
+		mappings[i].shootJSONPath = sourceJSONPath
+		mappings[i].targetJSONPath = targetJSONPath
+	}
+}
+
+// jsonPathForFieldPath returns the JSON path and the type of the field with the given Go field path in the given type.
+func jsonPathForFieldPath(t reflect.Type, path string) (string, reflect.Type, error) {
+	var jsonPath []string
+	for name := range strings.SplitSeq(path, ".") {
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return "", nil, fmt.Errorf("type %s has no field %s", t.Name(), name)
+		}
+		jsonPath = append(jsonPath, strings.Split(field.Tag.Get("json"), ",")[0])
+		t = field.Type
+	}
+	return strings.Join(jsonPath, "."), t, nil
+}
+
+// copyMappedFields copies the fields of the given mappings from source to target. Source and target must be pointers to
+// the Shoot spec (or a worker) and the spec of the CAPI object of the given kind, in either direction.
+func copyMappedFields(target, source any, mappings []FieldMapping, kind string, skip func(FieldMapping) bool) {
+	targetValue, sourceValue := reflect.ValueOf(target).Elem(), reflect.ValueOf(source).Elem()
+	for _, mapping := range mappings {
+		if mapping.Kind != kind || (skip != nil && skip(mapping)) {
+			continue
+		}
+		fieldByPath(targetValue, mapping.Path).Set(fieldByPath(sourceValue, mapping.Path))
+	}
+}
+
+func fieldByPath(v reflect.Value, path string) reflect.Value {
+	for name := range strings.SplitSeq(path, ".") {
+		v = v.FieldByName(name)
+	}
+	return v
+}
+
+func isCreateOnly(mapping FieldMapping) bool {
+	return mapping.CreateOnly
+}
+
+// targetForJSONPath returns the mapping whose Shoot JSON path is the longest prefix of the given JSON path, and the
+// JSON path in the CAPI object. Returns nil if no mapping matches.
+func targetForJSONPath(mappings []FieldMapping, jsonPath string) (*FieldMapping, string) {
+	var (
+		match      *FieldMapping
+		targetPath string
+	)
+	for i, mapping := range mappings {
+		rest, ok := strings.CutPrefix(jsonPath, mapping.shootJSONPath)
+		if !ok || (len(rest) > 0 && rest[0] != '.' && rest[0] != '[') {
+			continue
+		}
+		if match == nil || len(mapping.shootJSONPath) > len(match.shootJSONPath) {
+			match, targetPath = &mappings[i], mapping.targetJSONPath+rest
+		}
+	}
+	return match, targetPath
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"reflect"
+	"strings"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Field mappings", func() {
+	Describe("coverage", func() {
+		It("should map or explicitly exclude every field of the Shoot spec", func() {
+			Expect(unmappedFields(reflect.TypeFor[gardenercorev1beta1.ShootSpec](), "", ShootSpecFieldMappings, ShootSpecExcludedFields)).To(BeEmpty(),
+				"new fields of the Shoot spec must be added to ShootSpecFieldMappings or ShootSpecExcludedFields")
+		})
+
+		It("should map or explicitly exclude every field of a worker", func() {
+			Expect(unmappedFields(reflect.TypeFor[gardenercorev1beta1.Worker](), "", WorkerFieldMappings, WorkerExcludedFields)).To(BeEmpty(),
+				"new fields of a worker must be added to WorkerFieldMappings or WorkerExcludedFields")
+		})
+	})
+
+	Describe("sync", func() {
+		var (
+			controlPlane *controlplanev1alpha2.GardenerShootControlPlane
+			infraCluster *infrastructurev1alpha2.GardenerShootCluster
+			workerPool   *infrastructurev1alpha2.GardenerWorkerPool
+			shoot        *gardenercorev1beta1.Shoot
+		)
+
+		BeforeEach(func() {
+			controlPlane = &controlplanev1alpha2.GardenerShootControlPlane{
+				Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+					ProjectNamespace:       "garden-foo",
+					Provider:               controlplanev1alpha2.ProviderGSCP{Type: "aws"},
+					CloudProfile:           &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"},
+					CredentialsBindingName: ptr.To("credentials"),
+					AccessRestrictions:     []gardenercorev1beta1.AccessRestrictionWithOptions{{AccessRestriction: gardenercorev1beta1.AccessRestriction{Name: "eu-access-only"}}},
+				},
+			}
+			infraCluster = &infrastructurev1alpha2.GardenerShootCluster{
+				Spec: infrastructurev1alpha2.GardenerShootClusterSpec{
+					Region:   "eu-west-1",
+					SeedName: ptr.To("seed-a"),
+				},
+			}
+			workerPool = &infrastructurev1alpha2.GardenerWorkerPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: infrastructurev1alpha2.GardenerWorkerPoolSpec{
+					Machine: gardenercorev1beta1.Machine{Type: "m5.large"},
+					Minimum: 1,
+					Maximum: 3,
+				},
+			}
+			shoot = ShootFromCAPIResources(
+				clusterv1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+				*controlPlane, *infraCluster, []infrastructurev1alpha2.GardenerWorkerPool{*workerPool},
+			)
+		})
+
+		It("should create the Shoot from all mapped fields", func() {
+			Expect(shoot.Name).To(Equal("foo"))
+			Expect(shoot.Namespace).To(Equal("garden-foo"))
+			Expect(shoot.Spec.Provider.Type).To(Equal("aws"))
+			Expect(shoot.Spec.CloudProfile).To(Equal(controlPlane.Spec.CloudProfile))
+			Expect(shoot.Spec.CredentialsBindingName).To(Equal(ptr.To("credentials")))
+			Expect(shoot.Spec.AccessRestrictions).To(Equal(controlPlane.Spec.AccessRestrictions))
+			Expect(shoot.Spec.Region).To(Equal("eu-west-1"))
+			Expect(shoot.Spec.SeedName).To(Equal(ptr.To("seed-a")))
+			Expect(shoot.Spec.Provider.Workers).To(ConsistOf(gardenercorev1beta1.Worker{
+				Name:    "pool",
+				Machine: gardenercorev1beta1.Machine{Type: "m5.large"},
+				Minimum: 1,
+				Maximum: 3,
+			}))
+		})
+
+		It("should sync the same fields in both directions", func() {
+			controlPlane.Spec.CredentialsBindingName = ptr.To("other-credentials")
+			controlPlane.Spec.AccessRestrictions = nil
+			SyncShootSpecFromGSCP(shoot, controlPlane)
+			Expect(shoot.Spec.CredentialsBindingName).To(Equal(ptr.To("other-credentials")))
+			Expect(shoot.Spec.AccessRestrictions).To(BeNil())
+
+			syncedControlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
+			SyncGSCPSpecFromShoot(shoot, syncedControlPlane)
+			Expect(syncedControlPlane.Spec.Provider).To(Equal(controlPlane.Spec.Provider))
+			Expect(syncedControlPlane.Spec.CloudProfile).To(Equal(controlPlane.Spec.CloudProfile))
+			Expect(syncedControlPlane.Spec.CredentialsBindingName).To(Equal(controlPlane.Spec.CredentialsBindingName))
+
+			workerPool.Spec.Maximum = 5
+			SyncShootSpecFromWorkerPool(shoot, workerPool)
+			Expect(shoot.Spec.Provider.Workers[0].Maximum).To(Equal(int32(5)))
+
+			syncedWorkerPool := &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			SyncWorkerPoolFromShootSpec(shoot, syncedWorkerPool)
+			Expect(syncedWorkerPool.Spec).To(Equal(workerPool.Spec))
+		})
+
+		It("should not sync create-only fields to the Shoot", func() {
+			infraCluster.Spec.SeedName = ptr.To("seed-b")
+			infraCluster.Spec.Region = "eu-west-2"
+			SyncShootSpecFromCluster(shoot, infraCluster)
+			Expect(shoot.Spec.SeedName).To(Equal(ptr.To("seed-a")))
+			Expect(shoot.Spec.Region).To(Equal("eu-west-2"))
+
+			SyncClusterSpecFromShoot(shoot, infraCluster)
+			Expect(infraCluster.Spec.SeedName).To(Equal(ptr.To("seed-a")))
+		})
+	})
+})
+
+// unmappedFields returns the Go field paths of the given type which are neither mapped nor excluded.
+// Struct fields that are mapped partially are checked recursively.
+func unmappedFields(t reflect.Type, prefix string, mappings []FieldMapping, excluded map[string]string) []string {
+	var (
+		covered   = map[string]bool{}
+		unmapped  []string
+		hasPrefix = func(path string) bool {
+			for _, mapping := range mappings {
+				if strings.HasPrefix(mapping.Path, path+".") {
+					return true
+				}
+			}
+			for excludedPath := range excluded {
+				if strings.HasPrefix(excludedPath, path+".") {
+					return true
+				}
+			}
+			return false
+		}
+	)
+	for _, mapping := range mappings {
+		covered[mapping.Path] = true
+	}
+	for excludedPath := range excluded {
+		covered[excludedPath] = true
+	}
+
+	for i := range t.NumField() {
+		path := prefix + t.Field(i).Name
+		switch {
+		case covered[path]:
+		case t.Field(i).Type.Kind() == reflect.Struct && hasPrefix(path):
+			unmapped = append(unmapped, unmappedFields(t.Field(i).Type, path+".", mappings, excluded)...)
+		default:
+			unmapped = append(unmapped, path)
+		}
+	}
+	return unmapped
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"github.com/gardener/gardener/pkg/utils"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+// workspaceProjectNamePrefix is the prefix of the names of Gardener Projects derived from a logical cluster. Project
+// names must start with a letter and must not be longer than 10 characters.
+const workspaceProjectNamePrefix = "ws"
+
+// ProjectOwner returns the value of the ProjectOwnerAnnotation for the given GardenerProject.
+func ProjectOwner(clusterName multicluster.ClusterName, project *controlplanev1alpha2.GardenerProject) string {
+	if clusterName == "" {
+		return project.Name
+	}
+	return string(clusterName) + "/" + project.Name
+}
+
+// GardenerProjectName returns the name of the Project in the Gardener cluster for the given GardenerProject. Once the
+// Project was created, the name recorded in the status is used.
+func GardenerProjectName(clusterName multicluster.ClusterName, project *controlplanev1alpha2.GardenerProject) string {
+	switch {
+	case project.Status.ProjectName != "":
+		return project.Status.ProjectName
+	case project.Spec.ProjectName != "":
+		return project.Spec.ProjectName
+	case clusterName == "":
+		return project.Name
+	default:
+		return workspaceProjectNamePrefix + utils.ComputeSHA256Hex([]byte(ProjectOwner(clusterName, project)))[:8]
+	}
+}
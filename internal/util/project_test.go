@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("GardenerProject", func() {
+	var gardenerProject *controlplanev1alpha2.GardenerProject
+
+	BeforeEach(func() {
+		gardenerProject = &controlplanev1alpha2.GardenerProject{ObjectMeta: metav1.ObjectMeta{Name: "workspace"}}
+	})
+
+	It("should include the logical cluster in the owner", func() {
+		Expect(ProjectOwner("", gardenerProject)).To(Equal("workspace"))
+		Expect(ProjectOwner("2x6vp4r8", gardenerProject)).To(Equal("2x6vp4r8/workspace"))
+	})
+
+	Describe("#GardenerProjectName", func() {
+		It("should use the name of the object without logical cluster", func() {
+			Expect(GardenerProjectName("", gardenerProject)).To(Equal("workspace"))
+		})
+
+		It("should derive a short name from the logical cluster", func() {
+			name := GardenerProjectName("2x6vp4r8", gardenerProject)
+			Expect(name).To(MatchRegexp(`^ws[0-9a-f]{8}$`))
+			Expect(GardenerProjectName("5g7kqz2m", gardenerProject)).NotTo(Equal(name))
+		})
+
+		It("should prefer the name of the spec and the status", func() {
+			gardenerProject.Spec.ProjectName = "dev"
+			Expect(GardenerProjectName("2x6vp4r8", gardenerProject)).To(Equal("dev"))
+
+			gardenerProject.Status.ProjectName = "prod"
+			Expect(GardenerProjectName("2x6vp4r8", gardenerProject)).To(Equal("prod"))
+		})
+	})
+})
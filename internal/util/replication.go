@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+const (
+	// KindSecret is the kind of Secrets in resource references.
+	KindSecret = "Secret"
+	// KindConfigMap is the kind of ConfigMaps in resource references.
+	KindConfigMap = "ConfigMap"
+)
+
+// ResourceReference references a Secret or ConfigMap by its kind and name.
+type ResourceReference struct {
+	Kind string
+	Name string
+}
+
+// ReferencedResources returns the Secrets and ConfigMaps referenced by the spec of the given GardenerShootControlPlane,
+// i.e. by `resources`, which extension configs refer to, and by the credentials of the DNS providers.
+func ReferencedResources(controlPlane *controlplanev1alpha2.GardenerShootControlPlane) []ResourceReference {
+	return referencedResources(controlPlane.Spec.Resources, controlPlane.Spec.DNS)
+}
+
+// ShootReferencedResources returns the Secrets and ConfigMaps referenced by the spec of the given Shoot, see
+// ReferencedResources.
+func ShootReferencedResources(shoot *gardenercorev1beta1.Shoot) []ResourceReference {
+	return referencedResources(shoot.Spec.Resources, shoot.Spec.DNS)
+}
+
+func referencedResources(resources []gardenercorev1beta1.NamedResourceReference, dns *gardenercorev1beta1.DNS) []ResourceReference {
+	var references []ResourceReference
+	visitResourceReferences(resources, dns, func(kind string, name *string) {
+		if reference := (ResourceReference{Kind: kind, Name: *name}); !slices.Contains(references, reference) {
+			references = append(references, reference)
+		}
+	})
+	return references
+}
+
+// visitResourceReferences calls visit with the kind and a pointer to the name of every Secret and ConfigMap referenced
+// by the given fields.
+func visitResourceReferences(resources []gardenercorev1beta1.NamedResourceReference, dns *gardenercorev1beta1.DNS, visit func(kind string, name *string)) {
+	for i := range resources {
+		ref := &resources[i].ResourceRef
+		if ref.APIVersion == corev1.SchemeGroupVersion.String() && (ref.Kind == KindSecret || ref.Kind == KindConfigMap) {
+			visit(ref.Kind, &ref.Name)
+		}
+	}
+	if dns == nil {
+		return
+	}
+	for i := range dns.Providers {
+		provider := &dns.Providers[i]
+		if provider.SecretName != nil {
+			visit(KindSecret, provider.SecretName)
+		}
+		if ref := provider.CredentialsRef; ref != nil && ref.APIVersion == corev1.SchemeGroupVersion.String() && ref.Kind == KindSecret {
+			visit(KindSecret, &ref.Name)
+		}
+	}
+}
+
+// ReplicatedResources returns the Secrets and ConfigMaps referenced by the spec of the given GardenerShootControlPlane
+// which are replicated into the project namespace, i.e. which exist in the namespace of the GardenerShootControlPlane
+// and carry the ReplicateLabel. References to other objects are resolved in the project namespace by Gardener.
+func ReplicatedResources(ctx context.Context, c client.Reader, controlPlane *controlplanev1alpha2.GardenerShootControlPlane) (map[ResourceReference]client.Object, error) {
+	replicated := map[ResourceReference]client.Object{}
+	for _, reference := range ReferencedResources(controlPlane) {
+		obj := newResourceObject(reference.Kind)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: controlPlane.Namespace, Name: reference.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get %s %s: %w", reference.Kind, reference.Name, err)
+		}
+		if IsReplicated(obj) {
+			replicated[reference] = obj
+		}
+	}
+	return replicated, nil
+}
+
+// IsReplicated returns true if the given Secret or ConfigMap carries the ReplicateLabel.
+func IsReplicated(obj client.Object) bool {
+	return obj.GetLabels()[controlplanev1alpha2.ReplicateLabel] == "true"
+}
+
+// ReplicatedSelector selects the Secrets and ConfigMaps which carry the ReplicateLabel.
+func ReplicatedSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{controlplanev1alpha2.ReplicateLabel: "true"})
+}
+
+// ResourceKind returns the kind of the given Secret or ConfigMap.
+func ResourceKind(obj client.Object) string {
+	if _, ok := obj.(*corev1.ConfigMap); ok {
+		return KindConfigMap
+	}
+	return KindSecret
+}
+
+func newResourceObject(kind string) client.Object {
+	if kind == KindConfigMap {
+		return &corev1.ConfigMap{}
+	}
+	return &corev1.Secret{}
+}
+
+// ReplicaName returns the name of the replica of a Secret or ConfigMap in the project namespace of the Shoot with the
+// given name.
+func ReplicaName(shootName, name string) string {
+	return shootName + "-" + name
+}
+
+// NewReplica returns an empty replica of the given Secret or ConfigMap for the given Shoot.
+func NewReplica(source client.Object, shoot *gardenercorev1beta1.Shoot) client.Object {
+	replica := newResourceObject(ResourceKind(source))
+	replica.SetName(ReplicaName(shoot.Name, source.GetName()))
+	replica.SetNamespace(shoot.Namespace)
+	return replica
+}
+
+// IsReplicaOf returns true if the given object in the project namespace is the replica of the Secret or ConfigMap with
+// the given name for the given Shoot.
+func IsReplicaOf(replica client.Object, shoot *gardenercorev1beta1.Shoot, sourceName string) bool {
+	return replica.GetLabels()[controlplanev1alpha2.ReplicaShootLabel] == shoot.Name &&
+		replica.GetAnnotations()[controlplanev1alpha2.ReplicaSourceAnnotation] == sourceName
+}
+
+// SyncReplica sets the owner labels and the data of the given replica from the given Secret or ConfigMap and the owner
+// of the given Shoot. It returns true if the replica changed. The type of Secrets is immutable, hence it is only set for
+// new replicas.
+func SyncReplica(replica, source client.Object, shoot *gardenercorev1beta1.Shoot, owner ShootOwner) bool {
+	original := replica.DeepCopyObject()
+
+	var objectMeta *metav1.ObjectMeta
+	switch source := source.(type) {
+	case *corev1.Secret:
+		secret := replica.(*corev1.Secret)
+		objectMeta = &secret.ObjectMeta
+		if secret.ResourceVersion == "" {
+			secret.Type = source.Type
+		}
+		secret.Data = source.Data
+	case *corev1.ConfigMap:
+		configMap := replica.(*corev1.ConfigMap)
+		objectMeta = &configMap.ObjectMeta
+		configMap.Data = source.Data
+		configMap.BinaryData = source.BinaryData
+	}
+	metav1.SetMetaDataLabel(objectMeta, controlplanev1alpha2.ReplicaShootLabel, shoot.Name)
+	metav1.SetMetaDataLabel(objectMeta, controlplanev1alpha2.ShootOwnerHashLabel, owner.Hash())
+	metav1.SetMetaDataAnnotation(objectMeta, controlplanev1alpha2.ShootOwnerAnnotation, owner.String())
+	metav1.SetMetaDataAnnotation(objectMeta, controlplanev1alpha2.ReplicaSourceAnnotation, source.GetName())
+
+	return !apiequality.Semantic.DeepEqual(original, replica)
+}
+
+// ReplaceReplicatedReferences points the references of the given Shoot to the given replicated Secrets and ConfigMaps
+// to their replicas in the project namespace.
+func ReplaceReplicatedReferences(shoot *gardenercorev1beta1.Shoot, replicated sets.Set[ResourceReference]) {
+	if replicated.Len() == 0 {
+		return
+	}
+	cloneReferenceFields(&shoot.Spec.Resources, &shoot.Spec.DNS)
+	visitResourceReferences(shoot.Spec.Resources, shoot.Spec.DNS, func(kind string, name *string) {
+		if replicated.Has(ResourceReference{Kind: kind, Name: *name}) {
+			*name = ReplicaName(shoot.Name, *name)
+		}
+	})
+}
+
+// OmitPendingReplicas removes the references of the given Shoot to the given replicated Secrets and ConfigMaps whose
+// replicas do not exist yet, as Gardener rejects references to missing objects. Named resources are dropped, the DNS
+// providers are reset to the ones of the current Shoot if they reference a pending replica.
+func OmitPendingReplicas(shoot, current *gardenercorev1beta1.Shoot, pending sets.Set[ResourceReference]) {
+	if pending.Len() == 0 {
+		return
+	}
+	isPending := func(kind string, name *string) bool {
+		return pending.Has(ResourceReference{Kind: kind, Name: *name})
+	}
+
+	var resources []gardenercorev1beta1.NamedResourceReference
+	for _, resource := range shoot.Spec.Resources {
+		pendingResource := false
+		visitResourceReferences([]gardenercorev1beta1.NamedResourceReference{resource}, nil, func(kind string, name *string) {
+			pendingResource = isPending(kind, name)
+		})
+		if !pendingResource {
+			resources = append(resources, resource)
+		}
+	}
+	shoot.Spec.Resources = resources
+
+	pendingDNS := false
+	visitResourceReferences(nil, shoot.Spec.DNS, func(kind string, name *string) {
+		pendingDNS = pendingDNS || isPending(kind, name)
+	})
+	if pendingDNS {
+		shoot.Spec.DNS = current.Spec.DNS.DeepCopy()
+	}
+}
+
+// RestoreReplicatedReferences points the references of the given GardenerShootControlPlane, which were synced from the
+// Shoot with the given name, from the replicas back to the Secrets and ConfigMaps referenced by the former spec.
+func RestoreReplicatedReferences(controlPlane, former *controlplanev1alpha2.GardenerShootControlPlane, shootName string) {
+	formerReferences := ReferencedResources(former)
+	if len(formerReferences) == 0 {
+		return
+	}
+	cloneReferenceFields(&controlPlane.Spec.Resources, &controlPlane.Spec.DNS)
+	visitResourceReferences(controlPlane.Spec.Resources, controlPlane.Spec.DNS, func(kind string, name *string) {
+		for _, reference := range formerReferences {
+			if reference.Kind == kind && ReplicaName(shootName, reference.Name) == *name {
+				*name = reference.Name
+				return
+			}
+		}
+	})
+}
+
+// cloneReferenceFields replaces the given fields by deep copies before references are changed, as the fields are shared
+// between the Shoot and the GardenerShootControlPlane after syncing them.
+func cloneReferenceFields(resources *[]gardenercorev1beta1.NamedResourceReference, dns **gardenercorev1beta1.DNS) {
+	*resources = slices.Clone(*resources)
+	*dns = (*dns).DeepCopy()
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Replication", func() {
+	var (
+		controlPlane *controlplanev1alpha2.GardenerShootControlPlane
+		shoot        *gardenercorev1beta1.Shoot
+	)
+
+	BeforeEach(func() {
+		controlPlane = &controlplanev1alpha2.GardenerShootControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+				Resources: []gardenercorev1beta1.NamedResourceReference{
+					{Name: "credentials", ResourceRef: autoscalingv1.CrossVersionObjectReference{APIVersion: "v1", Kind: "Secret", Name: "extension-secret"}},
+					{Name: "config", ResourceRef: autoscalingv1.CrossVersionObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "extension-config"}},
+					{Name: "identity", ResourceRef: autoscalingv1.CrossVersionObjectReference{APIVersion: "security.gardener.cloud/v1alpha1", Kind: "WorkloadIdentity", Name: "identity"}},
+				},
+				DNS: &gardenercorev1beta1.DNS{
+					Providers: []gardenercorev1beta1.DNSProvider{{
+						SecretName:     ptr.To("dns-secret"),
+						CredentialsRef: &autoscalingv1.CrossVersionObjectReference{APIVersion: "v1", Kind: "Secret", Name: "dns-secret"},
+					}},
+				},
+			},
+		}
+		shoot = &gardenercorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-dev"}}
+	})
+
+	Describe("#ReferencedResources", func() {
+		It("should return the referenced Secrets and ConfigMaps", func() {
+			Expect(ReferencedResources(controlPlane)).To(ConsistOf(
+				ResourceReference{Kind: "Secret", Name: "extension-secret"},
+				ResourceReference{Kind: "ConfigMap", Name: "extension-config"},
+				ResourceReference{Kind: "Secret", Name: "dns-secret"},
+			))
+		})
+	})
+
+	Describe("#ReplicatedResources", func() {
+		It("should return the referenced objects which carry the replicate label", func() {
+			replicatedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "extension-secret",
+				Namespace: "default",
+				Labels:    map[string]string{"controlplane.cluster.x-k8s.io/replicate": "true"},
+			}}
+			replicatedConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "extension-config",
+				Namespace: "default",
+				Labels:    map[string]string{"controlplane.cluster.x-k8s.io/replicate": "true"},
+			}}
+			otherSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "dns-secret", Namespace: "default"}}
+			c := fakeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(replicatedSecret, replicatedConfigMap, otherSecret).Build()
+
+			replicated, err := ReplicatedResources(context.Background(), c, controlPlane)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replicated).To(HaveLen(2))
+			Expect(replicated).To(HaveKeyWithValue(ResourceReference{Kind: "Secret", Name: "extension-secret"}, HaveField("ObjectMeta.Name", "extension-secret")))
+			Expect(replicated).To(HaveKeyWithValue(ResourceReference{Kind: "ConfigMap", Name: "extension-config"}, HaveField("ObjectMeta.Name", "extension-config")))
+		})
+	})
+
+	Describe("#IsReplicated", func() {
+		It("should only accept objects with the replicate label set to true", func() {
+			Expect(IsReplicated(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"controlplane.cluster.x-k8s.io/replicate": "true"}}})).To(BeTrue())
+			Expect(IsReplicated(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"controlplane.cluster.x-k8s.io/replicate": "false"}}})).To(BeFalse())
+			Expect(IsReplicated(&corev1.Secret{})).To(BeFalse())
+			Expect(ReplicatedSelector().String()).To(Equal("controlplane.cluster.x-k8s.io/replicate=true"))
+		})
+	})
+
+	Describe("#SyncReplica", func() {
+		It("should copy the data and set the owner", func() {
+			source := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "extension-secret", Namespace: "default"},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       map[string][]byte{"token": []byte("secret")},
+			}
+			owner := ShootOwner{ObjectKey: client.ObjectKey{Namespace: "default", Name: "bar"}}
+
+			replica := NewReplica(source, shoot)
+			Expect(SyncReplica(replica, source, shoot, owner)).To(BeTrue())
+			Expect(replica).To(Equal(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar-extension-secret",
+					Namespace: "garden-dev",
+					Labels: map[string]string{
+						"controlplane.cluster.x-k8s.io/replica-of-shoot": "bar",
+						"controlplane.cluster.x-k8s.io/owner-hash":       owner.Hash(),
+					},
+					Annotations: map[string]string{
+						"controlplane.cluster.x-k8s.io/owner":      "default/bar",
+						"controlplane.cluster.x-k8s.io/replica-of": "extension-secret",
+					},
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{"token": []byte("secret")},
+			}))
+			Expect(IsReplicaOf(replica, shoot, "extension-secret")).To(BeTrue())
+			Expect(IsReplicaOf(replica, shoot, "other-secret")).To(BeFalse())
+
+			Expect(SyncReplica(replica, source, shoot, owner)).To(BeFalse())
+		})
+	})
+
+	Describe("#ReplaceReplicatedReferences", func() {
+		It("should point the references to the replicas without changing the GardenerShootControlPlane", func() {
+			original := controlPlane.DeepCopy()
+			shoot.Spec.Resources = controlPlane.Spec.Resources
+			shoot.Spec.DNS = controlPlane.Spec.DNS
+
+			ReplaceReplicatedReferences(shoot, sets.New(
+				ResourceReference{Kind: "Secret", Name: "extension-secret"},
+				ResourceReference{Kind: "Secret", Name: "dns-secret"},
+			))
+
+			Expect(ShootReferencedResources(shoot)).To(ConsistOf(
+				ResourceReference{Kind: "Secret", Name: "bar-extension-secret"},
+				ResourceReference{Kind: "ConfigMap", Name: "extension-config"},
+				ResourceReference{Kind: "Secret", Name: "bar-dns-secret"},
+			))
+			Expect(shoot.Spec.DNS.Providers[0].SecretName).To(PointTo(Equal("bar-dns-secret")))
+			Expect(controlPlane).To(Equal(original))
+		})
+	})
+
+	Describe("#OmitPendingReplicas", func() {
+		It("should drop the references to pending replicas without changing the GardenerShootControlPlane", func() {
+			original := controlPlane.DeepCopy()
+			current := shoot.DeepCopy()
+			current.Spec.DNS = &gardenercorev1beta1.DNS{Domain: ptr.To("foo.example.com")}
+			shoot.Spec.Resources = controlPlane.Spec.Resources
+			shoot.Spec.DNS = controlPlane.Spec.DNS
+
+			OmitPendingReplicas(shoot, current, sets.New(
+				ResourceReference{Kind: "Secret", Name: "extension-secret"},
+				ResourceReference{Kind: "Secret", Name: "dns-secret"},
+			))
+
+			Expect(shoot.Spec.Resources).To(Equal(controlPlane.Spec.Resources[1:]))
+			Expect(shoot.Spec.DNS).To(Equal(current.Spec.DNS))
+			Expect(controlPlane).To(Equal(original))
+		})
+
+		It("should keep the references if no replica is pending", func() {
+			shoot.Spec.Resources = controlPlane.Spec.Resources
+			shoot.Spec.DNS = controlPlane.Spec.DNS
+
+			OmitPendingReplicas(shoot, shoot.DeepCopy(), sets.New(ResourceReference{Kind: "ConfigMap", Name: "other-config"}))
+
+			Expect(shoot.Spec.Resources).To(Equal(controlPlane.Spec.Resources))
+			Expect(shoot.Spec.DNS).To(Equal(controlPlane.Spec.DNS))
+		})
+	})
+
+	Describe("#RestoreReplicatedReferences", func() {
+		It("should point the references to the replicas back to the replicated objects", func() {
+			former := controlPlane.DeepCopy()
+			controlPlane.Spec.Resources[0].ResourceRef.Name = "bar-extension-secret"
+			controlPlane.Spec.Resources[1].ResourceRef.Name = "other-config"
+			controlPlane.Spec.DNS.Providers[0].SecretName = ptr.To("bar-dns-secret")
+			controlPlane.Spec.DNS.Providers[0].CredentialsRef.Name = "bar-dns-secret"
+
+			RestoreReplicatedReferences(controlPlane, former, "bar")
+
+			Expect(ReferencedResources(controlPlane)).To(ConsistOf(
+				ResourceReference{Kind: "Secret", Name: "extension-secret"},
+				ResourceReference{Kind: "ConfigMap", Name: "other-config"},
+				ResourceReference{Kind: "Secret", Name: "dns-secret"},
+			))
+		})
+	})
+})
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// ClusterNameField is the field index which maps GardenerShootControlPlanes, GardenerShootClusters and
+// GardenerWorkerPools to the name of the CAPI Cluster they belong to.
+const ClusterNameField = "capi.clusterName"
+
+// ShootOwner references the CAPI Cluster which owns a Shoot.
+type ShootOwner struct {
+	// ClusterName is the name of the logical cluster, it is only set when running against kcp.
+	ClusterName multicluster.ClusterName
+	client.ObjectKey
+}
+
+// String returns the value of the ShootOwnerAnnotation for the owner.
+func (o ShootOwner) String() string {
+	if o.ClusterName == "" {
+		return o.Namespace + "/" + o.Name
+	}
+	return string(o.ClusterName) + "/" + o.Namespace + "/" + o.Name
+}
+
+// Hash returns the value of the ShootOwnerHashLabel for the owner.
+func (o ShootOwner) Hash() string {
+	// Label values must not be longer than 63 characters.
+	return utils.ComputeSHA256Hex([]byte(o.String()))[:32]
+}
+
+// ParseShootOwner parses the value of the ShootOwnerAnnotation.
+func ParseShootOwner(value string) (ShootOwner, error) {
+	parts := strings.Split(value, "/")
+	for _, part := range parts {
+		if part == "" {
+			return ShootOwner{}, fmt.Errorf("invalid Shoot owner %q", value)
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return ShootOwner{ObjectKey: client.ObjectKey{Namespace: parts[0], Name: parts[1]}}, nil
+	case 3:
+		return ShootOwner{ClusterName: multicluster.ClusterName(parts[0]), ObjectKey: client.ObjectKey{Namespace: parts[1], Name: parts[2]}}, nil
+	default:
+		return ShootOwner{}, fmt.Errorf("invalid Shoot owner %q", value)
+	}
+}
+
+// ManagedShootSelector returns a selector for the Shoots which carry the ShootOwnerHashLabel, i.e. which are managed by
+// the provider.
+func ManagedShootSelector() labels.Selector {
+	return labels.NewSelector().Add(utils.MustNewRequirement(controlplanev1alpha2.ShootOwnerHashLabel, selection.Exists))
+}
+
+// GetShoot reads the Shoot with the given key from the garden cache. The cache only contains Shoots carrying the
+// ShootOwnerHashLabel, and only the Shoots of the shards of the replica when sharding is enabled. Shoots created by
+// earlier versions or with an outdated ShootShardLabel are read with the given API reader until they are labeled. The
+// API reader is optional.
+func GetShoot(ctx context.Context, gardenerClient, gardenerAPIReader client.Reader, key client.ObjectKey, shoot *gardenercorev1beta1.Shoot) error {
+	err := gardenerClient.Get(ctx, key, shoot)
+	if !apierrors.IsNotFound(err) || gardenerAPIReader == nil {
+		return err
+	}
+	return gardenerAPIReader.Get(ctx, key, shoot)
+}
+
+// GetShootOwner returns the owner referenced by the ShootOwnerAnnotation of the given Shoot. Returns false if the
+// annotation is not set.
+func GetShootOwner(shoot *gardenercorev1beta1.Shoot) (ShootOwner, bool, error) {
+	value, ok := shoot.Annotations[controlplanev1alpha2.ShootOwnerAnnotation]
+	if !ok {
+		return ShootOwner{}, false, nil
+	}
+	owner, err := ParseShootOwner(value)
+	return owner, true, err
+}
+
+// SetShootOwner sets the ShootOwnerAnnotation and the ShootOwnerHashLabel of the given Shoot.
+func SetShootOwner(shoot *gardenercorev1beta1.Shoot, owner ShootOwner) {
+	metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, controlplanev1alpha2.ShootOwnerAnnotation, owner.String())
+	metav1.SetMetaDataLabel(&shoot.ObjectMeta, controlplanev1alpha2.ShootOwnerHashLabel, owner.Hash())
+}
+
+// ShootOwnerShardKey returns the logical cluster and the namespace of the owner of the given Shoot, which determine the
+// shard of the Shoot. Returns false if the Shoot has no valid owner.
+func ShootOwnerShardKey(obj client.Object) (multicluster.ClusterName, string, bool) {
+	shoot, ok := obj.(*gardenercorev1beta1.Shoot)
+	if !ok {
+		return "", "", false
+	}
+	owner, ok, err := GetShootOwner(shoot)
+	if err != nil || !ok {
+		return "", "", false
+	}
+	return owner.ClusterName, owner.Namespace, true
+}
+
+// ClusterNameIndexFunc returns the name of the CAPI Cluster of the given object. The name is taken from the cluster
+// name label, which CAPI sets on the infrastructure objects of MachinePools, or from the owner reference of the Cluster.
+func ClusterNameIndexFunc(obj client.Object) []string {
+	if clusterName, ok := obj.GetLabels()[clusterv1beta2.ClusterNameLabel]; ok && clusterName != "" {
+		return []string{clusterName}
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "Cluster" && ref.APIVersion == clusterv1beta2.GroupVersion.String() {
+			return []string{ref.Name}
+		}
+	}
+	return nil
+}
+
+// AddClusterNameIndexes adds the ClusterNameField index for GardenerShootControlPlanes, GardenerShootClusters and
+// GardenerWorkerPools.
+func AddClusterNameIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, obj := range []client.Object{
+		&controlplanev1alpha2.GardenerShootControlPlane{},
+		&infrastructurev1alpha2.GardenerShootCluster{},
+		&infrastructurev1alpha2.GardenerWorkerPool{},
+	} {
+		if err := indexer.IndexField(ctx, obj, ClusterNameField, ClusterNameIndexFunc); err != nil {
+			return fmt.Errorf("failed to add index %s for %T: %w", ClusterNameField, obj, err)
+		}
+	}
+	return nil
+}
+
+// MapShootToOwnedObjects returns reconcile requests for all objects of the given list type which belong to the CAPI
+// Cluster referenced by the ShootOwnerAnnotation of the given Shoot. It requires the ClusterNameField index.
+func MapShootToOwnedObjects(ctx context.Context, mgr mcmanager.Manager, obj client.Object, list client.ObjectList) []mcreconcile.Request {
+	log := runtimelog.FromContext(ctx).WithValues("shoot", client.ObjectKeyFromObject(obj))
+
+	shoot, ok := obj.(*gardenercorev1beta1.Shoot)
+	if !ok {
+		log.Error(fmt.Errorf("could not assert object to Shoot"), "")
+		return nil
+	}
+	owner, ok, err := GetShootOwner(shoot)
+	if err != nil {
+		log.Error(err, "Failed to parse owner of Shoot")
+		return nil
+	}
+	if !ok {
+		log.V(1).Info("Shoot has no owner annotation, ignoring it")
+		return nil
+	}
+
+	cl, err := mgr.GetCluster(ctx, owner.ClusterName)
+	if err != nil {
+		log.Error(err, "Failed to get cluster of Shoot owner", "owner", owner.String())
+		return nil
+	}
+	if err := cl.GetCache().List(ctx, list, client.InNamespace(owner.Namespace), client.MatchingFields{ClusterNameField: owner.Name}); err != nil {
+		log.Error(err, "Failed to list objects of Shoot owner", "owner", owner.String())
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "Failed to extract objects of Shoot owner", "owner", owner.String())
+		return nil
+	}
+
+	requests := make([]mcreconcile.Request, 0, len(items))
+	for _, item := range items {
+		o, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		requests = append(requests, mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)},
+			ClusterName: owner.ClusterName,
+		})
+	}
+	return requests
+}
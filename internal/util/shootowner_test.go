@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Shoot owner", func() {
+	DescribeTable("should round-trip the owner annotation",
+		func(owner ShootOwner, value string) {
+			Expect(owner.String()).To(Equal(value))
+			Expect(ParseShootOwner(value)).To(Equal(owner))
+		},
+		Entry("without logical cluster", ShootOwner{ObjectKey: client.ObjectKey{Namespace: "default", Name: "foo"}}, "default/foo"),
+		Entry("with logical cluster", ShootOwner{ClusterName: "2x6vp4r8", ObjectKey: client.ObjectKey{Namespace: "default", Name: "foo"}}, "2x6vp4r8/default/foo"),
+	)
+
+	DescribeTable("should reject invalid owner annotations",
+		func(value string) {
+			_, err := ParseShootOwner(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("name only", "foo"),
+		Entry("empty namespace", "/foo"),
+		Entry("too many parts", "a/b/c/d"),
+	)
+
+	It("should compute a hash which is a valid label value", func() {
+		owner := ShootOwner{ClusterName: "2x6vp4r8", ObjectKey: client.ObjectKey{
+			Namespace: "a-namespace-with-a-rather-long-name-to-exceed-the-limits",
+			Name:      "a-cluster-with-a-rather-long-name-to-exceed-the-limits",
+		}}
+		Expect(validation.IsValidLabelValue(owner.Hash())).To(BeEmpty())
+		Expect(owner.Hash()).NotTo(Equal(ShootOwner{ObjectKey: owner.ObjectKey}.Hash()))
+	})
+
+	It("should set and get the owner of a Shoot", func() {
+		shoot := &gardenercorev1beta1.Shoot{}
+		owner := ShootOwner{ObjectKey: client.ObjectKey{Namespace: "default", Name: "foo"}}
+
+		SetShootOwner(shoot, owner)
+
+		Expect(shoot.Annotations).To(HaveKeyWithValue("controlplane.cluster.x-k8s.io/owner", "default/foo"))
+		Expect(shoot.Labels).To(HaveKeyWithValue("controlplane.cluster.x-k8s.io/owner-hash", owner.Hash()))
+		actual, ok, err := GetShootOwner(shoot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(actual).To(Equal(owner))
+	})
+
+	Describe("#ClusterNameIndexFunc", func() {
+		It("should prefer the cluster name label", func() {
+			workerPool := &infrastructurev1alpha2.GardenerWorkerPool{ObjectMeta: metav1.ObjectMeta{
+				Labels:          map[string]string{clusterv1beta2.ClusterNameLabel: "foo"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: clusterv1beta2.GroupVersion.String(), Kind: "MachinePool", Name: "bar"}},
+			}}
+			Expect(ClusterNameIndexFunc(workerPool)).To(ConsistOf("foo"))
+		})
+
+		It("should fall back to the owner reference of the Cluster", func() {
+			infraCluster := &infrastructurev1alpha2.GardenerShootCluster{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: clusterv1beta2.GroupVersion.String(), Kind: "Cluster", Name: "foo"}},
+			}}
+			Expect(ClusterNameIndexFunc(infraCluster)).To(ConsistOf("foo"))
+		})
+
+		It("should return nothing for objects without a Cluster", func() {
+			Expect(ClusterNameIndexFunc(&infrastructurev1alpha2.GardenerShootCluster{})).To(BeEmpty())
+		})
+	})
+
+	Describe("#GetShoot", func() {
+		var (
+			ctx            context.Context
+			key            client.ObjectKey
+			gardenerClient client.Client
+			apiReader      client.Reader
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			key = client.ObjectKey{Namespace: "garden-foo", Name: "foo"}
+			// The cache only contains labeled Shoots, the API reader also the Shoots of earlier versions.
+			gardenerClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+			apiReader = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(&gardenercorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			}).Build()
+		})
+
+		It("should fall back to the API reader for Shoots which are not cached", func() {
+			shoot := &gardenercorev1beta1.Shoot{}
+			Expect(GetShoot(ctx, gardenerClient, apiReader, key, shoot)).To(Succeed())
+			Expect(shoot.Name).To(Equal("foo"))
+		})
+
+		It("should report that the Shoot does not exist without API reader", func() {
+			err := GetShoot(ctx, gardenerClient, nil, key, &gardenercorev1beta1.Shoot{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
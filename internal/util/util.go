@@ -9,11 +9,13 @@ import (
 
 	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardenerv1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capiutil "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -21,12 +23,12 @@ import (
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcsingle "sigs.k8s.io/multicluster-runtime/providers/single"
 
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 )
 
 // ShootNameFromCAPIResources generates a NamespacedName for the Shoot resource based on the provided CAPI resources.
-func ShootNameFromCAPIResources(cluster clusterv1beta2.Cluster, controlPlane controlplanev1alpha1.GardenerShootControlPlane) types.NamespacedName {
+func ShootNameFromCAPIResources(cluster clusterv1beta2.Cluster, controlPlane controlplanev1alpha2.GardenerShootControlPlane) types.NamespacedName {
 	return types.NamespacedName{
 		Name:      cluster.Name,
 		Namespace: controlPlane.Spec.ProjectNamespace,
@@ -36,56 +38,27 @@ func ShootNameFromCAPIResources(cluster clusterv1beta2.Cluster, controlPlane con
 // ShootFromCAPIResources creates a new Shoot resource based on the provided CAPI resources.
 func ShootFromCAPIResources(
 	capiCluster clusterv1beta2.Cluster,
-	controlPlane controlplanev1alpha1.GardenerShootControlPlane,
-	infraCluster infrastructurev1alpha1.GardenerShootCluster,
-	workerPools []infrastructurev1alpha1.GardenerWorkerPool,
+	controlPlane controlplanev1alpha2.GardenerShootControlPlane,
+	infraCluster infrastructurev1alpha2.GardenerShootCluster,
+	workerPools []infrastructurev1alpha2.GardenerWorkerPool,
 ) *gardenercorev1beta1.Shoot {
 	namespacedName := ShootNameFromCAPIResources(capiCluster, controlPlane)
 
-	workerConfigs := make([]gardenercorev1beta1.Worker, 0, len(workerPools))
-	for _, pool := range workerPools {
-		workerConfigs = append(workerConfigs, *WorkerConfigFromWorkerPool(&pool))
-	}
-
-	providerConfig := gardenercorev1beta1.Provider{
-		Type:                 controlPlane.Spec.Provider.Type,
-		ControlPlaneConfig:   controlPlane.Spec.Provider.ControlPlaneConfig,
-		InfrastructureConfig: controlPlane.Spec.Provider.InfrastructureConfig,
-		Workers:              workerConfigs,
-		WorkersSettings:      controlPlane.Spec.Provider.WorkersSettings,
-	}
-
-	return &gardenercorev1beta1.Shoot{
+	shoot := &gardenercorev1beta1.Shoot{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      namespacedName.Name,
 			Namespace: namespacedName.Namespace,
 		},
-		Spec: gardenercorev1beta1.ShootSpec{
-			Addons:                 controlPlane.Spec.Addons,
-			DNS:                    controlPlane.Spec.DNS,
-			Extensions:             controlPlane.Spec.Extensions,
-			Hibernation:            infraCluster.Spec.Hibernation,
-			Kubernetes:             controlPlane.Spec.Kubernetes,
-			Networking:             controlPlane.Spec.Networking,
-			Maintenance:            infraCluster.Spec.Maintenance,
-			Monitoring:             controlPlane.Spec.Monitoring,
-			Provider:               providerConfig,
-			Purpose:                controlPlane.Spec.Purpose,
-			Region:                 infraCluster.Spec.Region,
-			SecretBindingName:      controlPlane.Spec.SecretBindingName,
-			SeedName:               infraCluster.Spec.SeedName,
-			SeedSelector:           infraCluster.Spec.SeedSelector,
-			Resources:              controlPlane.Spec.Resources,
-			Tolerations:            controlPlane.Spec.Tolerations,
-			ExposureClassName:      controlPlane.Spec.ExposureClassName,
-			SystemComponents:       controlPlane.Spec.SystemComponents,
-			ControlPlane:           controlPlane.Spec.ControlPlane,
-			SchedulerName:          controlPlane.Spec.SchedulerName,
-			CloudProfile:           controlPlane.Spec.CloudProfile,
-			CredentialsBindingName: controlPlane.Spec.CredentialsBindingName,
-			AccessRestrictions:     controlPlane.Spec.AccessRestrictions,
-		},
 	}
+	copyMappedFields(&shoot.Spec, &controlPlane.Spec, ShootSpecFieldMappings, KindGardenerShootControlPlane, nil)
+	copyMappedFields(&shoot.Spec, &infraCluster.Spec, ShootSpecFieldMappings, KindGardenerShootCluster, nil)
+
+	shoot.Spec.Provider.Workers = make([]gardenercorev1beta1.Worker, 0, len(workerPools))
+	for _, pool := range workerPools {
+		shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, *WorkerConfigFromWorkerPool(&pool))
+	}
+
+	return shoot
 }
 
 var (
@@ -115,171 +88,67 @@ func syncAnnotations(source, target map[string]string, allowedKeys []string) map
 }
 
 // SyncShootSpecFromGSCP syncs the Shoot spec from the GardenerShootControlPlane spec.
-func SyncShootSpecFromGSCP(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha1.GardenerShootControlPlane) {
+func SyncShootSpecFromGSCP(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha2.GardenerShootControlPlane) {
 	shoot.Annotations = syncAnnotations(controlPlane.Annotations, shoot.Annotations, AnnotationAllowList)
-
-	shoot.Spec.Addons = controlPlane.Spec.Addons //nolint:staticcheck
-	shoot.Spec.DNS = controlPlane.Spec.DNS
-	shoot.Spec.Extensions = controlPlane.Spec.Extensions
-	shoot.Spec.Kubernetes = controlPlane.Spec.Kubernetes
-	shoot.Spec.Networking = controlPlane.Spec.Networking
-	shoot.Spec.Monitoring = controlPlane.Spec.Monitoring
-	SyncShootProviderFromGSCP(shoot, controlPlane)
-	shoot.Spec.Purpose = controlPlane.Spec.Purpose
-	// TODO(tobschli): Remove, once SecretBindingName is removed from Gardener
-	shoot.Spec.SecretBindingName = controlPlane.Spec.SecretBindingName //nolint:staticcheck
-	// Let's not allow updates on SeedName as this causes the reconciler to not be able to update anything
-	// shoot.Spec.SeedName = controlPlane.Spec.SeedName
-	shoot.Spec.Resources = controlPlane.Spec.Resources
-	shoot.Spec.Tolerations = controlPlane.Spec.Tolerations
-	shoot.Spec.ExposureClassName = controlPlane.Spec.ExposureClassName
-	shoot.Spec.SystemComponents = controlPlane.Spec.SystemComponents
-	shoot.Spec.ControlPlane = controlPlane.Spec.ControlPlane
-	shoot.Spec.SchedulerName = controlPlane.Spec.SchedulerName
+	copyMappedFields(&shoot.Spec, &controlPlane.Spec, ShootSpecFieldMappings, KindGardenerShootControlPlane, isCreateOnly)
 }
 
 // SyncGSCPSpecFromShoot syncs the GardenerShootControlPlane spec from the Shoot spec.
-func SyncGSCPSpecFromShoot(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha1.GardenerShootControlPlane) {
+func SyncGSCPSpecFromShoot(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha2.GardenerShootControlPlane) {
 	controlPlane.Annotations = syncAnnotations(shoot.Annotations, controlPlane.Annotations, AnnotationAllowList)
-
-	controlPlane.Spec.Addons = shoot.Spec.Addons //nolint:staticcheck
-	controlPlane.Spec.DNS = shoot.Spec.DNS
-	controlPlane.Spec.Extensions = shoot.Spec.Extensions
-	controlPlane.Spec.Kubernetes = shoot.Spec.Kubernetes
-	controlPlane.Spec.Networking = shoot.Spec.Networking
-	controlPlane.Spec.Monitoring = shoot.Spec.Monitoring
-	SyncGSCPProviderFromShoot(shoot, controlPlane)
-	controlPlane.Spec.Purpose = shoot.Spec.Purpose
-	// TODO(tobschli): Remove, once SecretBindingName is removed from Gardener
-	controlPlane.Spec.SecretBindingName = shoot.Spec.SecretBindingName //nolint:staticcheck
-	controlPlane.Spec.Resources = shoot.Spec.Resources
-	controlPlane.Spec.Tolerations = shoot.Spec.Tolerations
-	controlPlane.Spec.ExposureClassName = shoot.Spec.ExposureClassName
-	controlPlane.Spec.SystemComponents = shoot.Spec.SystemComponents
-	controlPlane.Spec.ControlPlane = shoot.Spec.ControlPlane
-	controlPlane.Spec.SchedulerName = shoot.Spec.SchedulerName
-	controlPlane.Spec.CredentialsBindingName = shoot.Spec.CredentialsBindingName
-	controlPlane.Spec.AccessRestrictions = shoot.Spec.AccessRestrictions
+	copyMappedFields(&controlPlane.Spec, &shoot.Spec, ShootSpecFieldMappings, KindGardenerShootControlPlane, nil)
 }
 
 // SyncShootSpecFromCluster syncs the Shoot spec from the GardenerShootCluster spec.
-func SyncShootSpecFromCluster(shoot *gardenercorev1beta1.Shoot, infraCluster *infrastructurev1alpha1.GardenerShootCluster) {
-	shoot.Spec.Hibernation = infraCluster.Spec.Hibernation
-	shoot.Spec.Maintenance = infraCluster.Spec.Maintenance
-	shoot.Spec.Region = infraCluster.Spec.Region
-	shoot.Spec.SeedName = infraCluster.Spec.SeedName
-	shoot.Spec.SeedSelector = infraCluster.Spec.SeedSelector
+func SyncShootSpecFromCluster(shoot *gardenercorev1beta1.Shoot, infraCluster *infrastructurev1alpha2.GardenerShootCluster) {
+	copyMappedFields(&shoot.Spec, &infraCluster.Spec, ShootSpecFieldMappings, KindGardenerShootCluster, isCreateOnly)
 }
 
 // SyncClusterSpecFromShoot syncs the GardenerShootCluster spec from the Shoot spec.
-func SyncClusterSpecFromShoot(shoot *gardenercorev1beta1.Shoot, infraCluster *infrastructurev1alpha1.GardenerShootCluster) {
-	infraCluster.Spec.Hibernation = shoot.Spec.Hibernation
-	infraCluster.Spec.Maintenance = shoot.Spec.Maintenance
-	infraCluster.Spec.Region = shoot.Spec.Region
-	infraCluster.Spec.SeedName = shoot.Spec.SeedName
-	infraCluster.Spec.SeedSelector = shoot.Spec.SeedSelector
-}
-
-// SyncShootProviderFromGSCP syncs the Shoot provider configuration from the GardenerShootControlPlane provider configuration.
-func SyncShootProviderFromGSCP(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha1.GardenerShootControlPlane) {
-	shoot.Spec.Provider.Type = controlPlane.Spec.Provider.Type
-	shoot.Spec.Provider.ControlPlaneConfig = controlPlane.Spec.Provider.ControlPlaneConfig
-	shoot.Spec.Provider.InfrastructureConfig = controlPlane.Spec.Provider.InfrastructureConfig
-	shoot.Spec.Provider.WorkersSettings = controlPlane.Spec.Provider.WorkersSettings
-}
-
-// SyncGSCPProviderFromShoot syncs the GardenerShootControlPlane provider configuration from the Shoot provider configuration.
-func SyncGSCPProviderFromShoot(shoot *gardenercorev1beta1.Shoot, controlPlane *controlplanev1alpha1.GardenerShootControlPlane) {
-	controlPlane.Spec.Provider.Type = shoot.Spec.Provider.Type
-	controlPlane.Spec.Provider.ControlPlaneConfig = shoot.Spec.Provider.ControlPlaneConfig
-	controlPlane.Spec.Provider.InfrastructureConfig = shoot.Spec.Provider.InfrastructureConfig
-	controlPlane.Spec.Provider.WorkersSettings = shoot.Spec.Provider.WorkersSettings
+func SyncClusterSpecFromShoot(shoot *gardenercorev1beta1.Shoot, infraCluster *infrastructurev1alpha2.GardenerShootCluster) {
+	copyMappedFields(&infraCluster.Spec, &shoot.Spec, ShootSpecFieldMappings, KindGardenerShootCluster, nil)
 }
 
 // WorkerConfigFromWorkerPool converts a GardenerWorkerPool to a GardenerWorker configuration.
-func WorkerConfigFromWorkerPool(workerPool *infrastructurev1alpha1.GardenerWorkerPool) *gardenercorev1beta1.Worker {
-	return &gardenercorev1beta1.Worker{
+func WorkerConfigFromWorkerPool(workerPool *infrastructurev1alpha2.GardenerWorkerPool) *gardenercorev1beta1.Worker {
+	worker := &gardenercorev1beta1.Worker{
 		Name: workerPool.Name,
-
-		Annotations:                      workerPool.Spec.Annotations,
-		CABundle:                         workerPool.Spec.CABundle,
-		CRI:                              workerPool.Spec.CRI,
-		Kubernetes:                       workerPool.Spec.Kubernetes,
-		Labels:                           workerPool.Spec.Labels,
-		Machine:                          workerPool.Spec.Machine,
-		Maximum:                          workerPool.Spec.Maximum,
-		Minimum:                          workerPool.Spec.Minimum,
-		MaxSurge:                         workerPool.Spec.MaxSurge,
-		MaxUnavailable:                   workerPool.Spec.MaxUnavailable,
-		ProviderConfig:                   workerPool.Spec.ProviderConfig,
-		Taints:                           workerPool.Spec.Taints,
-		Volume:                           workerPool.Spec.Volume,
-		DataVolumes:                      workerPool.Spec.DataVolumes,
-		KubeletDataVolumeName:            workerPool.Spec.KubeletDataVolumeName,
-		Zones:                            workerPool.Spec.Zones,
-		SystemComponents:                 workerPool.Spec.SystemComponents,
-		MachineControllerManagerSettings: workerPool.Spec.MachineControllerManagerSettings,
-		Sysctls:                          workerPool.Spec.Sysctls,
-		ClusterAutoscaler:                workerPool.Spec.ClusterAutoscaler,
-		Priority:                         workerPool.Spec.Priority,
-		UpdateStrategy:                   workerPool.Spec.UpdateStrategy,
-		ControlPlane:                     workerPool.Spec.ControlPlane,
 	}
+	copyMappedFields(worker, &workerPool.Spec, WorkerFieldMappings, KindGardenerWorkerPool, nil)
+	return worker
 }
 
 // SyncShootSpecFromWorkerPool syncs the Shoot spec from the GardenerWorkerPool spec.
-func SyncShootSpecFromWorkerPool(shoot *gardenercorev1beta1.Shoot, workerPool *infrastructurev1alpha1.GardenerWorkerPool) {
+func SyncShootSpecFromWorkerPool(shoot *gardenercorev1beta1.Shoot, workerPool *infrastructurev1alpha2.GardenerWorkerPool) {
 	workers := shoot.Spec.Provider.Workers
 	for i, worker := range workers {
 		if worker.Name != workerPool.Name {
 			continue
 		}
-		workers[i] = *WorkerConfigFromWorkerPool(workerPool)
+		copyMappedFields(&workers[i], &workerPool.Spec, WorkerFieldMappings, KindGardenerWorkerPool, isCreateOnly)
 	}
 }
 
 // SyncWorkerPoolFromShootSpec syncs the GardenerWorkerPool spec from the Shoot spec.
-func SyncWorkerPoolFromShootSpec(shoot *gardenercorev1beta1.Shoot, workerPool *infrastructurev1alpha1.GardenerWorkerPool) {
+func SyncWorkerPoolFromShootSpec(shoot *gardenercorev1beta1.Shoot, workerPool *infrastructurev1alpha2.GardenerWorkerPool) {
 	workers := shoot.Spec.Provider.Workers
-	for _, worker := range workers {
+	for i, worker := range workers {
 		if worker.Name != workerPool.Name {
 			continue
 		}
-		workerPool.Spec.Annotations = worker.Annotations
-		workerPool.Spec.CABundle = worker.CABundle
-		workerPool.Spec.CRI = worker.CRI
-		workerPool.Spec.Kubernetes = worker.Kubernetes
-		workerPool.Spec.Labels = worker.Labels
-		workerPool.Spec.Machine = worker.Machine
-		workerPool.Spec.Maximum = worker.Maximum
-		workerPool.Spec.Minimum = worker.Minimum
-		workerPool.Spec.MaxSurge = worker.MaxSurge
-		workerPool.Spec.MaxUnavailable = worker.MaxUnavailable
-		workerPool.Spec.ProviderConfig = worker.ProviderConfig
-		workerPool.Spec.Taints = worker.Taints
-		workerPool.Spec.Volume = worker.Volume
-		workerPool.Spec.DataVolumes = worker.DataVolumes
-		workerPool.Spec.KubeletDataVolumeName = worker.KubeletDataVolumeName
-		workerPool.Spec.Zones = worker.Zones
-		workerPool.Spec.SystemComponents = worker.SystemComponents
-		workerPool.Spec.MachineControllerManagerSettings = worker.MachineControllerManagerSettings
-		workerPool.Spec.Sysctls = worker.Sysctls
-		workerPool.Spec.ClusterAutoscaler = worker.ClusterAutoscaler
-		workerPool.Spec.Priority = worker.Priority
-		workerPool.Spec.UpdateStrategy = worker.UpdateStrategy
-		workerPool.Spec.ControlPlane = worker.ControlPlane
+		copyMappedFields(&workerPool.Spec, &workers[i], WorkerFieldMappings, KindGardenerWorkerPool, nil)
 	}
 }
 
 // ShootFromCluster retrieves the Shoot resource from the Gardener API based on the provided Cluster and ControlPlane references.
-func ShootFromCluster(ctx context.Context, gardenerClient client.Client, client client.Client, cluster *clusterv1beta2.Cluster) (*gardenercorev1beta1.Shoot, error) {
+func ShootFromCluster(ctx context.Context, gardenerClient client.Client, gardenerAPIReader client.Reader, client client.Client, cluster *clusterv1beta2.Cluster) (*gardenercorev1beta1.Shoot, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "shootFromCluster")
 
 	if !cluster.Spec.ControlPlaneRef.IsDefined() {
 		log.Info("ControlPlaneRef is not defined, do nothing")
 		return nil, nil
 	}
-	controlPlane := &controlplanev1alpha1.GardenerShootControlPlane{}
+	controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
 	if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, controlPlane); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("ControlPlane not found")
@@ -290,7 +159,7 @@ func ShootFromCluster(ctx context.Context, gardenerClient client.Client, client
 	}
 
 	shoot := &gardenercorev1beta1.Shoot{}
-	if err := gardenerClient.Get(ctx, ShootNameFromCAPIResources(*cluster, *controlPlane), shoot); err != nil {
+	if err := GetShoot(ctx, gardenerClient, gardenerAPIReader, ShootNameFromCAPIResources(*cluster, *controlPlane), shoot); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
 		}
@@ -301,7 +170,7 @@ func ShootFromCluster(ctx context.Context, gardenerClient client.Client, client
 }
 
 // GetMachinePoolForWorkerPool retrieves the MachinePool that owns the given GardenerWorkerPool.
-func GetMachinePoolForWorkerPool(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha1.GardenerWorkerPool) (*clusterv1beta2.MachinePool, error) {
+func GetMachinePoolForWorkerPool(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool) (*clusterv1beta2.MachinePool, error) {
 	log := runtimelog.FromContext(ctx).WithValues("operation", "GetMachinePoolForWorkerPool")
 	machinePool := &clusterv1beta2.MachinePool{}
 	for _, owner := range workerPool.OwnerReferences {
@@ -321,26 +190,121 @@ func GetMachinePoolForWorkerPool(ctx context.Context, c client.Client, workerPoo
 	return machinePool, nil
 }
 
+// ClusterForInfraCluster returns the Cluster that references the given GardenerShootCluster as its infrastructure.
+// The owner reference is preferred; as it is only set by the Cluster controller after the GardenerShootCluster has been
+// created, the Clusters in the namespace are searched as a fallback. Returns nil if no Cluster references the object yet.
+func ClusterForInfraCluster(ctx context.Context, c client.Client, infraCluster *infrastructurev1alpha2.GardenerShootCluster) (*clusterv1beta2.Cluster, error) {
+	cluster, err := capiutil.GetOwnerCluster(ctx, c, infraCluster.ObjectMeta)
+	if err != nil || cluster != nil {
+		return cluster, err
+	}
+
+	clusters := &clusterv1beta2.ClusterList{}
+	if err := c.List(ctx, clusters, client.InNamespace(infraCluster.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters.Items {
+		ref := cluster.Spec.InfrastructureRef
+		if ref.Name == infraCluster.Name && ref.GroupKind() == infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerShootCluster").GroupKind() {
+			return &cluster, nil
+		}
+	}
+	return nil, nil
+}
+
+// MachinePoolForWorkerPool returns the MachinePool that references the given GardenerWorkerPool as its infrastructure.
+// Like ClusterForInfraCluster, it falls back to searching the MachinePools in the namespace if the owner reference has
+// not been set yet. Returns nil if no MachinePool references the object yet.
+func MachinePoolForWorkerPool(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool) (*clusterv1beta2.MachinePool, error) {
+	machinePool, err := GetMachinePoolForWorkerPool(ctx, c, workerPool)
+	if err != nil || (machinePool != nil && machinePool.Name != "") {
+		return machinePool, err
+	}
+
+	machinePools := &clusterv1beta2.MachinePoolList{}
+	if err := c.List(ctx, machinePools, client.InNamespace(workerPool.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, machinePool := range machinePools.Items {
+		ref := machinePool.Spec.Template.Spec.InfrastructureRef
+		if ref.Name == workerPool.Name && ref.GroupKind() == infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind() {
+			return &machinePool, nil
+		}
+	}
+	return nil, nil
+}
+
+// ClusterForWorkerPool returns the Cluster of the MachinePool that references the given GardenerWorkerPool.
+// Returns nil if no MachinePool or Cluster references the object yet, see MachinePoolForWorkerPool.
+func ClusterForWorkerPool(ctx context.Context, c client.Client, workerPool *infrastructurev1alpha2.GardenerWorkerPool) (*clusterv1beta2.Cluster, error) {
+	machinePool, err := MachinePoolForWorkerPool(ctx, c, workerPool)
+	if err != nil || machinePool == nil || machinePool.Spec.ClusterName == "" {
+		return nil, err
+	}
+
+	cluster := &clusterv1beta2.Cluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: workerPool.Namespace, Name: machinePool.Spec.ClusterName}, cluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return cluster, nil
+}
+
+// ControlPlaneForCluster returns the GardenerShootControlPlane referenced by the given Cluster.
+// Returns nil if the Cluster does not reference a control plane yet, or if it does not exist.
+func ControlPlaneForCluster(ctx context.Context, c client.Client, cluster *clusterv1beta2.Cluster) (*controlplanev1alpha2.GardenerShootControlPlane, error) {
+	if !cluster.Spec.ControlPlaneRef.IsDefined() {
+		return nil, nil
+	}
+	controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, controlPlane); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return controlPlane, nil
+}
+
+// InfraClusterForCluster returns the GardenerShootCluster referenced by the given Cluster.
+// Returns nil if the Cluster does not reference an infrastructure cluster yet, or if it does not exist.
+func InfraClusterForCluster(ctx context.Context, c client.Client, cluster *clusterv1beta2.Cluster) (*infrastructurev1alpha2.GardenerShootCluster, error) {
+	if !cluster.Spec.InfrastructureRef.IsDefined() {
+		return nil, nil
+	}
+	infraCluster := &infrastructurev1alpha2.GardenerShootCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.InfrastructureRef.Name}, infraCluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return infraCluster, nil
+}
+
 // IsShootSpecEqual checks if the original and updated GardenerShoot specs and annotations are equal.
 func IsShootSpecEqual(original, updated *gardenercorev1beta1.Shoot) bool {
 	return apiequality.Semantic.DeepEqual(original.Spec, updated.Spec) && apiequality.Semantic.DeepEqual(original.Annotations, updated.Annotations)
 }
 
 // IsClusterSpecEqual checks if the original and updated GardenerShootCluster specs are equal.
-func IsClusterSpecEqual(original, updated *infrastructurev1alpha1.GardenerShootCluster) bool {
+func IsClusterSpecEqual(original, updated *infrastructurev1alpha2.GardenerShootCluster) bool {
 	return apiequality.Semantic.DeepEqual(original.Spec, updated.Spec)
 }
 
 // IsControlPlaneSpecEqual checks if the original and updated GardenerShootControlPlane specs and annotations are equal.
-func IsControlPlaneSpecEqual(original, updated *controlplanev1alpha1.GardenerShootControlPlane) bool {
+func IsControlPlaneSpecEqual(original, updated *controlplanev1alpha2.GardenerShootControlPlane) bool {
 	return apiequality.Semantic.DeepEqual(original.Spec, updated.Spec) && apiequality.Semantic.DeepEqual(original.Annotations, updated.Annotations)
 }
 
 // IsWorkerPoolSpecEqual checks if the original and updated GardenerWorkerPool specs are equal.
-func IsWorkerPoolSpecEqual(original, updated *infrastructurev1alpha1.GardenerWorkerPool) bool {
+func IsWorkerPoolSpecEqual(original, updated *infrastructurev1alpha2.GardenerWorkerPool) bool {
 	return apiequality.Semantic.DeepEqual(original.Spec, updated.Spec)
 }
 
+// IsNodeReady returns true if the Ready condition of the given node is true.
+func IsNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // ProviderWithRun is an interface that extends the multicluster.Provider interface that expects to be runnable.
 type ProviderWithRun interface {
 	multicluster.Provider
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// KubernetesVersionWarnings returns warnings if the given Kubernetes version is deprecated or expires according to the
+// CloudProfile. Versions which are not offered by the CloudProfile are rejected by Gardener, hence they are not warned
+// about.
+func KubernetesVersionWarnings(cloudProfile *gardenercorev1beta1.CloudProfile, version string, fldPath *field.Path) []string {
+	if cloudProfile == nil || version == "" {
+		return nil
+	}
+	exists, expirableVersion, err := v1beta1helper.KubernetesVersionExistsInCloudProfile(cloudProfile, version)
+	if err != nil || !exists {
+		return nil
+	}
+	return versionWarnings("Kubernetes version "+version, expirableVersion, fldPath)
+}
+
+// MachineImageWarnings returns warnings if the version of the given machine image is deprecated or expires according to
+// the CloudProfile.
+func MachineImageWarnings(cloudProfile *gardenercorev1beta1.CloudProfile, image *gardenercorev1beta1.ShootMachineImage, fldPath *field.Path) []string {
+	if cloudProfile == nil || image == nil || image.Version == nil {
+		return nil
+	}
+	found, machineImage := v1beta1helper.DetermineMachineImageForName(cloudProfile, image.Name)
+	if !found {
+		return nil
+	}
+	exists, index := v1beta1helper.ShootMachineImageVersionExists(machineImage, *image)
+	if !exists {
+		return nil
+	}
+	return versionWarnings(fmt.Sprintf("version %s of machine image %s", *image.Version, image.Name), machineImage.Versions[index].ExpirableVersion, fldPath)
+}
+
+func versionWarnings(subject string, version gardenercorev1beta1.ExpirableVersion, fldPath *field.Path) []string {
+	var (
+		deprecated     = v1beta1helper.CurrentLifecycleClassification(version) == gardenercorev1beta1.ClassificationDeprecated
+		expirationDate = VersionExpirationDate(version)
+	)
+	switch {
+	case expirationDate != nil && !expirationDate.After(time.Now()):
+		return []string{fmt.Sprintf("%s: the %s expired on %s. It will be forcefully updated in the next maintenance time window.", fldPath, subject, expirationDate.UTC().Format(time.RFC3339))}
+	case expirationDate != nil:
+		state := "expires"
+		if deprecated {
+			state = "is deprecated and expires"
+		}
+		return []string{fmt.Sprintf("%s: the %s %s on %s. It will be forcefully updated in the first maintenance time window thereafter.", fldPath, subject, state, expirationDate.UTC().Format(time.RFC3339))}
+	case deprecated:
+		return []string{fmt.Sprintf("%s: the %s is deprecated. Consider updating to a supported version.", fldPath, subject)}
+	}
+	return nil
+}
+
+// VersionExpirationDate returns the expiration date of the given version, which is either set explicitly or as the
+// start time of the expired stage of its lifecycle. Returns nil if the version does not expire.
+func VersionExpirationDate(version gardenercorev1beta1.ExpirableVersion) *metav1.Time {
+	if version.ExpirationDate != nil {
+		return version.ExpirationDate
+	}
+	for _, stage := range version.Lifecycle {
+		if stage.Classification == gardenercorev1beta1.ClassificationExpired {
+			return stage.StartTime
+		}
+	}
+	return nil
+}
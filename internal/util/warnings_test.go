@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	. "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+var _ = Describe("Warnings", func() {
+	var (
+		expiration   = metav1.NewTime(time.Now().AddDate(1, 0, 0).Truncate(time.Second))
+		expired      = metav1.NewTime(time.Now().AddDate(-1, 0, 0).Truncate(time.Second))
+		cloudProfile *gardenercorev1beta1.CloudProfile
+	)
+
+	BeforeEach(func() {
+		cloudProfile = &gardenercorev1beta1.CloudProfile{
+			Spec: gardenercorev1beta1.CloudProfileSpec{
+				Kubernetes: gardenercorev1beta1.KubernetesSettings{
+					Versions: []gardenercorev1beta1.ExpirableVersion{
+						{Version: "1.31.9", ExpirationDate: &expired},
+						{Version: "1.32.5", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated)},
+						{Version: "1.32.6", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated), ExpirationDate: &expiration},
+						{Version: "1.33.1", Lifecycle: []gardenercorev1beta1.LifecycleStage{
+							{Classification: gardenercorev1beta1.ClassificationSupported},
+							{Classification: gardenercorev1beta1.ClassificationExpired, StartTime: &expiration},
+						}},
+						{Version: "1.33.2"},
+					},
+				},
+				MachineImages: []gardenercorev1beta1.MachineImage{{
+					Name: "gardenlinux",
+					Versions: []gardenercorev1beta1.MachineImageVersion{
+						{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1.0.0", ExpirationDate: &expiration}},
+						{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "2.0.0"}},
+					},
+				}},
+			},
+		}
+	})
+
+	Describe("#KubernetesVersionWarnings", func() {
+		fldPath := field.NewPath("spec", "kubernetes", "version")
+
+		It("should not warn about supported versions", func() {
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.33.2", fldPath)).To(BeEmpty())
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.34.0", fldPath)).To(BeEmpty())
+			Expect(KubernetesVersionWarnings(nil, "1.31.9", fldPath)).To(BeEmpty())
+		})
+
+		It("should warn about deprecated versions", func() {
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.32.5", fldPath)).To(ConsistOf(
+				"spec.kubernetes.version: the Kubernetes version 1.32.5 is deprecated. Consider updating to a supported version.",
+			))
+		})
+
+		It("should warn about expiring versions", func() {
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.32.6", fldPath)).To(ConsistOf(
+				"spec.kubernetes.version: the Kubernetes version 1.32.6 is deprecated and expires on " + expiration.UTC().Format(time.RFC3339) + ". It will be forcefully updated in the first maintenance time window thereafter.",
+			))
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.33.1", fldPath)).To(ConsistOf(
+				"spec.kubernetes.version: the Kubernetes version 1.33.1 expires on " + expiration.UTC().Format(time.RFC3339) + ". It will be forcefully updated in the first maintenance time window thereafter.",
+			))
+		})
+
+		It("should warn about expired versions", func() {
+			Expect(KubernetesVersionWarnings(cloudProfile, "1.31.9", fldPath)).To(ConsistOf(
+				"spec.kubernetes.version: the Kubernetes version 1.31.9 expired on " + expired.UTC().Format(time.RFC3339) + ". It will be forcefully updated in the next maintenance time window.",
+			))
+		})
+	})
+
+	Describe("#MachineImageWarnings", func() {
+		fldPath := field.NewPath("spec", "machine", "image", "version")
+
+		It("should warn about expiring machine image versions", func() {
+			Expect(MachineImageWarnings(cloudProfile, &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1.0.0")}, fldPath)).To(ConsistOf(
+				"spec.machine.image.version: the version 1.0.0 of machine image gardenlinux expires on " + expiration.UTC().Format(time.RFC3339) + ". It will be forcefully updated in the first maintenance time window thereafter.",
+			))
+		})
+
+		It("should not warn about supported or unknown machine images", func() {
+			Expect(MachineImageWarnings(cloudProfile, &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("2.0.0")}, fldPath)).To(BeEmpty())
+			Expect(MachineImageWarnings(cloudProfile, &gardenercorev1beta1.ShootMachineImage{Name: "ubuntu", Version: ptr.To("1.0.0")}, fldPath)).To(BeEmpty())
+			Expect(MachineImageWarnings(cloudProfile, &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux"}, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#VersionExpirationDate", func() {
+		It("should return the expiration date or the start of the expired lifecycle stage", func() {
+			Expect(VersionExpirationDate(cloudProfile.Spec.Kubernetes.Versions[0])).To(Equal(&expired))
+			Expect(VersionExpirationDate(cloudProfile.Spec.Kubernetes.Versions[3])).To(Equal(&expiration))
+			Expect(VersionExpirationDate(cloudProfile.Spec.Kubernetes.Versions[4])).To(BeNil())
+		})
+	})
+})
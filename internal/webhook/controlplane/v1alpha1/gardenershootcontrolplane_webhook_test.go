@@ -1,48 +0,0 @@
-// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package v1alpha1
-
-import (
-	. "github.com/onsi/ginkgo/v2"
-	. "github.com/onsi/gomega"
-
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-)
-
-var _ = Describe("GardenerShootControlPlane Webhook", func() {
-	var (
-		obj       *controlplanev1alpha1.GardenerShootControlPlane
-		oldObj    *controlplanev1alpha1.GardenerShootControlPlane
-		defaulter GardenerShootControlPlaneCustomDefaulter
-	)
-
-	BeforeEach(func() {
-		obj = &controlplanev1alpha1.GardenerShootControlPlane{}
-		oldObj = &controlplanev1alpha1.GardenerShootControlPlane{}
-		defaulter = GardenerShootControlPlaneCustomDefaulter{}
-		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
-		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
-		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
-		// TODO (user): Add any setup logic common to all tests
-	})
-
-	AfterEach(func() {
-		// TODO (user): Add any teardown logic common to all tests
-	})
-
-	Context("When creating GardenerShootControlPlane under Defaulting Webhook", func() {
-		// TODO (user): Add logic for defaulting webhooks
-		// Example:
-		// It("Should apply defaults when a required field is empty", func() {
-		//     By("simulating a scenario where defaults should be applied")
-		//     obj.SomeFieldWithDefault = ""
-		//     By("calling the Default method to apply defaults")
-		//     defaulter.Default(ctx, obj)
-		//     By("checking that the default values are set")
-		//     Expect(obj.SomeFieldWithDefault).To(Equal("default_value"))
-		// })
-	})
-
-})
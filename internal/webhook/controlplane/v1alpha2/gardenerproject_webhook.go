@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+// projectNameMaxLength is the maximum length of the name of a Project in the Gardener cluster.
+const projectNameMaxLength = 10
+
+// SetupGardenerProjectWebhookWithManager registers the webhook for GardenerProject in the manager.
+func SetupGardenerProjectWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &controlplanev1alpha2.GardenerProject{}).
+		WithValidator(&GardenerProjectCustomValidator{}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1alpha2-gardenerproject,mutating=false,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=gardenerprojects,verbs=create,versions=v1alpha2,name=vgardenerproject-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// GardenerProjectCustomValidator struct is responsible for validating the GardenerProject resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type GardenerProjectCustomValidator struct{}
+
+var _ admission.Validator[*controlplanev1alpha2.GardenerProject] = &GardenerProjectCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type GardenerProject.
+// The webhook is only configured outside of kcp, where the name of the GardenerProject is used as name of the Project in
+// the Gardener cluster unless spec.projectName is set.
+func (v *GardenerProjectCustomValidator) ValidateCreate(_ context.Context, project *controlplanev1alpha2.GardenerProject) (admission.Warnings, error) {
+	if project.Spec.ProjectName != "" || len(project.Name) <= projectNameMaxLength {
+		return nil, nil
+	}
+	allErrs := field.ErrorList{field.Invalid(field.NewPath("metadata", "name"), project.Name,
+		fmt.Sprintf("must not be longer than %d characters, as it is used as name of the Gardener Project, set spec.projectName to use a shorter name", projectNameMaxLength))}
+	return nil, apierrors.NewInvalid(controlplanev1alpha2.GroupVersion.WithKind("GardenerProject").GroupKind(), project.Name, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type GardenerProject. The
+// name and spec.projectName cannot change, so there is nothing to validate.
+func (v *GardenerProjectCustomValidator) ValidateUpdate(_ context.Context, _, _ *controlplanev1alpha2.GardenerProject) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type GardenerProject.
+func (v *GardenerProjectCustomValidator) ValidateDelete(_ context.Context, _ *controlplanev1alpha2.GardenerProject) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+var _ = Describe("GardenerProject Webhook", func() {
+	var (
+		ctx       context.Context
+		validator GardenerProjectCustomValidator
+		obj       *controlplanev1alpha2.GardenerProject
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = GardenerProjectCustomValidator{}
+		obj = &controlplanev1alpha2.GardenerProject{ObjectMeta: metav1.ObjectMeta{Name: "my-project"}}
+	})
+
+	It("should admit a name which is a valid Project name", func() {
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+	})
+
+	It("should reject a name which is too long for a Project", func() {
+		obj.Name = "my-long-project"
+
+		_, err := validator.ValidateCreate(ctx, obj)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("metadata.name: Invalid value: \"my-long-project\": must not be longer than 10 characters")))
+	})
+
+	It("should admit a long name if the Project name is set", func() {
+		obj.Name = "my-long-project"
+		obj.Spec.ProjectName = "my-project"
+
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+	})
+})
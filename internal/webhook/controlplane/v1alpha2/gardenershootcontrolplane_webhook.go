@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var _ = logf.Log.WithName("gardenershootcontrolplane-resource")
+
+// SetupGardenerShootControlPlaneWebhookWithManager registers the webhook for GardenerShootControlPlane in the manager.
+// If projectNamespaces is not empty, only GardenerShootControlPlanes for these project namespaces are admitted.
+func SetupGardenerShootControlPlaneWebhookWithManager(mgr ctrl.Manager, gardenerClient client.Client, projectNamespaces []string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &controlplanev1alpha2.GardenerShootControlPlane{}).
+		WithValidator(&GardenerShootControlPlaneCustomValidator{
+			GardenerClient:    gardenerClient,
+			Client:            mgr.GetClient(),
+			ProjectNamespaces: projectNamespaces,
+		}).
+		WithDefaulter(&GardenerShootControlPlaneCustomDefaulter{
+			GardenerClient: gardenerClient,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-controlplane-cluster-x-k8s-io-v1alpha2-gardenershootcontrolplane,mutating=true,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=gardenershootcontrolplanes,verbs=create;update,versions=v1alpha2,name=vgardenershootcontrolplane-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:rbac:groups=core.gardener.cloud,resources=cloudprofiles;namespacedcloudprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=security.gardener.cloud,resources=credentialsbindings,verbs=get;list;watch
+
+// GardenerShootControlPlaneCustomDefaulter struct is responsible for defaulting the GardenerShootControlPlane resource.
+type GardenerShootControlPlaneCustomDefaulter struct {
+	GardenerClient client.Client
+}
+
+var _ admission.Defaulter[*controlplanev1alpha2.GardenerShootControlPlane] = &GardenerShootControlPlaneCustomDefaulter{}
+
+// Default implements admission.Defaulter so a webhook will be registered for the type GardenerShootControlPlane.
+// Fields that are defaulted from the CloudProfile or the project are recorded in annotations on the object.
+func (d GardenerShootControlPlaneCustomDefaulter) Default(ctx context.Context, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) error {
+	if len(shootControlPlane.Spec.ProjectNamespace) == 0 {
+		shootControlPlane.Spec.ProjectNamespace = shootControlPlane.Namespace
+	}
+
+	if d.GardenerClient == nil {
+		return nil
+	}
+
+	if err := d.defaultKubernetesVersion(ctx, shootControlPlane); err != nil {
+		return err
+	}
+
+	return d.defaultCredentialsBindingName(ctx, shootControlPlane)
+}
+
+// defaultKubernetesVersion defaults the latest supported patch version of the CloudProfile if only a minor version
+// (e.g. `1.33`) is specified.
+func (d GardenerShootControlPlaneCustomDefaulter) defaultKubernetesVersion(ctx context.Context, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) error {
+	version := shootControlPlane.Spec.Kubernetes.Version
+	if len(version) == 0 || strings.Count(version, ".") != 1 {
+		return nil
+	}
+
+	cloudProfile, err := providerutil.CloudProfileForControlPlane(ctx, d.GardenerClient, shootControlPlane)
+	if err != nil {
+		// The reference is validated when the Shoot is created, so missing CloudProfiles are not defaulted from.
+		return client.IgnoreNotFound(err)
+	}
+	if cloudProfile == nil {
+		return nil
+	}
+
+	latestVersion, err := latestSupportedPatchVersion(cloudProfile, version)
+	if err != nil || len(latestVersion) == 0 {
+		return err
+	}
+
+	shootControlPlane.Spec.Kubernetes.Version = latestVersion
+	metav1.SetMetaDataAnnotation(&shootControlPlane.ObjectMeta, controlplanev1alpha2.DefaultedKubernetesVersionAnnotation, latestVersion)
+	return nil
+}
+
+// defaultCredentialsBindingName defaults the CredentialsBinding if the project contains exactly one CredentialsBinding
+// for the provider type of the GardenerShootControlPlane.
+func (d GardenerShootControlPlaneCustomDefaulter) defaultCredentialsBindingName(ctx context.Context, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) error {
+	if shootControlPlane.Spec.CredentialsBindingName != nil || shootControlPlane.Spec.SecretBindingName != nil || len(shootControlPlane.Spec.Provider.Type) == 0 {
+		return nil
+	}
+
+	credentialsBindingList := &securityv1alpha1.CredentialsBindingList{}
+	if err := d.GardenerClient.List(ctx, credentialsBindingList, client.InNamespace(providerutil.ProjectNamespace(shootControlPlane))); err != nil {
+		return fmt.Errorf("could not list credentials bindings: %w", err)
+	}
+
+	var candidates []string
+	for _, credentialsBinding := range credentialsBindingList.Items {
+		if credentialsBinding.Provider.Type == shootControlPlane.Spec.Provider.Type {
+			candidates = append(candidates, credentialsBinding.Name)
+		}
+	}
+	if len(candidates) != 1 {
+		return nil
+	}
+
+	shootControlPlane.Spec.CredentialsBindingName = ptr.To(candidates[0])
+	metav1.SetMetaDataAnnotation(&shootControlPlane.ObjectMeta, controlplanev1alpha2.DefaultedCredentialsBindingNameAnnotation, candidates[0])
+	return nil
+}
+
+// latestSupportedPatchVersion returns the latest supported Kubernetes patch version of the given minor version offered by
+// the CloudProfile. Returns an empty string if the CloudProfile does not offer a supported version for the minor version.
+func latestSupportedPatchVersion(cloudProfile *gardenercorev1beta1.CloudProfile, minorVersion string) (string, error) {
+	minorSemVer, err := semver.NewVersion(minorVersion)
+	if err != nil {
+		// Invalid versions are rejected when the Shoot is validated.
+		return "", nil
+	}
+
+	found, latest, err := v1beta1helper.GetLatestQualifyingVersion(
+		cloudProfile.Spec.Kubernetes.Versions,
+		v1beta1helper.FilterDifferentMajorMinorVersionAndLowerPatchVersionsOfSameMinor(*minorSemVer),
+		func(version gardenercorev1beta1.ExpirableVersion, _ *semver.Version) (bool, error) {
+			return !v1beta1helper.VersionIsSupported(version), nil
+		},
+	)
+	if err != nil || !found {
+		return "", err
+	}
+	return latest.Version, nil
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1alpha2-gardenershootcontrolplane,mutating=false,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=gardenershootcontrolplanes,verbs=create;update,versions=v1alpha2,name=vgardenershootcontrolplane-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// GardenerShootControlPlaneCustomValidator struct is responsible for validating the GardenerShootControlPlane resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type GardenerShootControlPlaneCustomValidator struct {
+	GardenerClient client.Client
+	Client         client.Client
+	// ProjectNamespaces are the project namespaces whose Shoots are watched. All namespaces are allowed if it is empty.
+	ProjectNamespaces []string
+}
+
+var _ admission.Validator[*controlplanev1alpha2.GardenerShootControlPlane] = &GardenerShootControlPlaneCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type GardenerShootControlPlane.
+func (v *GardenerShootControlPlaneCustomValidator) ValidateCreate(ctx context.Context, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) (admission.Warnings, error) {
+	// Do not validate the Shoot here, as it does not exist, and all CAPI resources need to be put together to
+	// initially create the shoot spec.
+	return v.warnings(ctx, shootControlPlane), v.validateProjectNamespace(shootControlPlane)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type GardenerShootControlPlane.
+func (v *GardenerShootControlPlaneCustomValidator) ValidateUpdate(ctx context.Context, _, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) (admission.Warnings, error) {
+	warnings := v.warnings(ctx, shootControlPlane)
+	if err := v.validateProjectNamespace(shootControlPlane); err != nil {
+		return warnings, err
+	}
+
+	// For the update, we need to get the actual cluster and inject the new config, because e.g. the resourceVersion must be set.
+	cluster, err := util.GetOwnerCluster(ctx, v.Client, shootControlPlane.ObjectMeta)
+	if err != nil {
+		return warnings, err
+	}
+	shoot := &gardenercorev1beta1.Shoot{}
+	if err := v.GardenerClient.Get(ctx, providerutil.ShootNameFromCAPIResources(*cluster, *shootControlPlane), shoot); err != nil {
+		return warnings, client.IgnoreNotFound(err)
+	}
+
+	currentShoot := shoot.DeepCopy()
+	currentReferences := sets.New(providerutil.ShootReferencedResources(shoot)...)
+	replicated, err := providerutil.ReplicatedResources(ctx, v.Client, shootControlPlane)
+	if err != nil {
+		return warnings, err
+	}
+	// Replicas are only created by the controller, Gardener would reject references to them until then. The references
+	// are omitted from the validation instead, the rest of the spec is still validated.
+	replicas, pending := sets.New[providerutil.ResourceReference](), sets.New[providerutil.ResourceReference]()
+	for reference := range replicated {
+		if currentReferences.Has(providerutil.ResourceReference{Kind: reference.Kind, Name: providerutil.ReplicaName(shoot.Name, reference.Name)}) {
+			replicas.Insert(reference)
+		} else {
+			pending.Insert(reference)
+		}
+	}
+
+	providerutil.SyncShootSpecFromGSCP(shoot, shootControlPlane)
+	providerutil.ReplaceReplicatedReferences(shoot, replicas)
+	providerutil.OmitPendingReplicas(shoot, currentShoot, pending)
+
+	// During deletion, it can happen that the Shoot wants to be patched, when it does not exist anymore,
+	// therefore ignoring this error to prevent the reconciliation to be blocked.
+	if err := v.GardenerClient.Update(ctx, shoot, &client.UpdateOptions{DryRun: []string{"All"}}); err != nil {
+		return warnings, providerutil.TranslateShootStatusError(client.IgnoreNotFound(err), shoot, shootControlPlane)
+	}
+	return warnings, nil
+}
+
+// warnings returns warnings for deprecated fields of the GardenerShootControlPlane, and for a Kubernetes version which is
+// deprecated or expires according to the CloudProfile. The CloudProfile is skipped if it cannot be read, as warnings must
+// not block the admission.
+func (v *GardenerShootControlPlaneCustomValidator) warnings(ctx context.Context, shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) admission.Warnings {
+	var (
+		warnings admission.Warnings
+		specPath = field.NewPath("spec")
+	)
+
+	if shootControlPlane.Spec.SecretBindingName != nil {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated and will be disallowed starting with Kubernetes 1.34, use %s instead. For migration instructions, see: https://github.com/gardener/gardener/blob/master/docs/usage/shoot-operations/secretbinding-to-credentialsbinding-migration.md", specPath.Child("secretBindingName"), specPath.Child("credentialsBindingName")))
+	}
+	// CloudProfileName was dropped in v1alpha2, it is only preserved in the conversion data of objects which are
+	// managed in v1alpha1.
+	restored := &controlplanev1alpha1.GardenerShootControlPlane{}
+	if ok, _ := utilconversion.UnmarshalData(shootControlPlane.ObjectMeta.DeepCopy(), restored); ok && restored.Spec.CloudProfileName != nil {
+		warnings = append(warnings, fmt.Sprintf("%s of %s is deprecated, use %s instead", specPath.Child("cloudProfileName"), controlplanev1alpha1.GroupVersion, specPath.Child("cloudProfile", "name")))
+	}
+	if shootControlPlane.Spec.Addons != nil {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated and will be forbidden starting with Kubernetes 1.35", specPath.Child("addons")))
+	}
+
+	if v.GardenerClient != nil {
+		if cloudProfile, err := providerutil.CloudProfileForControlPlane(ctx, v.GardenerClient, shootControlPlane); err == nil {
+			warnings = append(warnings, providerutil.KubernetesVersionWarnings(cloudProfile, shootControlPlane.Spec.Kubernetes.Version, specPath.Child("kubernetes", "version"))...)
+		}
+	}
+	return warnings
+}
+
+// validateProjectNamespace rejects GardenerShootControlPlanes for project namespaces whose Shoots are not watched, as
+// their Shoots could never be reconciled.
+func (v *GardenerShootControlPlaneCustomValidator) validateProjectNamespace(shootControlPlane *controlplanev1alpha2.GardenerShootControlPlane) error {
+	projectNamespace := providerutil.ProjectNamespace(shootControlPlane)
+	if len(v.ProjectNamespaces) == 0 || slices.Contains(v.ProjectNamespaces, projectNamespace) {
+		return nil
+	}
+	return apierrors.NewInvalid(controlplanev1alpha2.GroupVersion.WithKind(providerutil.KindGardenerShootControlPlane).GroupKind(), shootControlPlane.Name, field.ErrorList{
+		field.NotSupported(field.NewPath("spec", "projectNamespace"), projectNamespace, v.ProjectNamespaces),
+	})
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type GardenerShootControlPlane.
+func (v *GardenerShootControlPlaneCustomValidator) ValidateDelete(_ context.Context, _ *controlplanev1alpha2.GardenerShootControlPlane) (admission.Warnings, error) {
+	return nil, nil
+}
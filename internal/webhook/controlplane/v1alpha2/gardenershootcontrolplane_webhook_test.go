@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+)
+
+var _ = Describe("GardenerShootControlPlane Webhook", func() {
+	var (
+		obj       *controlplanev1alpha2.GardenerShootControlPlane
+		oldObj    *controlplanev1alpha2.GardenerShootControlPlane
+		defaulter GardenerShootControlPlaneCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &controlplanev1alpha2.GardenerShootControlPlane{}
+		oldObj = &controlplanev1alpha2.GardenerShootControlPlane{}
+		defaulter = GardenerShootControlPlaneCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
+		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
+		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
+		// TODO (user): Add any setup logic common to all tests
+	})
+
+	AfterEach(func() {
+		// TODO (user): Add any teardown logic common to all tests
+	})
+
+	Context("When creating GardenerShootControlPlane under Defaulting Webhook", func() {
+		var (
+			fakeGardenerClient client.Client
+			cloudProfile       *gardenercorev1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			cloudProfile = &gardenercorev1beta1.CloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+				Spec: gardenercorev1beta1.CloudProfileSpec{
+					Kubernetes: gardenercorev1beta1.KubernetesSettings{
+						Versions: []gardenercorev1beta1.ExpirableVersion{
+							{Version: "1.32.4"},
+							{Version: "1.33.1"},
+							{Version: "1.33.2"},
+							{Version: "1.33.3", Classification: ptr.To(gardenercorev1beta1.ClassificationPreview)},
+						},
+					},
+				},
+			}
+			fakeGardenerClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(cloudProfile).Build()
+			defaulter = GardenerShootControlPlaneCustomDefaulter{GardenerClient: fakeGardenerClient}
+
+			obj.Namespace = "garden-foo"
+			obj.Spec.CloudProfile = &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"}
+			obj.Spec.Provider.Type = "aws"
+		})
+
+		It("should default the project namespace", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ProjectNamespace).To(Equal("garden-foo"))
+		})
+
+		It("should default the latest supported patch version for a minor version", func() {
+			obj.Spec.Kubernetes.Version = "1.33"
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Kubernetes.Version).To(Equal("1.33.2"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(controlplanev1alpha2.DefaultedKubernetesVersionAnnotation, "1.33.2"))
+		})
+
+		It("should not default a fully specified version", func() {
+			obj.Spec.Kubernetes.Version = "1.33.1"
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Kubernetes.Version).To(Equal("1.33.1"))
+			Expect(obj.Annotations).NotTo(HaveKey(controlplanev1alpha2.DefaultedKubernetesVersionAnnotation))
+		})
+
+		It("should not default a minor version which is not offered by the cloud profile", func() {
+			obj.Spec.Kubernetes.Version = "1.34"
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Kubernetes.Version).To(Equal("1.34"))
+		})
+
+		It("should default the credentials binding if exactly one matches the provider type", func() {
+			Expect(fakeGardenerClient.Create(ctx, newCredentialsBinding("aws-binding", "aws"))).To(Succeed())
+			Expect(fakeGardenerClient.Create(ctx, newCredentialsBinding("gcp-binding", "gcp"))).To(Succeed())
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.CredentialsBindingName).To(PointTo(Equal("aws-binding")))
+			Expect(obj.Annotations).To(HaveKeyWithValue(controlplanev1alpha2.DefaultedCredentialsBindingNameAnnotation, "aws-binding"))
+		})
+
+		It("should not default the credentials binding if it is ambiguous", func() {
+			Expect(fakeGardenerClient.Create(ctx, newCredentialsBinding("aws-binding", "aws"))).To(Succeed())
+			Expect(fakeGardenerClient.Create(ctx, newCredentialsBinding("other-aws-binding", "aws"))).To(Succeed())
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.CredentialsBindingName).To(BeNil())
+		})
+
+		It("should not default the credentials binding if a secret binding is set", func() {
+			Expect(fakeGardenerClient.Create(ctx, newCredentialsBinding("aws-binding", "aws"))).To(Succeed())
+			obj.Spec.SecretBindingName = ptr.To("secret-binding")
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.CredentialsBindingName).To(BeNil())
+		})
+	})
+
+	Context("When creating GardenerShootControlPlane under Validating Webhook", func() {
+		var validator *GardenerShootControlPlaneCustomValidator
+
+		BeforeEach(func() {
+			validator = &GardenerShootControlPlaneCustomValidator{}
+			obj.Name = "foo"
+			obj.Namespace = "default"
+			obj.Spec.ProjectNamespace = "garden-foo"
+		})
+
+		It("should allow all project namespaces if none are configured", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("should allow a configured project namespace", func() {
+			validator.ProjectNamespaces = []string{"garden-bar", "garden-foo"}
+
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("should reject a project namespace which is not watched", func() {
+			validator.ProjectNamespaces = []string{"garden-bar"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(`spec.projectNamespace: Unsupported value: "garden-foo"`)))
+		})
+
+		It("should not warn about a GardenerShootControlPlane without deprecations", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		})
+
+		It("should warn about deprecated fields", func() {
+			obj.Spec.SecretBindingName = ptr.To("secret-binding")
+			obj.Spec.Addons = &gardenercorev1beta1.Addons{}
+			obj.Annotations = map[string]string{utilconversion.DataAnnotation: `{"spec":{"cloudProfileName":"aws"}}`}
+
+			Expect(validator.ValidateCreate(ctx, obj)).To(ConsistOf(
+				ContainSubstring("spec.secretBindingName is deprecated"),
+				ContainSubstring("spec.cloudProfileName of controlplane.cluster.x-k8s.io/v1alpha1 is deprecated"),
+				ContainSubstring("spec.addons is deprecated"),
+			))
+		})
+
+		It("should warn about a deprecated Kubernetes version", func() {
+			cloudProfile := &gardenercorev1beta1.CloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+				Spec: gardenercorev1beta1.CloudProfileSpec{
+					Kubernetes: gardenercorev1beta1.KubernetesSettings{
+						Versions: []gardenercorev1beta1.ExpirableVersion{
+							{Version: "1.32.4", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated)},
+						},
+					},
+				},
+			}
+			validator.GardenerClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(cloudProfile).Build()
+			obj.Spec.CloudProfile = &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"}
+			obj.Spec.Kubernetes.Version = "1.32.4"
+
+			Expect(validator.ValidateCreate(ctx, obj)).To(ConsistOf(
+				"spec.kubernetes.version: the Kubernetes version 1.32.4 is deprecated. Consider updating to a supported version.",
+			))
+		})
+	})
+})
+
+func newCredentialsBinding(name, providerType string) *securityv1alpha1.CredentialsBinding {
+	return &securityv1alpha1.CredentialsBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden-foo"},
+		Provider:   securityv1alpha1.CredentialsBindingProvider{Type: providerType},
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+// defaultWorkerPoolFromCloudProfile defaults the machine image, the machine image version and the volume type of the
+// given GardenerWorkerPool from the CloudProfile. The defaulted values are recorded in annotations.
+func defaultWorkerPoolFromCloudProfile(workerPool *infrastructurev1alpha2.GardenerWorkerPool, cloudProfile *gardenercorev1beta1.CloudProfile, region string) error {
+	if err := defaultMachineImage(workerPool, cloudProfile); err != nil {
+		return err
+	}
+	defaultVolumeType(workerPool, cloudProfile, region)
+	return nil
+}
+
+// defaultMachineImage defaults the first machine image of the CloudProfile if none is specified, and the latest
+// supported version of the machine image that supports the architecture of the machine type if no version is specified.
+func defaultMachineImage(workerPool *infrastructurev1alpha2.GardenerWorkerPool, cloudProfile *gardenercorev1beta1.CloudProfile) error {
+	machine := &workerPool.Spec.Machine
+	if machine.Image != nil && len(ptr.Deref(machine.Image.Version, "")) > 0 {
+		return nil
+	}
+
+	var machineImage *gardenercorev1beta1.MachineImage
+	if machine.Image == nil {
+		machineImage = v1beta1helper.GetDefaultMachineImageFromCloudProfile(*cloudProfile)
+	} else if found, image := v1beta1helper.DetermineMachineImageForName(cloudProfile, machine.Image.Name); found {
+		machineImage = &image
+	}
+	if machineImage == nil {
+		// Unknown machine images are rejected by the validation.
+		return nil
+	}
+
+	version, err := latestSupportedMachineImageVersion(machineImage, architectureOfMachine(machine, cloudProfile), cloudProfile.Spec.MachineCapabilities)
+	if err != nil || len(version) == 0 {
+		return err
+	}
+
+	machine.Image = &gardenercorev1beta1.ShootMachineImage{
+		Name:    machineImage.Name,
+		Version: ptr.To(version),
+	}
+	metav1.SetMetaDataAnnotation(&workerPool.ObjectMeta, infrastructurev1alpha2.DefaultedMachineImageAnnotation, machineImage.Name+":"+version)
+	return nil
+}
+
+// defaultVolumeType defaults the first usable volume type of the CloudProfile that is available in all zones of the
+// worker pool, if a volume without a type is specified and the machine type does not come with a fixed root disk.
+func defaultVolumeType(workerPool *infrastructurev1alpha2.GardenerWorkerPool, cloudProfile *gardenercorev1beta1.CloudProfile, region string) {
+	volume := workerPool.Spec.Volume
+	if volume == nil || volume.Type != nil {
+		return
+	}
+	if machineType := v1beta1helper.FindMachineTypeByName(cloudProfile.Spec.MachineTypes, workerPool.Spec.Machine.Type); machineType != nil && machineType.Storage != nil {
+		return
+	}
+
+	var zones []gardenercorev1beta1.AvailabilityZone
+	if cloudProfileRegion := findRegion(cloudProfile, region); cloudProfileRegion != nil {
+		for _, zoneName := range workerPool.Spec.Zones {
+			if zone := findZone(cloudProfileRegion, zoneName); zone != nil {
+				zones = append(zones, *zone)
+			}
+		}
+	}
+
+	for _, volumeType := range cloudProfile.Spec.VolumeTypes {
+		if !ptr.Deref(volumeType.Usable, true) {
+			continue
+		}
+		if slices.ContainsFunc(zones, func(zone gardenercorev1beta1.AvailabilityZone) bool {
+			return slices.Contains(zone.UnavailableVolumeTypes, volumeType.Name)
+		}) {
+			continue
+		}
+
+		volume.Type = ptr.To(volumeType.Name)
+		metav1.SetMetaDataAnnotation(&workerPool.ObjectMeta, infrastructurev1alpha2.DefaultedVolumeTypeAnnotation, volumeType.Name)
+		return
+	}
+}
+
+// latestSupportedMachineImageVersion returns the latest supported version of the given machine image.
+// If an architecture is given, versions that do not support the architecture are not considered.
+// Returns an empty string if no version qualifies.
+func latestSupportedMachineImageVersion(machineImage *gardenercorev1beta1.MachineImage, architecture string, capabilityDefinitions []gardenercorev1beta1.CapabilityDefinition) (string, error) {
+	versions := make([]gardenercorev1beta1.ExpirableVersion, 0, len(machineImage.Versions))
+	for _, version := range machineImage.Versions {
+		// Versions which do not declare any architecture are not filtered, as nothing can be said about them.
+		supportedArchitectures := v1beta1helper.GetArchitecturesFromImageVersion(version, capabilityDefinitions)
+		if len(architecture) > 0 && len(supportedArchitectures) > 0 && !slices.Contains(supportedArchitectures, architecture) {
+			continue
+		}
+		versions = append(versions, version.ExpirableVersion)
+	}
+
+	found, latest, err := v1beta1helper.GetLatestQualifyingVersion(versions, func(version gardenercorev1beta1.ExpirableVersion, _ *semver.Version) (bool, error) {
+		return !v1beta1helper.VersionIsSupported(version), nil
+	})
+	if err != nil || !found {
+		return "", err
+	}
+	return latest.Version, nil
+}
+
+// architectureOfMachine returns the architecture of the machine, falling back to the architecture of its machine type.
+func architectureOfMachine(machine *gardenercorev1beta1.Machine, cloudProfile *gardenercorev1beta1.CloudProfile) string {
+	if architecture := ptr.Deref(machine.Architecture, ""); len(architecture) > 0 {
+		return architecture
+	}
+	if machineType := v1beta1helper.FindMachineTypeByName(cloudProfile.Spec.MachineTypes, machine.Type); machineType != nil {
+		return machineType.GetArchitecture(cloudProfile.Spec.MachineCapabilities)
+	}
+	return ""
+}
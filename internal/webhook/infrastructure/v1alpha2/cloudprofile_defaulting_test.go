@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/utils/ptr"
+
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+var _ = Describe("CloudProfile defaulting", func() {
+	var (
+		cloudProfile *gardenercorev1beta1.CloudProfile
+		workerPool   *infrastructurev1alpha2.GardenerWorkerPool
+	)
+
+	BeforeEach(func() {
+		cloudProfile = &gardenercorev1beta1.CloudProfile{
+			Spec: gardenercorev1beta1.CloudProfileSpec{
+				MachineTypes: []gardenercorev1beta1.MachineType{
+					{Name: "m5.large", Architecture: ptr.To("amd64")},
+					{Name: "m6g.large", Architecture: ptr.To("arm64")},
+					{Name: "i3.large", Storage: &gardenercorev1beta1.MachineTypeStorage{Type: "nvme"}},
+				},
+				MachineImages: []gardenercorev1beta1.MachineImage{
+					{
+						Name: "gardenlinux",
+						Versions: []gardenercorev1beta1.MachineImageVersion{
+							{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1877.0.0"}, Architectures: []string{"amd64", "arm64"}},
+							{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1877.1.0"}, Architectures: []string{"amd64"}},
+							{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1877.2.0", Classification: ptr.To(gardenercorev1beta1.ClassificationPreview)}, Architectures: []string{"amd64", "arm64"}},
+						},
+					},
+					{
+						Name:     "suse-chost",
+						Versions: []gardenercorev1beta1.MachineImageVersion{{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "15.6.0"}}},
+					},
+				},
+				VolumeTypes: []gardenercorev1beta1.VolumeType{{Name: "io2", Usable: ptr.To(false)}, {Name: "gp2"}, {Name: "gp3"}},
+				Regions: []gardenercorev1beta1.Region{{
+					Name:  "eu-west-1",
+					Zones: []gardenercorev1beta1.AvailabilityZone{{Name: "eu-west-1a", UnavailableVolumeTypes: []string{"gp2"}}, {Name: "eu-west-1b"}},
+				}},
+			},
+		}
+		workerPool = &infrastructurev1alpha2.GardenerWorkerPool{
+			Spec: infrastructurev1alpha2.GardenerWorkerPoolSpec{
+				Machine: gardenercorev1beta1.Machine{Type: "m5.large"},
+				Volume:  &gardenercorev1beta1.Volume{VolumeSize: "50Gi"},
+				Zones:   []string{"eu-west-1a"},
+			},
+		}
+	})
+
+	Describe("#defaultWorkerPoolFromCloudProfile", func() {
+		It("should default the first machine image with its latest supported version", func() {
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Machine.Image).To(Equal(&gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1877.1.0")}))
+			Expect(workerPool.Annotations).To(HaveKeyWithValue(infrastructurev1alpha2.DefaultedMachineImageAnnotation, "gardenlinux:1877.1.0"))
+		})
+
+		It("should only consider versions supporting the architecture of the machine type", func() {
+			workerPool.Spec.Machine.Type = "m6g.large"
+
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Machine.Image.Version).To(PointTo(Equal("1877.0.0")))
+		})
+
+		It("should default the version of a given machine image", func() {
+			workerPool.Spec.Machine.Image = &gardenercorev1beta1.ShootMachineImage{Name: "suse-chost"}
+
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Machine.Image.Version).To(PointTo(Equal("15.6.0")))
+		})
+
+		It("should not overwrite a given machine image version", func() {
+			workerPool.Spec.Machine.Image = &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1877.0.0")}
+
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Machine.Image.Version).To(PointTo(Equal("1877.0.0")))
+			Expect(workerPool.Annotations).NotTo(HaveKey(infrastructurev1alpha2.DefaultedMachineImageAnnotation))
+		})
+
+		It("should default the first usable volume type available in all zones", func() {
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Volume.Type).To(PointTo(Equal("gp3")))
+			Expect(workerPool.Annotations).To(HaveKeyWithValue(infrastructurev1alpha2.DefaultedVolumeTypeAnnotation, "gp3"))
+
+			workerPool.Spec.Volume.Type = nil
+			workerPool.Spec.Zones = []string{"eu-west-1b"}
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Volume.Type).To(PointTo(Equal("gp2")))
+		})
+
+		It("should not default the volume type for machine types with fixed storage", func() {
+			workerPool.Spec.Machine.Type = "i3.large"
+
+			Expect(defaultWorkerPoolFromCloudProfile(workerPool, cloudProfile, "eu-west-1")).To(Succeed())
+			Expect(workerPool.Spec.Volume.Type).To(BeNil())
+		})
+	})
+})
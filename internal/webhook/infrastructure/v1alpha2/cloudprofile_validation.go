@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+// cloudProfileContext bundles the objects that are needed to validate a GardenerShootCluster or a GardenerWorkerPool
+// against the CloudProfile of its Cluster.
+type cloudProfileContext struct {
+	cluster      *clusterv1beta2.Cluster
+	controlPlane *controlplanev1alpha2.GardenerShootControlPlane
+	cloudProfile *gardenercorev1beta1.CloudProfile
+	// region is the region of the GardenerShootCluster of the Cluster, it is empty if the infrastructure cluster
+	// does not exist (yet).
+	region string
+}
+
+// getCloudProfileContext resolves the control plane, the CloudProfile and the region referenced by the given Cluster.
+// Returns nil if any of them cannot be resolved (yet), as CAPI objects can be created in an arbitrary order.
+func getCloudProfileContext(ctx context.Context, c, gardenerClient client.Client, cluster *clusterv1beta2.Cluster) (*cloudProfileContext, error) {
+	if cluster == nil {
+		return nil, nil
+	}
+	controlPlane, err := providerutil.ControlPlaneForCluster(ctx, c, cluster)
+	if err != nil || controlPlane == nil {
+		return nil, err
+	}
+	cloudProfile, err := providerutil.CloudProfileForControlPlane(ctx, gardenerClient, controlPlane)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not get cloud profile: %w", err)
+	}
+	if cloudProfile == nil {
+		return nil, nil
+	}
+
+	var region string
+	infraCluster, err := providerutil.InfraClusterForCluster(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if infraCluster != nil {
+		region = infraCluster.Spec.Region
+	}
+
+	return &cloudProfileContext{
+		cluster:      cluster,
+		controlPlane: controlPlane,
+		cloudProfile: cloudProfile,
+		region:       region,
+	}, nil
+}
+
+// validateRegion validates that the given region is offered by the CloudProfile.
+func validateRegion(cloudProfile *gardenercorev1beta1.CloudProfile, region string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if findRegion(cloudProfile, region) == nil {
+		allErrs = append(allErrs, field.NotSupported(fldPath, region, regionNames(cloudProfile)))
+	}
+
+	return allErrs
+}
+
+// validateWorkerPoolSpec validates the parts of a GardenerWorkerPool spec that do not depend on any other object.
+func validateWorkerPoolSpec(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Minimum < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minimum"), spec.Minimum, "must be greater than or equal to 0"))
+	}
+	if spec.Maximum < spec.Minimum {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maximum"), spec.Maximum, "must be greater than or equal to minimum"))
+	}
+
+	return allErrs
+}
+
+// validateWorkerPoolAgainstCloudProfile validates that the machine type, machine image, volume type and zones of the
+// given GardenerWorkerPool are offered by the CloudProfile in the given region.
+func validateWorkerPoolAgainstCloudProfile(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, cloudProfile *gardenercorev1beta1.CloudProfile, region string, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs     = field.ErrorList{}
+		machinePath = fldPath.Child("machine")
+	)
+
+	if machineType := v1beta1helper.FindMachineTypeByName(cloudProfile.Spec.MachineTypes, spec.Machine.Type); machineType == nil {
+		allErrs = append(allErrs, field.NotSupported(machinePath.Child("type"), spec.Machine.Type, machineTypeNames(cloudProfile)))
+	} else if !ptr.Deref(machineType.Usable, true) {
+		allErrs = append(allErrs, field.Invalid(machinePath.Child("type"), spec.Machine.Type, "machine type is not usable"))
+	}
+
+	if image := spec.Machine.Image; image != nil {
+		found, machineImage := v1beta1helper.DetermineMachineImageForName(cloudProfile, image.Name)
+		if !found {
+			allErrs = append(allErrs, field.NotSupported(machinePath.Child("image", "name"), image.Name, machineImageNames(cloudProfile)))
+		} else if image.Version != nil {
+			if exists, _ := v1beta1helper.ShootMachineImageVersionExists(machineImage, *image); !exists {
+				allErrs = append(allErrs, field.NotSupported(machinePath.Child("image", "version"), *image.Version, machineImageVersions(machineImage)))
+			}
+		}
+	}
+
+	if spec.Volume != nil && spec.Volume.Type != nil {
+		volumePath := fldPath.Child("volume", "type")
+		if volumeType := findVolumeType(cloudProfile, *spec.Volume.Type); volumeType == nil {
+			allErrs = append(allErrs, field.NotSupported(volumePath, *spec.Volume.Type, volumeTypeNames(cloudProfile)))
+		} else if !ptr.Deref(volumeType.Usable, true) {
+			allErrs = append(allErrs, field.Invalid(volumePath, *spec.Volume.Type, "volume type is not usable"))
+		}
+	}
+	for i, dataVolume := range spec.DataVolumes {
+		if dataVolume.Type == nil {
+			continue
+		}
+		if findVolumeType(cloudProfile, *dataVolume.Type) == nil {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("dataVolumes").Index(i).Child("type"), *dataVolume.Type, volumeTypeNames(cloudProfile)))
+		}
+	}
+
+	cloudProfileRegion := findRegion(cloudProfile, region)
+	if cloudProfileRegion == nil {
+		// The region itself is validated on the GardenerShootCluster.
+		return allErrs
+	}
+	for i, zoneName := range spec.Zones {
+		zonePath := fldPath.Child("zones").Index(i)
+		zone := findZone(cloudProfileRegion, zoneName)
+		if zone == nil {
+			allErrs = append(allErrs, field.NotSupported(zonePath, zoneName, zoneNames(cloudProfileRegion)))
+			continue
+		}
+		if slices.Contains(zone.UnavailableMachineTypes, spec.Machine.Type) {
+			allErrs = append(allErrs, field.Invalid(zonePath, zoneName, fmt.Sprintf("machine type %q is unavailable in this zone", spec.Machine.Type)))
+		}
+		if spec.Volume != nil && spec.Volume.Type != nil && slices.Contains(zone.UnavailableVolumeTypes, *spec.Volume.Type) {
+			allErrs = append(allErrs, field.Invalid(zonePath, zoneName, fmt.Sprintf("volume type %q is unavailable in this zone", *spec.Volume.Type)))
+		}
+	}
+
+	return allErrs
+}
+
+func findRegion(cloudProfile *gardenercorev1beta1.CloudProfile, name string) *gardenercorev1beta1.Region {
+	for i, region := range cloudProfile.Spec.Regions {
+		if region.Name == name {
+			return &cloudProfile.Spec.Regions[i]
+		}
+	}
+	return nil
+}
+
+func findZone(region *gardenercorev1beta1.Region, name string) *gardenercorev1beta1.AvailabilityZone {
+	for i, zone := range region.Zones {
+		if zone.Name == name {
+			return &region.Zones[i]
+		}
+	}
+	return nil
+}
+
+func findVolumeType(cloudProfile *gardenercorev1beta1.CloudProfile, name string) *gardenercorev1beta1.VolumeType {
+	for i, volumeType := range cloudProfile.Spec.VolumeTypes {
+		if volumeType.Name == name {
+			return &cloudProfile.Spec.VolumeTypes[i]
+		}
+	}
+	return nil
+}
+
+func regionNames(cloudProfile *gardenercorev1beta1.CloudProfile) []string {
+	names := make([]string, 0, len(cloudProfile.Spec.Regions))
+	for _, region := range cloudProfile.Spec.Regions {
+		names = append(names, region.Name)
+	}
+	return names
+}
+
+func zoneNames(region *gardenercorev1beta1.Region) []string {
+	names := make([]string, 0, len(region.Zones))
+	for _, zone := range region.Zones {
+		names = append(names, zone.Name)
+	}
+	return names
+}
+
+func machineTypeNames(cloudProfile *gardenercorev1beta1.CloudProfile) []string {
+	names := make([]string, 0, len(cloudProfile.Spec.MachineTypes))
+	for _, machineType := range cloudProfile.Spec.MachineTypes {
+		names = append(names, machineType.Name)
+	}
+	return names
+}
+
+func machineImageNames(cloudProfile *gardenercorev1beta1.CloudProfile) []string {
+	names := make([]string, 0, len(cloudProfile.Spec.MachineImages))
+	for _, machineImage := range cloudProfile.Spec.MachineImages {
+		names = append(names, machineImage.Name)
+	}
+	return names
+}
+
+func machineImageVersions(machineImage gardenercorev1beta1.MachineImage) []string {
+	versions := make([]string, 0, len(machineImage.Versions))
+	for _, version := range machineImage.Versions {
+		versions = append(versions, version.Version)
+	}
+	return versions
+}
+
+func volumeTypeNames(cloudProfile *gardenercorev1beta1.CloudProfile) []string {
+	names := make([]string, 0, len(cloudProfile.Spec.VolumeTypes))
+	for _, volumeType := range cloudProfile.Spec.VolumeTypes {
+		names = append(names, volumeType.Name)
+	}
+	return names
+}
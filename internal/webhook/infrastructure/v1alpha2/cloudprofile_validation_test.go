@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+var _ = Describe("CloudProfile validation", func() {
+	var (
+		cloudProfile *gardenercorev1beta1.CloudProfile
+		spec         *infrastructurev1alpha2.GardenerWorkerPoolSpec
+		specPath     = field.NewPath("spec")
+	)
+
+	BeforeEach(func() {
+		cloudProfile = &gardenercorev1beta1.CloudProfile{
+			Spec: gardenercorev1beta1.CloudProfileSpec{
+				MachineTypes: []gardenercorev1beta1.MachineType{{Name: "m5.large"}, {Name: "m5.xlarge", Usable: ptr.To(false)}},
+				MachineImages: []gardenercorev1beta1.MachineImage{{
+					Name:     "gardenlinux",
+					Versions: []gardenercorev1beta1.MachineImageVersion{{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1877.0.0"}}},
+				}},
+				VolumeTypes: []gardenercorev1beta1.VolumeType{{Name: "gp3"}},
+				Regions: []gardenercorev1beta1.Region{{
+					Name:  "eu-west-1",
+					Zones: []gardenercorev1beta1.AvailabilityZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b", UnavailableMachineTypes: []string{"m5.large"}}},
+				}},
+			},
+		}
+		spec = &infrastructurev1alpha2.GardenerWorkerPoolSpec{
+			Machine: gardenercorev1beta1.Machine{
+				Type:  "m5.large",
+				Image: &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1877.0.0")},
+			},
+			Volume:  &gardenercorev1beta1.Volume{Type: ptr.To("gp3"), VolumeSize: "50Gi"},
+			Zones:   []string{"eu-west-1a"},
+			Minimum: 1,
+			Maximum: 2,
+		}
+	})
+
+	Describe("#validateWorkerPoolSpec", func() {
+		It("should allow minimum to equal maximum", func() {
+			spec.Maximum = spec.Minimum
+			Expect(validateWorkerPoolSpec(spec, specPath)).To(BeEmpty())
+		})
+
+		It("should forbid maximum to be lower than minimum", func() {
+			spec.Maximum = 0
+			Expect(validateWorkerPoolSpec(spec, specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("spec.maximum"),
+			}))))
+		})
+	})
+
+	Describe("#validateWorkerPoolAgainstCloudProfile", func() {
+		It("should allow a worker pool offered by the cloud profile", func() {
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(BeEmpty())
+		})
+
+		It("should forbid unknown or unusable machine types", func() {
+			spec.Machine.Type = "m5.xlarge"
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("spec.machine.type"),
+			}))))
+
+			spec.Machine.Type = "foo"
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("spec.machine.type"),
+			}))))
+		})
+
+		It("should forbid unknown machine images and versions", func() {
+			spec.Machine.Image.Version = ptr.To("1.0.0")
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("spec.machine.image.version"),
+			}))))
+
+			spec.Machine.Image.Name = "foo"
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("spec.machine.image.name"),
+			}))))
+		})
+
+		It("should forbid unknown volume types", func() {
+			spec.Volume.Type = ptr.To("io2")
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("spec.volume.type"),
+			}))))
+		})
+
+		It("should forbid unknown zones and zones in which the machine type is unavailable", func() {
+			spec.Zones = []string{"eu-west-1b", "eu-west-1c"}
+			Expect(validateWorkerPoolAgainstCloudProfile(spec, cloudProfile, "eu-west-1", specPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.zones[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("spec.zones[1]"),
+				})),
+			))
+		})
+	})
+
+	Describe("#validateRegion", func() {
+		It("should forbid regions not offered by the cloud profile", func() {
+			Expect(validateRegion(cloudProfile, "eu-west-1", field.NewPath("spec", "region"))).To(BeEmpty())
+			Expect(validateRegion(cloudProfile, "us-east-1", field.NewPath("spec", "region"))).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("spec.region"),
+			}))))
+		})
+	})
+
+	Describe("#getCloudProfileContext", func() {
+		var (
+			ctx          context.Context
+			c            client.Client
+			cluster      *clusterv1beta2.Cluster
+			controlPlane *controlplanev1alpha2.GardenerShootControlPlane
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clusterv1beta2.AddToScheme(scheme)).To(Succeed())
+			Expect(controlplanev1alpha2.AddToScheme(scheme)).To(Succeed())
+			Expect(infrastructurev1alpha2.AddToScheme(scheme)).To(Succeed())
+
+			cluster = &clusterv1beta2.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: clusterv1beta2.ClusterSpec{ControlPlaneRef: clusterv1beta2.ContractVersionedObjectReference{
+					APIGroup: controlplanev1alpha2.GroupVersion.Group,
+					Kind:     "GardenerShootControlPlane",
+					Name:     "foo",
+				}},
+			}
+			controlPlane = &controlplanev1alpha2.GardenerShootControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+					ProjectNamespace: "garden-foo",
+					CloudProfile:     &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"},
+				},
+			}
+			c = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, controlPlane).Build()
+		})
+
+		It("should skip the validation if the CloudProfile does not exist yet", func() {
+			gardenerClient := fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+
+			cloudProfileCtx, err := getCloudProfileContext(ctx, c, gardenerClient, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudProfileCtx).To(BeNil())
+		})
+
+		It("should resolve the CloudProfile of the control plane", func() {
+			cloudProfile.Name = "aws"
+			gardenerClient := fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(cloudProfile).Build()
+
+			cloudProfileCtx, err := getCloudProfileContext(ctx, c, gardenerClient, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudProfileCtx).NotTo(BeNil())
+			Expect(cloudProfileCtx.cloudProfile.Name).To(Equal("aws"))
+			Expect(cloudProfileCtx.region).To(BeEmpty())
+		})
+	})
+})
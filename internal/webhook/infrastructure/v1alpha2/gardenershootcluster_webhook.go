@@ -2,22 +2,24 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package v1alpha1
+package v1alpha2
 
 import (
 	"context"
 	"fmt"
 
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
 )
 
@@ -27,7 +29,7 @@ var _ = logf.Log.WithName("gardenershootcluster-resource")
 
 // SetupGardenerShootClusterWebhookWithManager registers the webhook for GardenerShootCluster in the manager.
 func SetupGardenerShootClusterWebhookWithManager(mgr ctrl.Manager, gardenerClient client.Client) error {
-	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha1.GardenerShootCluster{}).
+	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha2.GardenerShootCluster{}).
 		WithValidator(&GardenerShootClusterCustomValidator{
 			Client:         mgr.GetClient(),
 			GardenerClient: gardenerClient,
@@ -37,7 +39,7 @@ func SetupGardenerShootClusterWebhookWithManager(mgr ctrl.Manager, gardenerClien
 
 // NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
 // Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
-// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-gardenershootcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gardenershootclusters,verbs=create;update,versions=v1alpha1,name=vgardenershootcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha2-gardenershootcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gardenershootclusters,verbs=create;update,versions=v1alpha2,name=vgardenershootcluster-v1alpha2.kb.io,admissionReviewVersions=v1
 
 // GardenerShootClusterCustomValidator struct is responsible for validating the GardenerShootCluster resource
 // when it is created, updated, or deleted.
@@ -49,22 +51,38 @@ type GardenerShootClusterCustomValidator struct {
 	Client         client.Client
 }
 
-var _ admission.Validator[*infrastructurev1alpha1.GardenerShootCluster] = &GardenerShootClusterCustomValidator{}
+var _ admission.Validator[*infrastructurev1alpha2.GardenerShootCluster] = &GardenerShootClusterCustomValidator{}
 
 // ValidateCreate implements admission.Validator so a webhook will be registered for the type GardenerShootCluster.
-func (v *GardenerShootClusterCustomValidator) ValidateCreate(_ context.Context, _ *infrastructurev1alpha1.GardenerShootCluster) (admission.Warnings, error) {
+func (v *GardenerShootClusterCustomValidator) ValidateCreate(ctx context.Context, shootCluster *infrastructurev1alpha2.GardenerShootCluster) (admission.Warnings, error) {
+	// The Shoot does not exist yet, so the cluster is validated against the CloudProfile instead of a dry-run.
+	cluster, err := providerutil.ClusterForInfraCluster(ctx, v.Client, shootCluster)
+	if err != nil {
+		return nil, fmt.Errorf("could not get owner cluster: %w", err)
+	}
+	cloudProfileCtx, err := getCloudProfileContext(ctx, v.Client, v.GardenerClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if cloudProfileCtx == nil {
+		return nil, nil
+	}
+
+	if allErrs := validateRegion(cloudProfileCtx.cloudProfile, shootCluster.Spec.Region, field.NewPath("spec", "region")); len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerShootCluster").GroupKind(), shootCluster.Name, allErrs)
+	}
 	return nil, nil
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type GardenerShootCluster.
-func (v *GardenerShootClusterCustomValidator) ValidateUpdate(ctx context.Context, _, shootCluster *infrastructurev1alpha1.GardenerShootCluster) (admission.Warnings, error) {
+func (v *GardenerShootClusterCustomValidator) ValidateUpdate(ctx context.Context, _, shootCluster *infrastructurev1alpha2.GardenerShootCluster) (admission.Warnings, error) {
 	// For the update, we need to get the actual cluster.
 	cluster, err := util.GetOwnerCluster(ctx, v.Client, shootCluster.ObjectMeta)
 	if err != nil {
 		return nil, fmt.Errorf("could not get owner cluster: %w", err)
 	}
 	// Unfortunately, we need to get the control plane object to get the typed namespace of the shoot.
-	controlPlane := &controlplanev1alpha1.GardenerShootControlPlane{}
+	controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
 	if err := v.Client.Get(ctx, types.NamespacedName{
 		Name:      cluster.Spec.ControlPlaneRef.Name,
 		Namespace: cluster.Namespace,
@@ -81,10 +99,13 @@ func (v *GardenerShootClusterCustomValidator) ValidateUpdate(ctx context.Context
 
 	// During deletion, it can happen that the Shoot wants to be patched, when it does not exist anymore,
 	// therefore ignoring this error to prevent the reconciliation to be blocked.
-	return nil, client.IgnoreNotFound(v.GardenerClient.Update(ctx, shoot, &client.UpdateOptions{DryRun: []string{"All"}}))
+	if err := v.GardenerClient.Update(ctx, shoot, &client.UpdateOptions{DryRun: []string{"All"}}); err != nil {
+		return nil, providerutil.TranslateShootStatusError(client.IgnoreNotFound(err), shoot, shootCluster)
+	}
+	return nil, nil
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type GardenerShootCluster.
-func (v *GardenerShootClusterCustomValidator) ValidateDelete(_ context.Context, _ *infrastructurev1alpha1.GardenerShootCluster) (admission.Warnings, error) {
+func (v *GardenerShootClusterCustomValidator) ValidateDelete(_ context.Context, _ *infrastructurev1alpha2.GardenerShootCluster) (admission.Warnings, error) {
 	return nil, nil
 }
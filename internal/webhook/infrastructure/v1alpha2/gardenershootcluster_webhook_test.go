@@ -2,25 +2,25 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package v1alpha1
+package v1alpha2
 
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 )
 
 var _ = Describe("GardenerShootCluster Webhook", func() {
 	var (
-		obj       *infrastructurev1alpha1.GardenerShootCluster
-		oldObj    *infrastructurev1alpha1.GardenerShootCluster
+		obj       *infrastructurev1alpha2.GardenerShootCluster
+		oldObj    *infrastructurev1alpha2.GardenerShootCluster
 		validator GardenerShootClusterCustomValidator
 	)
 
 	BeforeEach(func() {
-		obj = &infrastructurev1alpha1.GardenerShootCluster{}
-		oldObj = &infrastructurev1alpha1.GardenerShootCluster{}
+		obj = &infrastructurev1alpha2.GardenerShootCluster{}
+		oldObj = &infrastructurev1alpha2.GardenerShootCluster{}
 		validator = GardenerShootClusterCustomValidator{}
 		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
 		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+	providerutil "github.com/gardener/cluster-api-provider-gardener/internal/util"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var gardenerworkerpoollog = logf.Log.WithName("gardenerworkerpool-resource")
+
+// SetupGardenerWorkerPoolWebhookWithManager registers the webhook for GardenerWorkerPool in the manager.
+func SetupGardenerWorkerPoolWebhookWithManager(mgr ctrl.Manager, gardenerClient client.Client) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha2.GardenerWorkerPool{}).
+		WithValidator(&GardenerWorkerPoolCustomValidator{
+			Client:         mgr.GetClient(),
+			GardenerClient: gardenerClient,
+		}).
+		WithDefaulter(&GardenerWorkerPoolCustomDefaulter{
+			Client:         mgr.GetClient(),
+			GardenerClient: gardenerClient,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha2-gardenerworkerpool,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gardenerworkerpools,verbs=create;update,versions=v1alpha2,name=mgardenerworkerpool-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// GardenerWorkerPoolCustomDefaulter struct is responsible for defaulting the GardenerWorkerPool resource.
+type GardenerWorkerPoolCustomDefaulter struct {
+	Client         client.Client
+	GardenerClient client.Client
+}
+
+var _ admission.Defaulter[*infrastructurev1alpha2.GardenerWorkerPool] = &GardenerWorkerPoolCustomDefaulter{}
+
+// Default implements admission.Defaulter so a webhook will be registered for the type GardenerWorkerPool.
+// The machine image and volume type are defaulted from the CloudProfile of the Cluster. If the GardenerWorkerPool cannot
+// be related to a Cluster yet, nothing is defaulted and Gardener applies its own defaults when the Shoot is created.
+func (d *GardenerWorkerPoolCustomDefaulter) Default(ctx context.Context, workerPool *infrastructurev1alpha2.GardenerWorkerPool) error {
+	if d.GardenerClient == nil {
+		return nil
+	}
+
+	cluster, err := providerutil.ClusterForWorkerPool(ctx, d.Client, workerPool)
+	if err != nil {
+		return err
+	}
+	cloudProfileCtx, err := getCloudProfileContext(ctx, d.Client, d.GardenerClient, cluster)
+	if err != nil || cloudProfileCtx == nil {
+		return err
+	}
+
+	return defaultWorkerPoolFromCloudProfile(workerPool, cloudProfileCtx.cloudProfile, cloudProfileCtx.region)
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha2-gardenerworkerpool,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gardenerworkerpools,verbs=create;update,versions=v1alpha2,name=vgardenerworkerpool-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:rbac:groups=core.gardener.cloud,resources=cloudprofiles;namespacedcloudprofiles,verbs=get;list;watch
+
+// GardenerWorkerPoolCustomValidator struct is responsible for validating the GardenerWorkerPool resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type GardenerWorkerPoolCustomValidator struct {
+	Client         client.Client
+	GardenerClient client.Client
+}
+
+var _ admission.Validator[*infrastructurev1alpha2.GardenerWorkerPool] = &GardenerWorkerPoolCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type GardenerWorkerPool.
+func (v *GardenerWorkerPoolCustomValidator) ValidateCreate(ctx context.Context, workerPool *infrastructurev1alpha2.GardenerWorkerPool) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	allErrs := validateWorkerPoolSpec(&workerPool.Spec, specPath)
+
+	machinePool, err := providerutil.MachinePoolForWorkerPool(ctx, v.Client, workerPool)
+	if err != nil {
+		return nil, err
+	}
+	_, errs := providerutil.AutoscalerBoundsFromMachinePool(machinePool, &workerPool.Spec, specPath)
+	allErrs = append(allErrs, errs...)
+
+	// The Shoot does not exist yet, so the worker pool is validated against the CloudProfile instead of a dry-run.
+	cluster, err := providerutil.ClusterForWorkerPool(ctx, v.Client, workerPool)
+	if err != nil {
+		return nil, err
+	}
+	if cluster != nil {
+		controlPlane, err := providerutil.ControlPlaneForCluster(ctx, v.Client, cluster)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, validateKubernetesVersion(&workerPool.Spec, machinePool, controlPlane, specPath)...)
+	}
+	cloudProfileCtx, err := getCloudProfileContext(ctx, v.Client, v.GardenerClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+	var warnings admission.Warnings
+	if cloudProfileCtx != nil {
+		allErrs = append(allErrs, validateWorkerPoolAgainstCloudProfile(&workerPool.Spec, cloudProfileCtx.cloudProfile, cloudProfileCtx.region, specPath)...)
+		warnings = workerPoolWarnings(&workerPool.Spec, machinePool, cloudProfileCtx.cloudProfile, specPath)
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind(), workerPool.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type GardenerWorkerPool.
+func (v *GardenerWorkerPoolCustomValidator) ValidateUpdate(ctx context.Context, _, workerPool *infrastructurev1alpha2.GardenerWorkerPool) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	allErrs := validateWorkerPoolSpec(&workerPool.Spec, specPath)
+
+	machinePool, err := providerutil.MachinePoolForWorkerPool(ctx, v.Client, workerPool)
+	if err != nil {
+		return nil, err
+	}
+	bounds, errs := providerutil.AutoscalerBoundsFromMachinePool(machinePool, &workerPool.Spec, specPath)
+	allErrs = append(allErrs, errs...)
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind(), workerPool.Name, allErrs)
+	}
+	if machinePool == nil {
+		return nil, nil
+	}
+	warnings := v.warnings(ctx, workerPool, machinePool, specPath)
+
+	cluster, err := util.GetOwnerCluster(ctx, v.Client, machinePool.ObjectMeta)
+	if err != nil {
+		return warnings, err
+	}
+	if cluster == nil {
+		return warnings, nil
+	}
+
+	controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: cluster.Spec.ControlPlaneRef.Name, Namespace: cluster.Namespace}, controlPlane); err != nil {
+		return warnings, client.IgnoreNotFound(err)
+	}
+	if allErrs := validateKubernetesVersion(&workerPool.Spec, machinePool, controlPlane, specPath); len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(infrastructurev1alpha2.SchemeGroupVersion.WithKind("GardenerWorkerPool").GroupKind(), workerPool.Name, allErrs)
+	}
+
+	shoot := &v1beta1.Shoot{}
+	if err := v.GardenerClient.Get(ctx, providerutil.ShootNameFromCAPIResources(*cluster, *controlPlane), shoot); err != nil {
+		return warnings, client.IgnoreNotFound(err)
+	}
+
+	// The controller applies the autoscaler annotations and the Kubernetes version of the MachinePool before syncing the
+	// worker pool to the Shoot.
+	workerPool = workerPool.DeepCopy()
+	bounds.Apply(&workerPool.Spec)
+	providerutil.ApplyWorkerPoolKubernetesVersion(&workerPool.Spec, providerutil.MachinePoolKubernetesVersion(machinePool))
+	providerutil.SyncShootSpecFromWorkerPool(shoot, workerPool)
+
+	if err := v.GardenerClient.Update(ctx, shoot, &client.UpdateOptions{DryRun: []string{"All"}}); err != nil {
+		return warnings, providerutil.TranslateShootStatusError(client.IgnoreNotFound(err), shoot, workerPool)
+	}
+	return warnings, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type GardenerWorkerPool.
+func (v *GardenerWorkerPoolCustomValidator) ValidateDelete(_ context.Context, _ *infrastructurev1alpha2.GardenerWorkerPool) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateKubernetesVersion validates the Kubernetes version of the worker pool against the one of the control plane.
+// The version of the MachinePool takes precedence over the one of the spec, as it is applied by the controller.
+func validateKubernetesVersion(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, machinePool *clusterv1beta2.MachinePool, controlPlane *controlplanev1alpha2.GardenerShootControlPlane, specPath *field.Path) field.ErrorList {
+	if controlPlane == nil {
+		return nil
+	}
+	version := providerutil.MachinePoolKubernetesVersion(machinePool)
+	if version == "" {
+		version = providerutil.WorkerPoolKubernetesVersion(spec)
+	}
+	return providerutil.ValidateWorkerPoolKubernetesVersion(version, controlPlane.Spec.Kubernetes.Version, specPath.Child("kubernetes", "version"))
+}
+
+// warnings resolves the CloudProfile of the Cluster of the given GardenerWorkerPool and returns the warnings of
+// workerPoolWarnings. The CloudProfile is skipped if it cannot be resolved, as warnings must not block the admission.
+func (v *GardenerWorkerPoolCustomValidator) warnings(ctx context.Context, workerPool *infrastructurev1alpha2.GardenerWorkerPool, machinePool *clusterv1beta2.MachinePool, specPath *field.Path) admission.Warnings {
+	if v.GardenerClient == nil {
+		return nil
+	}
+	cluster, err := providerutil.ClusterForWorkerPool(ctx, v.Client, workerPool)
+	if err != nil {
+		return nil
+	}
+	cloudProfileCtx, err := getCloudProfileContext(ctx, v.Client, v.GardenerClient, cluster)
+	if err != nil || cloudProfileCtx == nil {
+		return nil
+	}
+	return workerPoolWarnings(&workerPool.Spec, machinePool, cloudProfileCtx.cloudProfile, specPath)
+}
+
+// workerPoolWarnings returns warnings for the machine image version and the Kubernetes version of the worker pool which
+// are deprecated or expire according to the CloudProfile. The version of the MachinePool takes precedence over the one of
+// the spec, as it is applied by the controller.
+func workerPoolWarnings(spec *infrastructurev1alpha2.GardenerWorkerPoolSpec, machinePool *clusterv1beta2.MachinePool, cloudProfile *v1beta1.CloudProfile, specPath *field.Path) admission.Warnings {
+	warnings := providerutil.MachineImageWarnings(cloudProfile, spec.Machine.Image, specPath.Child("machine", "image", "version"))
+
+	version := providerutil.MachinePoolKubernetesVersion(machinePool)
+	if version == "" {
+		version = providerutil.WorkerPoolKubernetesVersion(spec)
+	}
+	return append(warnings, providerutil.KubernetesVersionWarnings(cloudProfile, version, specPath.Child("kubernetes", "version"))...)
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+
+	gardenercorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
+)
+
+var _ = Describe("GardenerWorkerPool Webhook", func() {
+	var (
+		obj       *infrastructurev1alpha2.GardenerWorkerPool
+		oldObj    *infrastructurev1alpha2.GardenerWorkerPool
+		validator GardenerWorkerPoolCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &infrastructurev1alpha2.GardenerWorkerPool{}
+		oldObj = &infrastructurev1alpha2.GardenerWorkerPool{}
+		validator = GardenerWorkerPoolCustomValidator{}
+		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
+		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
+		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
+		// TODO (user): Add any setup logic common to all tests
+	})
+
+	AfterEach(func() {
+		// TODO (user): Add any teardown logic common to all tests
+	})
+
+	Context("When creating or updating GardenerWorkerPool under Validating Webhook", func() {
+		// TODO (user): Add logic for validating webhooks
+		// Example:
+		// It("Should deny creation if a required field is missing", func() {
+		//     By("simulating an invalid creation scenario")
+		//     obj.SomeRequiredField = ""
+		//     Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		// })
+		//
+		// It("Should admit creation if all required fields are present", func() {
+		//     By("simulating an invalid creation scenario")
+		//     obj.SomeRequiredField = "valid_value"
+		//     Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		// })
+		//
+		// It("Should validate updates correctly", func() {
+		//     By("simulating a valid update scenario")
+		//     oldObj.SomeRequiredField = "updated_value"
+		//     obj.SomeRequiredField = "updated_value"
+		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		// })
+
+		It("should warn about a deprecated machine image and Kubernetes version", func() {
+			cloudProfile := &gardenercorev1beta1.CloudProfile{
+				Spec: gardenercorev1beta1.CloudProfileSpec{
+					Kubernetes: gardenercorev1beta1.KubernetesSettings{
+						Versions: []gardenercorev1beta1.ExpirableVersion{
+							{Version: "1.32.4", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated)},
+							{Version: "1.33.2"},
+						},
+					},
+					MachineImages: []gardenercorev1beta1.MachineImage{{
+						Name: "gardenlinux",
+						Versions: []gardenercorev1beta1.MachineImageVersion{
+							{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1.0.0", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated)}},
+						},
+					}},
+				},
+			}
+			obj.Spec.Machine.Image = &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1.0.0")}
+			obj.Spec.Kubernetes = &gardenercorev1beta1.WorkerKubernetes{Version: ptr.To("1.32.4")}
+			specPath := field.NewPath("spec")
+
+			Expect(workerPoolWarnings(&obj.Spec, nil, cloudProfile, specPath)).To(ConsistOf(
+				"spec.machine.image.version: the version 1.0.0 of machine image gardenlinux is deprecated. Consider updating to a supported version.",
+				"spec.kubernetes.version: the Kubernetes version 1.32.4 is deprecated. Consider updating to a supported version.",
+			))
+
+			machinePool := &clusterv1beta2.MachinePool{}
+			machinePool.Spec.Template.Spec.Version = "v1.33.2"
+			Expect(workerPoolWarnings(&obj.Spec, machinePool, cloudProfile, specPath)).To(ConsistOf(
+				"spec.machine.image.version: the version 1.0.0 of machine image gardenlinux is deprecated. Consider updating to a supported version.",
+			))
+		})
+
+		Context("against the CloudProfile", func() {
+			var (
+				ctx          context.Context
+				cloudProfile *gardenercorev1beta1.CloudProfile
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				scheme := runtime.NewScheme()
+				Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+				Expect(clusterv1beta2.AddToScheme(scheme)).To(Succeed())
+				Expect(controlplanev1alpha2.AddToScheme(scheme)).To(Succeed())
+				Expect(infrastructurev1alpha2.AddToScheme(scheme)).To(Succeed())
+
+				obj.ObjectMeta = metav1.ObjectMeta{Name: "foo-worker", Namespace: "default"}
+				obj.Spec.Machine = gardenercorev1beta1.Machine{
+					Type:  "large",
+					Image: &gardenercorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1.0.0")},
+				}
+				cluster := &clusterv1beta2.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+					Spec: clusterv1beta2.ClusterSpec{ControlPlaneRef: clusterv1beta2.ContractVersionedObjectReference{
+						APIGroup: controlplanev1alpha2.GroupVersion.Group,
+						Kind:     "GardenerShootControlPlane",
+						Name:     "foo",
+					}},
+				}
+				machinePool := &clusterv1beta2.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "foo-worker", Namespace: "default"}}
+				machinePool.Spec.ClusterName = "foo"
+				machinePool.Spec.Template.Spec.InfrastructureRef = clusterv1beta2.ContractVersionedObjectReference{
+					APIGroup: infrastructurev1alpha2.SchemeGroupVersion.Group,
+					Kind:     "GardenerWorkerPool",
+					Name:     "foo-worker",
+				}
+				controlPlane := &controlplanev1alpha2.GardenerShootControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+					Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
+						ProjectNamespace: "garden-foo",
+						CloudProfile:     &gardenercorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "aws"},
+					},
+				}
+				validator.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machinePool, controlPlane).Build()
+				validator.GardenerClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+
+				cloudProfile = &gardenercorev1beta1.CloudProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+					Spec: gardenercorev1beta1.CloudProfileSpec{
+						MachineTypes: []gardenercorev1beta1.MachineType{{Name: "large"}},
+						MachineImages: []gardenercorev1beta1.MachineImage{{
+							Name: "gardenlinux",
+							Versions: []gardenercorev1beta1.MachineImageVersion{
+								{ExpirableVersion: gardenercorev1beta1.ExpirableVersion{Version: "1.0.0", Classification: ptr.To(gardenercorev1beta1.ClassificationDeprecated)}},
+							},
+						}},
+					},
+				}
+			})
+
+			It("should skip the validation if the CloudProfile does not exist yet", func() {
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+
+			It("should warn about a deprecated machine image of the CloudProfile", func() {
+				Expect(validator.GardenerClient.Create(ctx, cloudProfile)).To(Succeed())
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf(
+					"spec.machine.image.version: the version 1.0.0 of machine image gardenlinux is deprecated. Consider updating to a supported version.",
+				))
+			})
+
+			It("should reject a machine type which is not offered by the CloudProfile", func() {
+				Expect(validator.GardenerClient.Create(ctx, cloudProfile)).To(Succeed())
+				obj.Spec.Machine.Type = "huge"
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(MatchError(ContainSubstring("spec.machine.type: Unsupported value: \"huge\"")))
+			})
+		})
+	})
+
+})
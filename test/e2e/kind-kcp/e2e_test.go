@@ -24,8 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/cluster-api-provider-gardener/api"
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 	"github.com/gardener/cluster-api-provider-gardener/test/utils"
 )
 
@@ -58,7 +58,7 @@ var _ = Describe("Manager", Ordered, Label("kind-kcp"), func() {
 			By("running the controller")
 			Expect(os.Setenv("ENABLE_WEBHOOKS", "false")).To(Succeed())
 			if err := retry.Until(ctx, time.Minute, func(_ context.Context) (done bool, err error) {
-				// Retry starting the controller. It may fail initially while KCP reconciles the APIExport virtual workspace endpoints.
+				// Retry starting the controller. It may fail initially while KCP sets up the provider workspace.
 				cmd = exec.Command("go", "run", "cmd/main.go", "--kubeconfig", kubeconfigKcp, "-gardener-kubeconfig", utils.KubeconfigGardener)
 				controllerOutput, err := utils.Run(cmd)
 				if err == nil {
@@ -128,7 +128,7 @@ var _ = Describe("Manager", Ordered, Label("kind-kcp"), func() {
 
 			namePrefix := "e2e-kcp-"
 
-			controlPlaneSpec := &controlplanev1alpha1.GardenerShootControlPlane{
+			controlPlaneSpec := &controlplanev1alpha2.GardenerShootControlPlane{
 				ObjectMeta: metav1.ObjectMeta{
 					GenerateName: namePrefix,
 					Namespace:    "default",
@@ -137,9 +137,9 @@ var _ = Describe("Manager", Ordered, Label("kind-kcp"), func() {
 						"app.kubernetes.io/managed-by": "kustomize",
 					},
 				},
-				Spec: controlplanev1alpha1.GardenerShootControlPlaneSpec{
+				Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
 					ProjectNamespace: "garden-local",
-					Provider:         controlplanev1alpha1.ProviderGSCP{Type: "local"},
+					Provider:         controlplanev1alpha2.ProviderGSCP{Type: "local"},
 					Kubernetes:       gardenercorev1beta1.Kubernetes{Version: "1"},
 					CloudProfile:     &gardenercorev1beta1.CloudProfileReference{Name: "local"},
 					Workerless:       true,
@@ -151,7 +151,7 @@ var _ = Describe("Manager", Ordered, Label("kind-kcp"), func() {
 				return kcpClient.Client().Create(ctx, controlPlaneSpec)
 			}).Should(Succeed())
 
-			infraClusterSpec := &infrastructurev1alpha1.GardenerShootCluster{
+			infraClusterSpec := &infrastructurev1alpha2.GardenerShootCluster{
 				ObjectMeta: metav1.ObjectMeta{
 					GenerateName: namePrefix,
 					Namespace:    "default",
@@ -160,7 +160,7 @@ var _ = Describe("Manager", Ordered, Label("kind-kcp"), func() {
 						"app.kubernetes.io/managed-by": "kustomize",
 					},
 				},
-				Spec: infrastructurev1alpha1.GardenerShootClusterSpec{
+				Spec: infrastructurev1alpha2.GardenerShootClusterSpec{
 					Region:      "local",
 					Hibernation: &gardenercorev1beta1.Hibernation{Enabled: ptr.To(true)},
 				},
@@ -22,8 +22,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/cluster-api-provider-gardener/api"
-	controlplanev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha1"
-	infrastructurev1alpha1 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha1"
+	controlplanev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/controlplane/v1alpha2"
+	infrastructurev1alpha2 "github.com/gardener/cluster-api-provider-gardener/api/infrastructure/v1alpha2"
 	"github.com/gardener/cluster-api-provider-gardener/test/utils"
 )
 
@@ -181,7 +181,7 @@ var _ = Describe("Manager", Ordered, Label("kind"), func() {
 
 			namePrefix := "e2e-kind-"
 
-			controlPlaneSpec := &controlplanev1alpha1.GardenerShootControlPlane{
+			controlPlaneSpec := &controlplanev1alpha2.GardenerShootControlPlane{
 				ObjectMeta: metav1.ObjectMeta{
 					GenerateName: namePrefix,
 					Namespace:    "default",
@@ -190,9 +190,9 @@ var _ = Describe("Manager", Ordered, Label("kind"), func() {
 						"app.kubernetes.io/managed-by": "kustomize",
 					},
 				},
-				Spec: controlplanev1alpha1.GardenerShootControlPlaneSpec{
+				Spec: controlplanev1alpha2.GardenerShootControlPlaneSpec{
 					ProjectNamespace: "garden-local",
-					Provider:         controlplanev1alpha1.ProviderGSCP{Type: "local"},
+					Provider:         controlplanev1alpha2.ProviderGSCP{Type: "local"},
 					Kubernetes:       gardenercorev1beta1.Kubernetes{Version: "1"},
 					CloudProfile:     &gardenercorev1beta1.CloudProfileReference{Name: "local"},
 					Workerless:       true,
@@ -204,7 +204,7 @@ var _ = Describe("Manager", Ordered, Label("kind"), func() {
 				return clusterClient.Client().Create(ctx, controlPlaneSpec)
 			}).Should(Succeed())
 
-			infraClusterSpec := &infrastructurev1alpha1.GardenerShootCluster{
+			infraClusterSpec := &infrastructurev1alpha2.GardenerShootCluster{
 				ObjectMeta: metav1.ObjectMeta{
 					GenerateName: namePrefix,
 					Namespace:    "default",
@@ -213,7 +213,7 @@ var _ = Describe("Manager", Ordered, Label("kind"), func() {
 						"app.kubernetes.io/managed-by": "kustomize",
 					},
 				},
-				Spec: infrastructurev1alpha1.GardenerShootClusterSpec{
+				Spec: infrastructurev1alpha2.GardenerShootClusterSpec{
 					Region:      "local",
 					Hibernation: &gardenercorev1beta1.Hibernation{Enabled: ptr.To(true)},
 				},